@@ -2,23 +2,37 @@ package main
 
 import (
 	"app/internal/application"
+	"app/internal/config"
+	"errors"
+	"flag"
 	"fmt"
+	"os"
 )
 
 func main() {
 	// env
-	// ...
+	// - config: defaults < config file < environment variables < flags
+	cfg, opts, err := config.Load(os.Args[1:], os.LookupEnv)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Println(err)
+			os.Exit(2)
+		}
+		return
+	}
+	if opts.PrintConfig {
+		if err := config.Print(os.Stdout, cfg); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// app
-	// - config
-	cfg := &application.ConfigServerChi{
-		ServerAddress: ":8080",
-		LoaderFilePath: "docs/db/vehicles_100.json",
-	}
 	app := application.NewServerChi(cfg)
-	// - run
+	// - run: a server that could not start or stop cleanly exits non-zero
 	if err := app.Run(); err != nil {
 		fmt.Println(err)
-		return
+		os.Exit(1)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+// Scopes granted to API keys
+const (
+	// ScopeVehiclesRead allows the read-only vehicle routes
+	ScopeVehiclesRead = "vehicles:read"
+	// ScopeVehiclesWrite allows the routes that create or update vehicles
+	ScopeVehiclesWrite = "vehicles:write"
+	// ScopeVehiclesDelete allows the routes that delete vehicles
+	ScopeVehiclesDelete = "vehicles:delete"
+	// ScopeAdmin allows the administrative routes
+	ScopeAdmin = "admin"
+)
+
+// Scopes are all the scopes an API key can be granted
+var Scopes = []string{ScopeVehiclesRead, ScopeVehiclesWrite, ScopeVehiclesDelete, ScopeAdmin}
+
+// APIKey is a struct that represents an API key, only the hash of its secret is kept
+type APIKey struct {
+	// ID is the public identifier of the key
+	ID string
+	// Name is a description of the owner of the key
+	Name string
+	// Scopes are the permissions granted to the key
+	Scopes []string
+	// Tenant is the tenant the key is bound to, empty if it may act on any tenant
+	Tenant string
+	// Hash is the SHA-256 hex digest of the secret
+	Hash string
+	// PreviousHash is the digest of the secret replaced by the last rotation
+	PreviousHash string
+	// PreviousExpiresAt is the time the replaced secret stops being accepted
+	PreviousExpiresAt time.Time
+	// CreatedAt is the time the key was issued
+	CreatedAt time.Time
+	// RotatedAt is the time of the last rotation, zero if never rotated
+	RotatedAt time.Time
+	// ExpiresAt is the time the key stops being accepted, zero if it does not expire
+	ExpiresAt time.Time
+	// RevokedAt is the time the key was revoked, zero if it is active
+	RevokedAt time.Time
+}
+
+// HasScope is a method that reports whether the key was granted a scope
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyStore is an interface that represents a store of API keys
+type APIKeyStore interface {
+	// Authenticate is a method that returns the active key whose secret is given
+	Authenticate(secret string) (k APIKey, err error)
+
+	// FindAll is a method that returns all the keys, revoked ones included
+	FindAll() (k []APIKey, err error)
+
+	// Issue is a method that creates a key returning it with its secret, which is not stored,
+	// an empty tenant issues a key for any tenant and a zero ttl a key that does not expire
+	Issue(name string, tenant string, scopes []string, ttl time.Duration) (k APIKey, secret string, err error)
+
+	// Rotate is a method that replaces the secret of a key, the previous one is still accepted during grace
+	Rotate(id string, grace time.Duration) (k APIKey, secret string, err error)
+
+	// Revoke is a method that revokes a key
+	Revoke(id string) (err error)
+}
+
+// Errors in API keys
+var (
+	ErrorAPIKeyMissing      = errors.New("API key required")
+	ErrorAPIKeyInvalid      = errors.New("Invalid API key")
+	ErrorAPIKeyNotFound     = errors.New("API key not found")
+	ErrorAPIKeyInvalidScope = errors.New("Invalid API key scope")
+	ErrorAPIKeyInvalidName  = errors.New("Invalid API key name")
+	ErrorAPIKeyStore        = errors.New("API key store could not be saved")
+)
@@ -1,42 +1,332 @@
 package application
 
 import (
+	"app/internal"
+	"app/internal/auth"
+	"app/internal/graphql"
 	"app/internal/handler"
 	"app/internal/loader"
+	"app/internal/logging"
+	"app/internal/metrics"
+	"app/internal/normalize"
+	"app/internal/openapi"
+	"app/internal/ratelimit"
+	"app/internal/registration"
+	"app/internal/reloader"
 	"app/internal/repository"
+	"app/internal/rpc"
 	"app/internal/service"
+	"app/internal/tracing"
+	"app/internal/vin"
+	"app/internal/vocabulary"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+)
+
+// StorageBackendMemory is the storage backend that keeps the vehicles in memory
+const StorageBackendMemory = "memory"
+
+// Errors in the application
+var (
+	ErrorStorageBackendUnsupported = errors.New("Unsupported storage backend")
+	ErrorServerNotRunning          = errors.New("Server is not running")
+	ErrorWriteTimeoutTooShort      = errors.New("Write timeout is shorter than the handler timeouts")
 )
 
 // ConfigServerChi is a struct that represents the configuration for ServerChi
 type ConfigServerChi struct {
 	// ServerAddress is the address where the server will be listening
 	ServerAddress string
+	// GRPCAddress is the address where the gRPC server will be listening, empty disables it
+	GRPCAddress string
+	// ServerReadTimeout is the maximum duration for reading an entire request
+	ServerReadTimeout time.Duration
+	// ServerWriteTimeout is the maximum duration before timing out writes of a response
+	ServerWriteTimeout time.Duration
+	// ServerIdleTimeout is the maximum time to wait for the next request on keep-alive connections
+	ServerIdleTimeout time.Duration
+	// ServerShutdownTimeout is the deadline to drain in-flight requests on shutdown
+	ServerShutdownTimeout time.Duration
+	// ServerRequestTimeout is the deadline to handle a request on the vehicle routes
+	ServerRequestTimeout time.Duration
+	// ServerBatchTimeout is the deadline to handle a request on the batch route
+	ServerBatchTimeout time.Duration
+	// ServerMaxBodyBytes is the largest request body read, a larger one is answered 413
+	ServerMaxBodyBytes int
+	// StorageBackend is the storage used by the repository, only "memory" is supported
+	StorageBackend string
+	// LogLevel is the minimum level of the logs: debug, info, warn or error
+	LogLevel string
+	// LogFormat is the format of the logs: json or text
+	LogFormat string
+	// AuthKeyFile is the JSON file with the hashed API keys, empty disables authentication
+	AuthKeyFile string
+	// AuthRotationGrace is the time the previous secret of a rotated API key is still accepted
+	AuthRotationGrace time.Duration
+	// AuthJWTHMACSecretFile is the file with the shared secret of HS256 tokens
+	AuthJWTHMACSecretFile string
+	// AuthJWTRSAPublicKeyFile is the PEM file with the public key of RS256 tokens
+	AuthJWTRSAPublicKeyFile string
+	// AuthJWTJWKSFile is the JSON Web Key Set file with the token keys
+	AuthJWTJWKSFile string
+	// AuthJWTIssuer is the required iss claim of the tokens, empty accepts any
+	AuthJWTIssuer string
+	// AuthJWTAudience is the required aud claim of the tokens, empty accepts any
+	AuthJWTAudience string
+	// AuthJWTRolesClaim is the claim of the tokens holding the roles
+	AuthJWTRolesClaim string
+	// AuthJWTTenantClaim is the claim of the tokens holding the tenant they are bound to
+	AuthJWTTenantClaim string
+	// RateLimitRead are the limits of each client on the routes that read vehicles, zero disables them
+	RateLimitRead ratelimit.Limit
+	// RateLimitWrite are the limits of each client on the routes that create, update or delete a vehicle
+	RateLimitWrite ratelimit.Limit
+	// RateLimitBatch are the limits of each client on the batch route
+	RateLimitBatch ratelimit.Limit
+	// GraphQLMaxDepth is the maximum depth of nested fields of a GraphQL operation
+	GraphQLMaxDepth int
+	// GraphQLMaxComplexity is the maximum complexity of a GraphQL operation
+	GraphQLMaxComplexity int
+	// TracingExporter is the destination of the request spans: none, stdout or file
+	TracingExporter string
+	// TracingFilePath is the file the spans are appended to by the file exporter
+	TracingFilePath string
 	// LoaderFilePath is the path to the file that contains the vehicles
 	LoaderFilePath string
+	// LoaderSources are files, directories or glob patterns merged into the fleet (replaces LoaderFilePath when set)
+	LoaderSources []string
+	// LoaderTenantSources are tenant=source entries loading a fleet per tenant (replaces LoaderFilePath and LoaderSources when set)
+	LoaderTenantSources []string
+	// LoaderMergePolicy is the policy applied on collisions between sources: first-wins, last-wins, fail or renumber
+	LoaderMergePolicy string
+	// ReloadInterval is the time between checks of the loader files for changes, zero disables the watcher
+	ReloadInterval time.Duration
+	// NormalizeSynonyms are field:alias=canonical entries compared as the same value by the finders
+	NormalizeSynonyms []string
+	// VocabularyFuelTypes are the fuel types allowed for the vehicles written
+	VocabularyFuelTypes []string
+	// VocabularyTransmissions are the transmissions allowed for the vehicles written
+	VocabularyTransmissions []string
+	// CatalogFilePath is the JSON file with the brands and models the vehicles are linked to, empty disables the catalog
+	CatalogFilePath string
+	// RegistrationCountries are the countries of the formats allowed for the registrations of the vehicles written
+	RegistrationCountries []string
+	// RegistrationFormats are country=regexp entries of formats added to the built-in ones or replacing them
+	RegistrationFormats []string
 }
 
-// NewServerChi is a function that returns a new instance of ServerChi
+// DefaultConfigServerChi is a function that returns the configuration of the settings left empty, the features
+// disabled by an empty setting (gRPC, hot reload, rate limits, catalog) are disabled by default
+func DefaultConfigServerChi() *ConfigServerChi {
+	return &ConfigServerChi{
+		ServerAddress:           ":8080",
+		ServerReadTimeout:       5 * time.Second,
+		ServerWriteTimeout:      60 * time.Second,
+		ServerIdleTimeout:       60 * time.Second,
+		ServerShutdownTimeout:   15 * time.Second,
+		ServerRequestTimeout:    5 * time.Second,
+		ServerBatchTimeout:      30 * time.Second,
+		ServerMaxBodyBytes:      4 << 20,
+		StorageBackend:          StorageBackendMemory,
+		LogLevel:                "info",
+		LogFormat:               logging.FormatJSON,
+		AuthRotationGrace:       time.Hour,
+		AuthJWTRolesClaim:       "roles",
+		AuthJWTTenantClaim:      "tenant",
+		GraphQLMaxDepth:         8,
+		GraphQLMaxComplexity:    1000,
+		TracingExporter:         tracing.ExporterNone,
+		LoaderFilePath:          "docs/db/vehicles_100.json",
+		LoaderMergePolicy:       string(loader.MergePolicyFirstWins),
+		NormalizeSynonyms:       normalize.DefaultSynonyms,
+		VocabularyFuelTypes:     vocabulary.DefaultFuelTypes,
+		VocabularyTransmissions: vocabulary.DefaultTransmissions,
+		RegistrationCountries:   registration.DefaultCountries,
+	}
+}
+
+// NewServerChi is a function that returns a new instance of ServerChi, the settings left empty in cfg take
+// the value of DefaultConfigServerChi
 func NewServerChi(cfg *ConfigServerChi) *ServerChi {
 	// default values
-	defaultConfig := &ConfigServerChi{
-		ServerAddress: ":8080",
-	}
+	defaultConfig := DefaultConfigServerChi()
 	if cfg != nil {
 		if cfg.ServerAddress != "" {
 			defaultConfig.ServerAddress = cfg.ServerAddress
 		}
+		if cfg.GRPCAddress != "" {
+			defaultConfig.GRPCAddress = cfg.GRPCAddress
+		}
+		if cfg.ServerReadTimeout > 0 {
+			defaultConfig.ServerReadTimeout = cfg.ServerReadTimeout
+		}
+		if cfg.ServerWriteTimeout > 0 {
+			defaultConfig.ServerWriteTimeout = cfg.ServerWriteTimeout
+		}
+		if cfg.ServerIdleTimeout > 0 {
+			defaultConfig.ServerIdleTimeout = cfg.ServerIdleTimeout
+		}
+		if cfg.ServerShutdownTimeout > 0 {
+			defaultConfig.ServerShutdownTimeout = cfg.ServerShutdownTimeout
+		}
+		if cfg.ServerRequestTimeout > 0 {
+			defaultConfig.ServerRequestTimeout = cfg.ServerRequestTimeout
+		}
+		if cfg.ServerBatchTimeout > 0 {
+			defaultConfig.ServerBatchTimeout = cfg.ServerBatchTimeout
+		}
+		if cfg.ServerMaxBodyBytes > 0 {
+			defaultConfig.ServerMaxBodyBytes = cfg.ServerMaxBodyBytes
+		}
+		if cfg.StorageBackend != "" {
+			defaultConfig.StorageBackend = cfg.StorageBackend
+		}
+		if cfg.LogLevel != "" {
+			defaultConfig.LogLevel = cfg.LogLevel
+		}
+		if cfg.LogFormat != "" {
+			defaultConfig.LogFormat = cfg.LogFormat
+		}
+		if cfg.AuthKeyFile != "" {
+			defaultConfig.AuthKeyFile = cfg.AuthKeyFile
+		}
+		if cfg.AuthRotationGrace > 0 {
+			defaultConfig.AuthRotationGrace = cfg.AuthRotationGrace
+		}
+		if cfg.AuthJWTHMACSecretFile != "" {
+			defaultConfig.AuthJWTHMACSecretFile = cfg.AuthJWTHMACSecretFile
+		}
+		if cfg.AuthJWTRSAPublicKeyFile != "" {
+			defaultConfig.AuthJWTRSAPublicKeyFile = cfg.AuthJWTRSAPublicKeyFile
+		}
+		if cfg.AuthJWTJWKSFile != "" {
+			defaultConfig.AuthJWTJWKSFile = cfg.AuthJWTJWKSFile
+		}
+		if cfg.AuthJWTIssuer != "" {
+			defaultConfig.AuthJWTIssuer = cfg.AuthJWTIssuer
+		}
+		if cfg.AuthJWTAudience != "" {
+			defaultConfig.AuthJWTAudience = cfg.AuthJWTAudience
+		}
+		if cfg.AuthJWTRolesClaim != "" {
+			defaultConfig.AuthJWTRolesClaim = cfg.AuthJWTRolesClaim
+		}
+		if cfg.AuthJWTTenantClaim != "" {
+			defaultConfig.AuthJWTTenantClaim = cfg.AuthJWTTenantClaim
+		}
+		if cfg.RateLimitRead != (ratelimit.Limit{}) {
+			defaultConfig.RateLimitRead = cfg.RateLimitRead
+		}
+		if cfg.RateLimitWrite != (ratelimit.Limit{}) {
+			defaultConfig.RateLimitWrite = cfg.RateLimitWrite
+		}
+		if cfg.RateLimitBatch != (ratelimit.Limit{}) {
+			defaultConfig.RateLimitBatch = cfg.RateLimitBatch
+		}
+		if cfg.GraphQLMaxDepth > 0 {
+			defaultConfig.GraphQLMaxDepth = cfg.GraphQLMaxDepth
+		}
+		if cfg.GraphQLMaxComplexity > 0 {
+			defaultConfig.GraphQLMaxComplexity = cfg.GraphQLMaxComplexity
+		}
+		if cfg.TracingExporter != "" {
+			defaultConfig.TracingExporter = cfg.TracingExporter
+		}
+		if cfg.TracingFilePath != "" {
+			defaultConfig.TracingFilePath = cfg.TracingFilePath
+		}
 		if cfg.LoaderFilePath != "" {
 			defaultConfig.LoaderFilePath = cfg.LoaderFilePath
 		}
+		if len(cfg.LoaderSources) > 0 {
+			defaultConfig.LoaderSources = cfg.LoaderSources
+		}
+		if len(cfg.LoaderTenantSources) > 0 {
+			defaultConfig.LoaderTenantSources = cfg.LoaderTenantSources
+		}
+		if cfg.LoaderMergePolicy != "" {
+			defaultConfig.LoaderMergePolicy = cfg.LoaderMergePolicy
+		}
+		if cfg.ReloadInterval > 0 {
+			defaultConfig.ReloadInterval = cfg.ReloadInterval
+		}
+		if len(cfg.NormalizeSynonyms) > 0 {
+			defaultConfig.NormalizeSynonyms = cfg.NormalizeSynonyms
+		}
+		if len(cfg.VocabularyFuelTypes) > 0 {
+			defaultConfig.VocabularyFuelTypes = cfg.VocabularyFuelTypes
+		}
+		if len(cfg.VocabularyTransmissions) > 0 {
+			defaultConfig.VocabularyTransmissions = cfg.VocabularyTransmissions
+		}
+		if cfg.CatalogFilePath != "" {
+			defaultConfig.CatalogFilePath = cfg.CatalogFilePath
+		}
+		if len(cfg.RegistrationCountries) > 0 {
+			defaultConfig.RegistrationCountries = cfg.RegistrationCountries
+		}
+		if len(cfg.RegistrationFormats) > 0 {
+			defaultConfig.RegistrationFormats = cfg.RegistrationFormats
+		}
 	}
 
 	return &ServerChi{
-		serverAddress:  defaultConfig.ServerAddress,
-		loaderFilePath: defaultConfig.LoaderFilePath,
+		serverAddress:           defaultConfig.ServerAddress,
+		grpcAddress:             defaultConfig.GRPCAddress,
+		serverReadTimeout:       defaultConfig.ServerReadTimeout,
+		serverWriteTimeout:      defaultConfig.ServerWriteTimeout,
+		serverIdleTimeout:       defaultConfig.ServerIdleTimeout,
+		serverShutdownTimeout:   defaultConfig.ServerShutdownTimeout,
+		serverRequestTimeout:    defaultConfig.ServerRequestTimeout,
+		serverBatchTimeout:      defaultConfig.ServerBatchTimeout,
+		serverMaxBodyBytes:      defaultConfig.ServerMaxBodyBytes,
+		storageBackend:          defaultConfig.StorageBackend,
+		logLevel:                defaultConfig.LogLevel,
+		logFormat:               defaultConfig.LogFormat,
+		authKeyFile:             defaultConfig.AuthKeyFile,
+		authRotationGrace:       defaultConfig.AuthRotationGrace,
+		authJWTHMACSecretFile:   defaultConfig.AuthJWTHMACSecretFile,
+		authJWTRSAPublicKeyFile: defaultConfig.AuthJWTRSAPublicKeyFile,
+		authJWTJWKSFile:         defaultConfig.AuthJWTJWKSFile,
+		authJWTIssuer:           defaultConfig.AuthJWTIssuer,
+		authJWTAudience:         defaultConfig.AuthJWTAudience,
+		authJWTTenantClaim:      defaultConfig.AuthJWTTenantClaim,
+		authJWTRolesClaim:       defaultConfig.AuthJWTRolesClaim,
+		rateLimits: map[string]ratelimit.Limit{
+			internal.RateClassRead:  defaultConfig.RateLimitRead,
+			internal.RateClassWrite: defaultConfig.RateLimitWrite,
+			internal.RateClassBatch: defaultConfig.RateLimitBatch,
+		},
+		graphQLMaxDepth:         defaultConfig.GraphQLMaxDepth,
+		graphQLMaxComplexity:    defaultConfig.GraphQLMaxComplexity,
+		tracingExporter:         defaultConfig.TracingExporter,
+		tracingFilePath:         defaultConfig.TracingFilePath,
+		loaderFilePath:          defaultConfig.LoaderFilePath,
+		loaderSources:           defaultConfig.LoaderSources,
+		loaderTenantSources:     defaultConfig.LoaderTenantSources,
+		loaderMergePolicy:       defaultConfig.LoaderMergePolicy,
+		reloadInterval:          defaultConfig.ReloadInterval,
+		normalizeSynonyms:       defaultConfig.NormalizeSynonyms,
+		vocabularyFuelTypes:     defaultConfig.VocabularyFuelTypes,
+		vocabularyTransmissions: defaultConfig.VocabularyTransmissions,
+		catalogFilePath:         defaultConfig.CatalogFilePath,
+		registrationCountries:   defaultConfig.RegistrationCountries,
+		registrationFormats:     defaultConfig.RegistrationFormats,
 	}
 }
 
@@ -44,79 +334,751 @@ func NewServerChi(cfg *ConfigServerChi) *ServerChi {
 type ServerChi struct {
 	// serverAddress is the address where the server will be listening
 	serverAddress string
+	// grpcAddress is the address where the gRPC server will be listening, empty disables it
+	grpcAddress string
+	// serverReadTimeout is the maximum duration for reading an entire request
+	serverReadTimeout time.Duration
+	// serverWriteTimeout is the maximum duration before timing out writes of a response
+	serverWriteTimeout time.Duration
+	// serverIdleTimeout is the maximum time to wait for the next request on keep-alive connections
+	serverIdleTimeout time.Duration
+	// serverShutdownTimeout is the deadline to drain in-flight requests on shutdown
+	serverShutdownTimeout time.Duration
+	// serverRequestTimeout is the deadline to handle a request on the vehicle routes
+	serverRequestTimeout time.Duration
+	// serverBatchTimeout is the deadline to handle a request on the batch route
+	serverBatchTimeout time.Duration
+	// serverMaxBodyBytes is the largest request body read
+	serverMaxBodyBytes int
+	// storageBackend is the storage used by the repository
+	storageBackend string
+	// logLevel is the minimum level of the logs
+	logLevel string
+	// logFormat is the format of the logs
+	logFormat string
+	// authKeyFile is the JSON file with the hashed API keys, empty disables authentication
+	authKeyFile string
+	// authRotationGrace is the time the previous secret of a rotated API key is still accepted
+	authRotationGrace time.Duration
+	// authJWTHMACSecretFile is the file with the shared secret of HS256 tokens
+	authJWTHMACSecretFile string
+	// authJWTRSAPublicKeyFile is the PEM file with the public key of RS256 tokens
+	authJWTRSAPublicKeyFile string
+	// authJWTJWKSFile is the JSON Web Key Set file with the token keys
+	authJWTJWKSFile string
+	// authJWTIssuer is the required iss claim of the tokens, empty accepts any
+	authJWTIssuer string
+	// authJWTAudience is the required aud claim of the tokens, empty accepts any
+	authJWTAudience string
+	// authJWTRolesClaim is the claim of the tokens holding the roles
+	authJWTRolesClaim string
+	// authJWTTenantClaim is the claim of the tokens holding the tenant they are bound to
+	authJWTTenantClaim string
+	// rateLimits are the limits of each client by class of routes
+	rateLimits map[string]ratelimit.Limit
+	// graphQLMaxDepth is the maximum depth of nested fields of a GraphQL operation
+	graphQLMaxDepth int
+	// graphQLMaxComplexity is the maximum complexity of a GraphQL operation
+	graphQLMaxComplexity int
+	// tracingExporter is the destination of the request spans
+	tracingExporter string
+	// tracingFilePath is the file the spans are appended to by the file exporter
+	tracingFilePath string
 	// loaderFilePath is the path to the file that contains the vehicles
 	loaderFilePath string
+	// loaderSources are the sources merged into the fleet
+	loaderSources []string
+	// loaderTenantSources are the sources of each tenant
+	loaderTenantSources []string
+	// loaderMergePolicy is the policy applied on collisions between sources
+	loaderMergePolicy string
+	// reloadInterval is the time between checks of the loader files for changes
+	reloadInterval time.Duration
+	// normalizeSynonyms are the synonyms of the text fields compared by the finders
+	normalizeSynonyms []string
+	// vocabularyFuelTypes are the fuel types allowed for the vehicles written
+	vocabularyFuelTypes []string
+	// vocabularyTransmissions are the transmissions allowed for the vehicles written
+	vocabularyTransmissions []string
+	// catalogFilePath is the JSON file with the brands and models of the catalog, empty disables it
+	catalogFilePath string
+	// registrationCountries are the countries of the formats allowed for the registrations of the vehicles written
+	registrationCountries []string
+	// registrationFormats are the formats added to the built-in ones or replacing them
+	registrationFormats []string
+
+	// mu guards the fields below, set while the server is running
+	mu sync.Mutex
+	// srv is the running http server
+	srv *http.Server
+	// ln is the listener the server accepts connections on
+	ln net.Listener
+	// grpcSrv is the running gRPC server, nil if it is disabled
+	grpcSrv *grpc.Server
+	// closeStreams ends the streams of the gRPC server that would otherwise hold the drain
+	closeStreams context.CancelFunc
+	// rp is the repository flushed on shutdown
+	rp internal.VehicleRepository
+	// stopBackground stops the background tasks (dataset watcher)
+	stopBackground context.CancelFunc
+	// closeExporter releases the span exporter
+	closeExporter func() error
+	// shuttingDown is set once Shutdown has been called
+	shuttingDown atomic.Bool
 }
 
 // Run is a method that runs the application
 func (a *ServerChi) Run() (err error) {
+	// logger: every layer logs through slog's default logger
+	logger, err := logging.New(os.Stdout, a.logLevel, a.logFormat)
+	if err != nil {
+		return
+	}
+	slog.SetDefault(logger)
+
+	// timeouts: a response written after the write timeout is lost, the handlers must time out before it
+	if a.serverWriteTimeout < max(a.serverRequestTimeout, a.serverBatchTimeout) {
+		err = fmt.Errorf("%w: %s, request %s, batch %s", ErrorWriteTimeoutTooShort, a.serverWriteTimeout, a.serverRequestTimeout, a.serverBatchTimeout)
+		return
+	}
+
 	// dependencies
-	// - loader
-	ld := loader.NewVehicleJSONFile(a.loaderFilePath)
-	db, err := ld.Load()
+	// - repository: a namespace of vehicles per tenant
+	if a.storageBackend != StorageBackendMemory {
+		err = fmt.Errorf("%w: %s", ErrorStorageBackendUnsupported, a.storageBackend)
+		return
+	}
+	// - the text fields are compared case, accent and separator insensitively, with synonyms
+	synonyms, err := normalize.ParseSynonyms(a.normalizeSynonyms)
+	if err != nil {
+		return
+	}
+	nm := normalize.NewFolding(synonyms)
+	// - the fuel types and transmissions written are checked against the vocabulary, with the same keys
+	vc, err := vocabulary.NewRegistry(nm, a.vocabularyFuelTypes, a.vocabularyTransmissions)
+	if err != nil {
+		return
+	}
+	// - the brands and models written are checked against the catalog when it is enabled, with the same keys
+	var ct internal.CatalogRepository
+	if a.catalogFilePath != "" {
+		var brands []internal.CatalogBrand
+		brands, err = loader.NewCatalogJSONFile(a.catalogFilePath).Load()
+		if err != nil {
+			return
+		}
+		cm := repository.NewCatalogMap(nm)
+		if err = cm.ReplaceAllContext(context.Background(), brands); err != nil {
+			return
+		}
+		ct = cm
+	}
+	// - the registrations written must have the format of a country and are unique by their normalized form
+	formats, err := registration.ParseFormats(a.registrationFormats)
 	if err != nil {
 		return
 	}
-	// - repository
-	rp := repository.NewVehicleMap(db)
+	rg, err := registration.NewRegistry(a.registrationCountries, formats)
+	if err != nil {
+		return
+	}
+	// - the VINs written must have their check digit and match the brand and year of their vehicle, unique if any
+	rp := repository.NewVehicleTenant(nm, vc, ct, rg, vin.NewDecoder(nil))
+	// - loader and reloader: the reloaders perform the initial load and the hot reloads of each tenant,
+	// published by the feed of the service built below, before the initial load
+	var fd *service.VehicleFeed
+	reloaded := func(ctx context.Context) { fd.PublishReload(ctx) }
+	var reloaders []*reloader.VehiclePolling
+	if len(a.loaderTenantSources) > 0 {
+		var policy loader.MergePolicy
+		policy, err = loader.ParseMergePolicy(a.loaderMergePolicy)
+		if err != nil {
+			return
+		}
+		var sources map[string][]string
+		sources, err = loader.ParseTenantSources(a.loaderTenantSources)
+		if err != nil {
+			return
+		}
+		tenants := make([]string, 0, len(sources))
+		for tenant := range sources {
+			tenants = append(tenants, tenant)
+		}
+		sort.Strings(tenants)
+		for _, tenant := range tenants {
+			reloaders = append(reloaders, reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+				Loader:     loader.NewVehicleMulti(sources[tenant], policy),
+				Repository: rp,
+				Tenant:     tenant,
+				Paths:      sources[tenant],
+				Interval:   a.reloadInterval,
+				Reloaded:   reloaded,
+			}))
+		}
+	} else {
+		var ld internal.VehicleLoader = loader.NewVehicleJSONFile(a.loaderFilePath)
+		watched := []string{a.loaderFilePath}
+		if len(a.loaderSources) > 0 {
+			var policy loader.MergePolicy
+			policy, err = loader.ParseMergePolicy(a.loaderMergePolicy)
+			if err != nil {
+				return
+			}
+			ld = loader.NewVehicleMulti(a.loaderSources, policy)
+			watched = a.loaderSources
+		}
+		reloaders = append(reloaders, reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+			Loader:     ld,
+			Repository: rp,
+			Paths:      watched,
+			Interval:   a.reloadInterval,
+			Reloaded:   reloaded,
+		}))
+	}
+	rl := reloader.NewVehicleGroup(reloaders...)
+	// - authentication: API keys and JWTs, each disabled when its keys are not configured
+	var st internal.APIKeyStore
+	if a.authKeyFile != "" {
+		var keys *auth.APIKeyFile
+		keys, err = auth.NewAPIKeyFile(a.authKeyFile)
+		if err != nil {
+			return
+		}
+		if err = bootstrapAPIKey(logger, keys, a.authKeyFile+".bootstrap"); err != nil {
+			return
+		}
+		st = keys
+	}
+	var jwt *auth.JWT
+	jwtCfg := auth.ConfigJWT{
+		HMACSecretFile:   a.authJWTHMACSecretFile,
+		RSAPublicKeyFile: a.authJWTRSAPublicKeyFile,
+		JWKSFile:         a.authJWTJWKSFile,
+		Issuer:           a.authJWTIssuer,
+		Audience:         a.authJWTAudience,
+		RolesClaim:       a.authJWTRolesClaim,
+		TenantClaim:      a.authJWTTenantClaim,
+	}
+	if jwtCfg.Enabled() {
+		jwt, err = auth.NewJWT(jwtCfg)
+		if err != nil {
+			return
+		}
+	}
+	au := auth.NewAuthenticator(auth.ConfigAuthenticator{APIKeys: st, JWT: jwt})
+	if !au.Enabled() {
+		logger.Warn("authentication disabled, set auth.key_file or the auth.jwt keys to require credentials")
+	}
+	// - rate limiter: token buckets and daily quotas by client and class of routes
+	lm := ratelimit.NewTokenBucket(a.rateLimits)
+	// - tracer: spans of the handler, service and repository layers
+	exporter, closeExporter, err := tracing.NewExporter(a.tracingExporter, a.tracingFilePath)
+	if err != nil {
+		return
+	}
+	tr := tracing.NewTracer(exporter)
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	// - metrics: the service sees the repository through the instrumented one
+	pm := metrics.NewPrometheus(metrics.ConfigPrometheus{
+		Reloader:   rl,
+		Repository: rp,
+	})
+	rpMetrics := metrics.NewVehicleRepository(rp, pm)
+	// - tracing: each layer sees the next one through the traced one
+	rpTraced := tracing.NewVehicleRepository(rpMetrics, tr)
 	// - service
-	sv := service.NewVehicleDefault(rp)
+	// - the feed publishes the changes made through the REST and gRPC APIs
+	fd = service.NewVehicleFeed(tracing.NewVehicleService(service.NewVehicleDefault(rpTraced), tr))
+	var sv internal.VehicleService = fd
 	// - handler
 	hd := handler.NewVehicleDefault(sv)
+	hdV2 := handler.NewVehicleV2(sv, vc)
+	hdAdmin := handler.NewAdminDefault(rl)
+	hdKeys := handler.NewAPIKeyDefault(st, a.authRotationGrace)
+	hdUsage := handler.NewUsageDefault(lm)
+	hdCatalog := handler.NewCatalogDefault(ct)
+	hdTenant := handler.NewTenantDefault(rp)
+	hdHealth := handler.NewHealthDefault(handler.ConfigHealthDefault{
+		Reloader:     rl,
+		Repository:   rp,
+		ShuttingDown: a.shuttingDown.Load,
+	})
+	schema, err := graphql.NewSchema(sv)
+	if err != nil {
+		err = errors.Join(err, closeExporter())
+		return
+	}
+	hdGraphQL := handler.NewGraphQLDefault(handler.ConfigGraphQLDefault{
+		Executor:   graphql.NewExecutor(schema, graphql.ConfigExecutor{MaxDepth: a.graphQLMaxDepth, MaxComplexity: a.graphQLMaxComplexity}),
+		Authorizer: au,
+		// - each operation is bounded and limited as the vehicle routes of the class of its most expensive root field
+		Classes: map[string]func(http.Handler) http.Handler{
+			internal.RateClassRead:  chi.Chain(handler.Timeout(a.serverRequestTimeout), ratelimit.Middleware(lm, internal.RateClassRead)).Handler,
+			internal.RateClassWrite: chi.Chain(handler.Timeout(a.serverRequestTimeout), ratelimit.Middleware(lm, internal.RateClassWrite)).Handler,
+			internal.RateClassBatch: chi.Chain(handler.Timeout(a.serverBatchTimeout), ratelimit.Middleware(lm, internal.RateClassBatch)).Handler,
+		},
+	})
 	// router
 	rt := chi.NewRouter()
+	// - the document describes every route, the requests are validated against it
+	doc := openapi.NewVehicleDocument()
+	vl := openapi.NewValidator(doc, rt, int64(a.serverMaxBodyBytes))
 	// - middlewares
-	rt.Use(middleware.Logger)
+	rt.Use(pm.Middleware())
+	// - the request span wraps the request log so it carries the trace ID
+	rt.Use(tr.Middleware())
+	rt.Use(logging.Middleware(logger))
 	rt.Use(middleware.Recoverer)
 	// - endpoints
-	rt.Route("/vehicles", func(rt chi.Router) {
-		// - GET /vehicles
-		rt.Get("/", hd.GetAll())
+	// - GET /healthz
+	rt.Get("/healthz", hdHealth.Healthz())
+
+	// - GET /readyz
+	rt.Get("/readyz", hdHealth.Readyz())
+
+	// - GET /version
+	rt.Get("/version", hdHealth.Version())
+
+	// - GET /metrics
+	rt.Method(http.MethodGet, "/metrics", pm.Handler())
+
+	// - every vehicle route of every version requires an API key
+	// - until the dataset is loaded every vehicle route answers 503
+	// - every vehicle route acts on the tenant of the request only
+	vehicleMiddlewares := chi.Chain(au.Authenticate(), hdHealth.RequireReady(), hdTenant.Resolve())
+	// - each route is bounded by a timeout, batches get a longer one
+	requestTimeout := handler.Timeout(a.serverRequestTimeout)
+	batchTimeout := handler.Timeout(a.serverBatchTimeout)
+	// - each route is allowed by the policy of its handler (auth.DefaultPolicy)
+	// - then limited by class, after the authentication so the clients are keyed by their credentials
+	readLimit := ratelimit.Middleware(lm, internal.RateClassRead)
+	writeLimit := ratelimit.Middleware(lm, internal.RateClassWrite)
+	batchLimit := ratelimit.Middleware(lm, internal.RateClassBatch)
+	// - the parameters and bodies are validated against the OpenAPI document before the handlers run, after the
+	// policy and the limits so the callers refused are neither read nor told the schema
+	validate := vl.Middleware()
+
+	// - version 1, frozen: lists as objects by ID and filters as path segments
+	vehicleV1 := func(rt chi.Router) {
+		rt.Use(vehicleMiddlewares...)
 
-		// - GET /vehicles/{id}
-		rt.Get("/{id}", hd.GetByID())
+		// - GET /v1/vehicles
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetAll"), readLimit, validate).Get("/", hd.GetAll())
 
-		// - POST /vehicles
-		rt.Post("/", hd.Create())
+		// - GET /v1/vehicles/{id}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetByID"), readLimit, validate).Get("/{id}", hd.GetByID())
 
-		// - GET /vehicles/brand/{brand}/between/{start_year}/{end_year}
-		rt.Get("/brand/{brand}/between/{start_year}/{end_year}", hd.GetByBrandAndRangeYear())
+		// - POST /v1/vehicles
+		rt.With(requestTimeout, au.Allow("VehicleDefault.Create"), writeLimit, validate).Post("/", hd.Create())
 
-		// - GET /vehicles/color/{color}/year/{year}
-		rt.Get("/color/{color}/year/{year}", hd.GetByColorAndYear())
+		// - GET /v1/vehicles/brand/{brand}/between/{start_year}/{end_year}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetByBrandAndRangeYear"), readLimit, validate).Get("/brand/{brand}/between/{start_year}/{end_year}", hd.GetByBrandAndRangeYear())
 
-		// - GET /vehicles/average-speed/brand/{brand}
-		rt.Get("/average-speed/brand/{brand}", hd.GetAverageSpeedByBrand())
+		// - GET /v1/vehicles/color/{color}/year/{year}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetByColorAndYear"), readLimit, validate).Get("/color/{color}/year/{year}", hd.GetByColorAndYear())
 
-		// - POST /vehicles/batch
-		rt.Post("/batch", hd.CreateBatch())
+		// - GET /v1/vehicles/average-speed/brand/{brand}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetAverageSpeedByBrand"), readLimit, validate).Get("/average-speed/brand/{brand}", hd.GetAverageSpeedByBrand())
 
-		// - PATCH /vehicles/{id}/update-speed
-		rt.Patch("/{id}/update-speed", hd.UpdateMaxSpeed())
+		// - POST /v1/vehicles/batch
+		rt.With(batchTimeout, au.Allow("VehicleDefault.CreateBatch"), batchLimit, validate).Post("/batch", hd.CreateBatch())
 
-		// - GET /vehicles/fuel-type/{type}
-		rt.Get("/fuel-type/{type}", hd.GetByFuelType())
+		// - PATCH /v1/vehicles/{id}/update-speed
+		rt.With(requestTimeout, au.Allow("VehicleDefault.UpdateMaxSpeed"), writeLimit, validate).Patch("/{id}/update-speed", hd.UpdateMaxSpeed())
 
-		// - DELETE /vehicles/{id}
-		rt.Delete("/{id}", hd.Delete())
+		// - GET /v1/vehicles/fuel-type/{type}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetByFuelType"), readLimit, validate).Get("/fuel-type/{type}", hd.GetByFuelType())
 
-		// - GET /vehicles/transmission/{type}
-		rt.Get("/transmission/{type}", hd.GetByTransmissionType())
+		// - DELETE /v1/vehicles/{id}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.Delete"), writeLimit, validate).Delete("/{id}", hd.Delete())
+
+		// - GET /v1/vehicles/transmission/{type}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetByTransmissionType"), readLimit, validate).Get("/transmission/{type}", hd.GetByTransmissionType())
+
+		// - PATCH /v1/vehicles/{id}/update-fuel
+		rt.With(requestTimeout, au.Allow("VehicleDefault.UpdateFuelType"), writeLimit, validate).Patch("/{id}/update-fuel", hd.UpdateFuelType())
+
+		// - GET /v1/vehicles/average-capacity/brand/{brand}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetAverageCapacityByBrand"), readLimit, validate).Get("/average-capacity/brand/{brand}", hd.GetAverageCapacityByBrand())
+
+		// - GET /v1/vehicles/dimensions?height={min_height}-{max_height}&width={min_width}-{max_width}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetByDimensions"), readLimit, validate).Get("/dimensions", hd.GetByDimensions())
+
+		// - GET /v1/vehicles/weight?min={min_weight}&max={max_weight}
+		rt.With(requestTimeout, au.Allow("VehicleDefault.GetByWeightRange"), readLimit, validate).Get("/weight", hd.GetByWeightRange())
+	}
+	rt.Route(handler.PathVehiclesV1, vehicleV1)
+	// - the version 1 is also served unversioned, where the clients written before the versions call it
+	rt.Route(handler.PathVehicles, vehicleV1)
 
-		// - PATCH /vehicles/{id}/update-fuel
-		rt.Patch("/{id}/update-fuel", hd.UpdateFuelType())
+	// - version 2: lists as arrays with pagination metadata, filters as query parameters
+	rt.Route(handler.PathVehiclesV2, func(rt chi.Router) {
+		rt.Use(vehicleMiddlewares...)
 
-		// - GET /vehicles/average-capacity/brand/{brand}
-		rt.Get("/average-capacity/brand/{brand}", hd.GetAverageCapacityByBrand())
+		// - GET /v2/vehicles?color&year&brand&year_from&year_to&fuel_type&transmission&height&width&weight&body_type&country&page&per_page&fields
+		rt.With(requestTimeout, au.Allow("VehicleV2.List"), readLimit, validate).Get("/", hdV2.List())
 
-		// - GET /vehicles/dimensions?length={min_length}-{max_length}&width={min_width}-{max_width}
-		rt.Get("/dimensions", hd.GetByDimensions())
+		// - GET /v2/vehicles/search/text?q={query}&limit={limit}
+		rt.With(requestTimeout, au.Allow("VehicleV2.Search"), readLimit, validate).Get("/search/text", hdV2.Search())
 
-		// - GET /vehicles/weight?min={min_weight}&max={max_weight}
-		rt.Get("/weight", hd.GetByWeightRange())
+		// - GET /v2/vehicles/meta/enums
+		rt.With(requestTimeout, au.Allow("VehicleV2.GetEnums"), readLimit, validate).Get("/meta/enums", hdV2.GetEnums())
+
+		// - GET /v2/vehicles/registration/{plate}
+		rt.With(requestTimeout, au.Allow("VehicleV2.GetByRegistration"), readLimit, validate).Get("/registration/{plate}", hdV2.GetByRegistration())
+
+		// - GET /v2/vehicles/registrations/duplicates
+		rt.With(requestTimeout, au.Allow("VehicleV2.GetRegistrationDuplicates"), readLimit, validate).Get("/registrations/duplicates", hdV2.GetRegistrationDuplicates())
+
+		// - GET /v2/vehicles/vin/{vin}
+		rt.With(requestTimeout, au.Allow("VehicleV2.GetByVIN"), readLimit, validate).Get("/vin/{vin}", hdV2.GetByVIN())
+
+		// - GET /v2/vehicles/stats?brand={brand}
+		rt.With(requestTimeout, au.Allow("VehicleV2.GetStats"), readLimit, validate).Get("/stats", hdV2.GetStats())
+
+		// - GET /v2/vehicles/{id}
+		rt.With(requestTimeout, au.Allow("VehicleV2.Get"), readLimit, validate).Get("/{id}", hdV2.Get())
+
+		// - POST /v2/vehicles
+		rt.With(requestTimeout, au.Allow("VehicleV2.Create"), writeLimit, validate).Post("/", hdV2.Create())
+
+		// - POST /v2/vehicles/batch
+		rt.With(batchTimeout, au.Allow("VehicleV2.CreateBatch"), batchLimit, validate).Post("/batch", hdV2.CreateBatch())
+
+		// - PATCH /v2/vehicles/{id}
+		rt.With(requestTimeout, au.Allow("VehicleV2.Update"), writeLimit, validate).Patch("/{id}", hdV2.Update())
+
+		// - DELETE /v2/vehicles/{id}
+		rt.With(requestTimeout, au.Allow("VehicleV2.Delete"), writeLimit, validate).Delete("/{id}", hdV2.Delete())
 	})
 
+	// - the catalog is shared by the tenants and can only be administered when it is enabled
+	if ct != nil {
+		rt.Route(handler.PathCatalogBrands, func(rt chi.Router) {
+			rt.Use(au.Authenticate())
+
+			// - GET /v2/catalog/brands
+			rt.With(requestTimeout, au.Allow("CatalogDefault.GetBrands"), readLimit, validate).Get("/", hdCatalog.GetBrands())
+
+			// - GET /v2/catalog/brands/{brand}
+			rt.With(requestTimeout, au.Allow("CatalogDefault.GetBrand"), readLimit, validate).Get("/{brand}", hdCatalog.GetBrand())
+
+			// - POST /v2/catalog/brands
+			rt.With(requestTimeout, au.Allow("CatalogDefault.CreateBrand"), writeLimit, validate).Post("/", hdCatalog.CreateBrand())
+
+			// - PUT /v2/catalog/brands/{brand}
+			rt.With(requestTimeout, au.Allow("CatalogDefault.UpdateBrand"), writeLimit, validate).Put("/{brand}", hdCatalog.UpdateBrand())
+
+			// - DELETE /v2/catalog/brands/{brand}
+			rt.With(requestTimeout, au.Allow("CatalogDefault.DeleteBrand"), writeLimit, validate).Delete("/{brand}", hdCatalog.DeleteBrand())
+
+			// - POST /v2/catalog/brands/{brand}/models
+			rt.With(requestTimeout, au.Allow("CatalogDefault.CreateModel"), writeLimit, validate).Post("/{brand}/models", hdCatalog.CreateModel())
+
+			// - PUT /v2/catalog/brands/{brand}/models/{model}
+			rt.With(requestTimeout, au.Allow("CatalogDefault.UpdateModel"), writeLimit, validate).Put("/{brand}/models/{model}", hdCatalog.UpdateModel())
+
+			// - DELETE /v2/catalog/brands/{brand}/models/{model}
+			rt.With(requestTimeout, au.Allow("CatalogDefault.DeleteModel"), writeLimit, validate).Delete("/{brand}/models/{model}", hdCatalog.DeleteModel())
+		})
+	}
+
+	rt.Route("/graphql", func(rt chi.Router) {
+		// - authenticated, ready and scoped to the tenant as the vehicle routes
+		rt.Use(au.Authenticate())
+		rt.Use(hdHealth.RequireReady())
+		rt.Use(hdTenant.Resolve())
+		// - the policy and the limits depend on the root fields of the operation, so the body is validated first
+		rt.Use(vl.Middleware())
+
+		// - POST /graphql, each root field is allowed by the policy (GraphQLDefault.Query, Mutate or Delete)
+		rt.Post("/", hdGraphQL.Execute())
+	})
+
+	rt.Route("/admin", func(rt chi.Router) {
+		// - every admin route requires credentials allowed by the policy of its handler
+		// - the routes of the tenants and the keys are scoped by their handlers to the tenant of bound credentials
+		// - then validated against the OpenAPI document
+		rt.Use(au.Authenticate())
+
+		// - POST /admin/reload
+		rt.With(au.Allow("AdminDefault.Reload"), validate).Post("/reload", hdAdmin.Reload())
+
+		// - GET /admin/reload
+		rt.With(au.Allow("AdminDefault.GetReloadStatus"), validate).Get("/reload", hdAdmin.GetReloadStatus())
+
+		// - GET /admin/tenants
+		rt.With(au.Allow("TenantDefault.GetTenants"), validate).Get("/tenants", hdTenant.GetTenants())
+
+		// - GET /admin/vehicles
+		rt.With(au.Allow("TenantDefault.GetAllVehicles"), validate).Get("/vehicles", hdTenant.GetAllVehicles())
+
+		// - GET /admin/usage
+		rt.With(au.Allow("UsageDefault.GetUsage"), validate).Get("/usage", hdUsage.GetUsage())
+
+		// - the keys can only be administered when API keys are enabled
+		if st != nil {
+			// - GET /admin/keys
+			rt.With(au.Allow("APIKeyDefault.GetAll"), validate).Get("/keys", hdKeys.GetAll())
+
+			// - POST /admin/keys
+			rt.With(au.Allow("APIKeyDefault.Issue"), validate).Post("/keys", hdKeys.Issue())
+
+			// - POST /admin/keys/{id}/rotate
+			rt.With(au.Allow("APIKeyDefault.Rotate"), validate).Post("/keys/{id}/rotate", hdKeys.Rotate())
+
+			// - DELETE /admin/keys/{id}
+			rt.With(au.Allow("APIKeyDefault.Revoke"), validate).Delete("/keys/{id}", hdKeys.Revoke())
+		}
+	})
+
+	// - GET /openapi.json
+	rt.Method(http.MethodGet, openapi.PathSpec, doc.Handler())
+
+	// - GET /docs
+	rt.Method(http.MethodGet, openapi.PathDocs, openapi.DocsHandler())
+
+	// - every registered route must be described by the document
+	if err = doc.Check(rt); err != nil {
+		err = errors.Join(err, closeExporter())
+		return
+	}
+
 	// run server
-	err = http.ListenAndServe(a.serverAddress, rt)
+	srv := &http.Server{
+		Addr:         a.serverAddress,
+		Handler:      rt,
+		ReadTimeout:  a.serverReadTimeout,
+		WriteTimeout: a.serverWriteTimeout,
+		IdleTimeout:  a.serverIdleTimeout,
+	}
+	ln, err := net.Listen("tcp", a.serverAddress)
+	if err != nil {
+		err = errors.Join(err, closeExporter())
+		return
+	}
+	// - the gRPC server serves the same service on its own port, governed as the vehicle routes
+	var grpcSrv *grpc.Server
+	var grpcLn net.Listener
+	closing, closeStreams := context.WithCancel(context.Background())
+	if a.grpcAddress != "" {
+		grpcLn, err = net.Listen("tcp", a.grpcAddress)
+		if err != nil {
+			closeStreams()
+			err = errors.Join(err, ln.Close(), closeExporter())
+			return
+		}
+		grpcSrv = rpc.NewServer(rpc.ConfigServer{
+			Service:        sv,
+			Feed:           fd,
+			Reloader:       rl,
+			Authenticator:  au,
+			Tenants:        hdTenant,
+			RateLimiter:    lm,
+			Tracer:         tr,
+			Logger:         logger,
+			RequestTimeout: a.serverRequestTimeout,
+			BatchTimeout:   a.serverBatchTimeout,
+			Closing:        closing,
+		})
+	}
+	a.mu.Lock()
+	a.srv = srv
+	a.ln = ln
+	a.grpcSrv = grpcSrv
+	a.closeStreams = closeStreams
+	a.rp = rp
+	a.stopBackground = stopBackground
+	a.closeExporter = closeExporter
+	a.mu.Unlock()
+
+	// - shutdown on SIGINT/SIGTERM
+	sigCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	logger.Info("server listening", slog.String("address", ln.Addr().String()))
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+	if grpcSrv != nil {
+		logger.Info("grpc server listening", slog.String("address", grpcLn.Addr().String()))
+		go func() {
+			if err := grpcSrv.Serve(grpcLn); err != nil {
+				logger.Error("grpc server failed", slog.String("error", err.Error()))
+			}
+		}()
+	}
+
+	// - initial load, the server is already up so the probes can report it
+	loadErr := make(chan error, 1)
+	go func() {
+		_, err := rl.Reload()
+		loadErr <- err
+	}()
+
+	for {
+		select {
+		case err = <-loadErr:
+			if err != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), a.serverShutdownTimeout)
+				defer cancel()
+				err = errors.Join(err, a.Shutdown(ctx))
+				<-serveErr
+				return
+			}
+			reportDuplicates(logger, rp)
+			if a.reloadInterval > 0 {
+				go rl.Watch(bgCtx)
+			}
+			// a nil channel is never ready, the load is done
+			loadErr = nil
+		case err = <-serveErr:
+			// closed by a call to Shutdown
+			if errors.Is(err, http.ErrServerClosed) {
+				err = nil
+			}
+			return
+		case <-sigCtx.Done():
+			logger.Info("shutting down", slog.Duration("drain_deadline", a.serverShutdownTimeout))
+			ctx, cancel := context.WithTimeout(context.Background(), a.serverShutdownTimeout)
+			defer cancel()
+			err = a.Shutdown(ctx)
+			<-serveErr
+			return
+		}
+	}
+}
+
+// Addr is a method that returns the address the server is listening on, nil if it is not running
+func (a *ServerChi) Addr() (addr net.Addr) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.ln != nil {
+		addr = a.ln.Addr()
+	}
+	return
+}
+
+// Shutdown is a method that stops the server draining in-flight requests until ctx is done,
+// then stops the background tasks, flushes the storage and releases the span exporter
+func (a *ServerChi) Shutdown(ctx context.Context) (err error) {
+	a.mu.Lock()
+	srv, grpcSrv, closeStreams := a.srv, a.grpcSrv, a.closeStreams
+	rp, stopBackground, closeExporter := a.rp, a.stopBackground, a.closeExporter
+	a.mu.Unlock()
+	if srv == nil {
+		err = ErrorServerNotRunning
+		return
+	}
+	a.shuttingDown.Store(true)
+
+	// - stop accepting connections and drain in-flight requests and calls
+	closeStreams()
+	grpcStopped := make(chan struct{})
+	go func() {
+		if grpcSrv != nil {
+			shutdownGRPC(ctx, grpcSrv)
+		}
+		close(grpcStopped)
+	}()
+	err = srv.Shutdown(ctx)
+	<-grpcStopped
+
+	// - stop background tasks
+	stopBackground()
+
+	// - flush storage, even if the drain deadline was exceeded
+	if fl, ok := rp.(internal.VehicleRepositoryFlusher); ok {
+		err = errors.Join(err, fl.Flush())
+	}
+
+	// - release the span exporter once no request can end a span
+	err = errors.Join(err, closeExporter())
+
+	return
+}
+
+// shutdownGRPC is a function that stops a gRPC server draining in-flight calls until ctx is done,
+// the calls still running then are canceled
+func shutdownGRPC(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+		<-stopped
+	}
+}
+
+// reportDuplicates is a function that warns of the registrations shared by several vehicles of each tenant
+// in the loaded dataset, they are kept but no new vehicle can take them
+func reportDuplicates(logger *slog.Logger, rp *repository.VehicleTenant) {
+	tenants := make([]string, 0)
+	for tenant := range rp.Tenants() {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	for _, tenant := range tenants {
+		d, err := rp.FindRegistrationDuplicatesContext(internal.WithTenant(context.Background(), tenant))
+		if err != nil || len(d) == 0 {
+			continue
+		}
+		vehicles := 0
+		registrations := make([]string, 0, len(d))
+		for _, value := range d {
+			vehicles += len(value.IDs)
+			registrations = append(registrations, value.Registration)
+		}
+		logger.Warn("registrations shared by several vehicles in the loaded dataset, see "+handler.PathVehiclesV2+"/registrations/duplicates",
+			slog.String("tenant", tenant),
+			slog.Int("registrations", len(d)),
+			slog.Int("vehicles", vehicles),
+			slog.Any("duplicates", registrations),
+		)
+	}
+}
+
+// bootstrapAPIKey is a function that issues an admin key when the store has none,
+// so the first fleet keys can be issued through the admin routes. The secret is only written
+// to the file at path, readable by the owner alone, and never logged
+func bootstrapAPIKey(logger *slog.Logger, st internal.APIKeyStore, path string) (err error) {
+	keys, err := st.FindAll()
+	if err != nil || len(keys) > 0 {
+		return
+	}
+
+	k, secret, err := st.Issue("bootstrap", "", []string{internal.ScopeAdmin}, 0)
+	if err != nil {
+		return
+	}
+
+	// - a previous file is removed so the new one is created with the restricted permissions
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return
+	}
+	if _, err = f.WriteString(secret + "\n"); err != nil {
+		f.Close()
+		return
+	}
+	if err = f.Close(); err != nil {
+		return
+	}
+
+	logger.Warn("no API keys found, issued a bootstrap admin key: issue the fleet keys with it, then revoke it and delete its file",
+		slog.String("key_id", k.ID),
+		slog.String("secret_file", path),
+	)
 	return
 }
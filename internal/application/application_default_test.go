@@ -0,0 +1,116 @@
+package application_test
+
+import (
+	"app/internal"
+	"app/internal/application"
+	"app/internal/auth"
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// run is a function that runs a server with cfg until the end of the test, returning its base URL
+func run(t *testing.T, cfg *application.ConfigServerChi) (base string) {
+	t.Helper()
+	a := application.NewServerChi(cfg)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Run()
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for a.Addr() == nil {
+		select {
+		case err := <-done:
+			t.Fatalf("server stopped before listening: %v", err)
+		case <-deadline:
+			t.Fatal("server not listening after 5s")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := a.Shutdown(ctx); err != nil {
+			t.Errorf("shutdown: %v", err)
+		}
+		if err := <-done; err != nil {
+			t.Errorf("run: %v", err)
+		}
+	})
+	return "http://" + a.Addr().String()
+}
+
+// TestServerChi_Run_RoutesDocumented runs the server with every optional route registered: it only starts
+// when the OpenAPI document describes every route of the router
+func TestServerChi_Run_RoutesDocumented(t *testing.T) {
+	run(t, &application.ConfigServerChi{
+		ServerAddress:   "127.0.0.1:0",
+		LogLevel:        "error",
+		LoaderFilePath:  filepath.Join("..", "..", "docs", "db", "vehicles_100.json"),
+		AuthKeyFile:     filepath.Join(t.TempDir(), "keys.json"),
+		CatalogFilePath: filepath.Join("..", "..", "docs", "db", "catalog.json"),
+	})
+}
+
+// TestServerChi_Run_Validation checks the bodies are only read and validated for the callers allowed
+// by the policy, and never beyond the size limit
+func TestServerChi_Run_Validation(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "keys.json")
+	st, err := auth.NewAPIKeyFile(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, reader, err := st.Issue("reader", "", []string{internal.ScopeVehiclesRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, writer, err := st.Issue("writer", "", []string{internal.ScopeVehiclesWrite}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := run(t, &application.ConfigServerChi{
+		ServerAddress:      "127.0.0.1:0",
+		LogLevel:           "error",
+		LoaderFilePath:     filepath.Join("..", "..", "docs", "db", "vehicles_100.json"),
+		AuthKeyFile:        keyFile,
+		ServerMaxBodyBytes: 1024,
+	})
+
+	cases := []struct {
+		name   string
+		key    string
+		body   string
+		status int
+	}{
+		{name: "invalid body of a caller not allowed", key: reader, body: `{"brand":1}`, status: http.StatusForbidden},
+		{name: "body too large of a caller not allowed", key: reader, body: `{"brand":"` + strings.Repeat("a", 2048) + `"}`, status: http.StatusForbidden},
+		{name: "invalid body", key: writer, body: `{"brand":1}`, status: http.StatusBadRequest},
+		{name: "body too large", key: writer, body: `{"brand":"` + strings.Repeat("a", 2048) + `"}`, status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, base+"/v2/vehicles", strings.NewReader(c.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set(auth.HeaderAPIKey, c.key)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != c.status {
+				t.Errorf("status = %d, want %d", res.StatusCode, c.status)
+			}
+		})
+	}
+}
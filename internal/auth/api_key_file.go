@@ -0,0 +1,333 @@
+package auth
+
+import (
+	"app/internal"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyJSON is a struct that represents an API key in the key file
+type APIKeyJSON struct {
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Scopes            []string   `json:"scopes"`
+	Tenant            string     `json:"tenant,omitempty"`
+	Hash              string     `json:"hash"`
+	PreviousHash      string     `json:"previous_hash,omitempty"`
+	PreviousExpiresAt *time.Time `json:"previous_expires_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	RotatedAt         *time.Time `json:"rotated_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKeyFile is a function that returns a new instance of APIKeyFile, reading the key file if it exists
+func NewAPIKeyFile(path string) (s *APIKeyFile, err error) {
+	s = &APIKeyFile{path: path, keys: make(map[string]internal.APIKey), now: time.Now}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		// a missing file is an empty store, it is created on the first change
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+	var keysJSON []APIKeyJSON
+	if err = json.Unmarshal(b, &keysJSON); err != nil {
+		err = fmt.Errorf("%w: %s: %w", internal.ErrorAPIKeyStore, path, err)
+		return
+	}
+	for _, kj := range keysJSON {
+		s.keys[kj.ID] = fromJSON(kj)
+	}
+	return
+}
+
+// APIKeyFile is a struct that implements the APIKeyStore interface keeping the keys in a JSON file
+type APIKeyFile struct {
+	// path is the path of the key file
+	path string
+	// mu guards keys
+	mu sync.RWMutex
+	// keys are the keys by ID
+	keys map[string]internal.APIKey
+	// now returns the current time
+	now func() time.Time
+}
+
+// Authenticate is a method that returns the active key whose secret is given
+func (s *APIKeyFile) Authenticate(secret string) (k internal.APIKey, err error) {
+	if secret == "" {
+		err = internal.ErrorAPIKeyMissing
+		return
+	}
+	hash := hashSecret(secret)
+	now := s.now()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		current := equalHash(key.Hash, hash)
+		previous := key.PreviousHash != "" && now.Before(key.PreviousExpiresAt) && equalHash(key.PreviousHash, hash)
+		if !current && !previous {
+			continue
+		}
+		if !key.RevokedAt.IsZero() || (!key.ExpiresAt.IsZero() && !now.Before(key.ExpiresAt)) {
+			break
+		}
+		k = key
+		return
+	}
+
+	err = internal.ErrorAPIKeyInvalid
+	return
+}
+
+// FindAll is a method that returns all the keys sorted by creation, revoked ones included
+func (s *APIKeyFile) FindAll() (k []internal.APIKey, err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k = make([]internal.APIKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		k = append(k, key)
+	}
+	slices.SortFunc(k, func(a, b internal.APIKey) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return
+}
+
+// Issue is a method that creates a key returning it with its secret, which is not stored
+func (s *APIKeyFile) Issue(name string, tenant string, scopes []string, ttl time.Duration) (k internal.APIKey, secret string, err error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		err = internal.ErrorAPIKeyInvalidName
+		return
+	}
+	if tenant != "" && !internal.ValidTenant(tenant) {
+		err = fmt.Errorf("%w: %q", internal.ErrorTenantInvalid, tenant)
+		return
+	}
+	if err = validateScopes(scopes); err != nil {
+		return
+	}
+
+	now := s.now()
+	k = internal.APIKey{
+		ID:        randomHex(8),
+		Name:      name,
+		Scopes:    slices.Clone(scopes),
+		Tenant:    tenant,
+		CreatedAt: now,
+	}
+	slices.Sort(k.Scopes)
+	k.Scopes = slices.Compact(k.Scopes)
+	if ttl > 0 {
+		k.ExpiresAt = now.Add(ttl)
+	}
+	secret = newSecret(k.ID)
+	k.Hash = hashSecret(secret)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys[k.ID] = k
+	if err = s.save(); err != nil {
+		delete(s.keys, k.ID)
+		k, secret = internal.APIKey{}, ""
+	}
+	return
+}
+
+// Rotate is a method that replaces the secret of a key, the previous one is still accepted during grace
+func (s *APIKeyFile) Rotate(id string, grace time.Duration) (k internal.APIKey, secret string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.keys[id]
+	if !ok || !old.RevokedAt.IsZero() {
+		err = fmt.Errorf("%w: %s", internal.ErrorAPIKeyNotFound, id)
+		return
+	}
+
+	now := s.now()
+	k = old
+	k.PreviousHash, k.PreviousExpiresAt = "", time.Time{}
+	if grace > 0 {
+		k.PreviousHash = old.Hash
+		k.PreviousExpiresAt = now.Add(grace)
+	}
+	k.RotatedAt = now
+	secret = newSecret(k.ID)
+	k.Hash = hashSecret(secret)
+
+	s.keys[id] = k
+	if err = s.save(); err != nil {
+		s.keys[id] = old
+		k, secret = internal.APIKey{}, ""
+	}
+	return
+}
+
+// Revoke is a method that revokes a key, its secrets stop being accepted at once
+func (s *APIKeyFile) Revoke(id string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.keys[id]
+	if !ok || !old.RevokedAt.IsZero() {
+		err = fmt.Errorf("%w: %s", internal.ErrorAPIKeyNotFound, id)
+		return
+	}
+
+	k := old
+	k.RevokedAt = s.now()
+	k.PreviousHash, k.PreviousExpiresAt = "", time.Time{}
+	s.keys[id] = k
+	if err = s.save(); err != nil {
+		s.keys[id] = old
+	}
+	return
+}
+
+// save is a method that writes the keys to the key file atomically, the caller must hold the lock
+func (s *APIKeyFile) save() (err error) {
+	keysJSON := make([]APIKeyJSON, 0, len(s.keys))
+	for _, k := range s.keys {
+		keysJSON = append(keysJSON, toJSON(k))
+	}
+	slices.SortFunc(keysJSON, func(a, b APIKeyJSON) int { return strings.Compare(a.ID, b.ID) })
+
+	b, err := json.MarshalIndent(keysJSON, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("%w: %w", internal.ErrorAPIKeyStore, err)
+		return
+	}
+
+	// - write a sibling file and rename it so the key file is never left half written
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		err = fmt.Errorf("%w: %w", internal.ErrorAPIKeyStore, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		err = fmt.Errorf("%w: %w", internal.ErrorAPIKeyStore, err)
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		err = fmt.Errorf("%w: %w", internal.ErrorAPIKeyStore, err)
+		return
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		err = fmt.Errorf("%w: %w", internal.ErrorAPIKeyStore, err)
+	}
+	return
+}
+
+// validateScopes is a function that checks that scopes is not empty and only has known scopes
+func validateScopes(scopes []string) (err error) {
+	if len(scopes) == 0 {
+		err = fmt.Errorf("%w: at least one scope is required", internal.ErrorAPIKeyInvalidScope)
+		return
+	}
+	for _, sc := range scopes {
+		if !slices.Contains(internal.Scopes, sc) {
+			err = fmt.Errorf("%w: %s", internal.ErrorAPIKeyInvalidScope, sc)
+			return
+		}
+	}
+	return
+}
+
+// newSecret is a function that returns a random secret, prefixed with the key ID to ease support
+func newSecret(id string) string {
+	return "vk_" + id + "_" + randomHex(24)
+}
+
+// hashSecret is a function that returns the SHA-256 hex digest of a secret,
+// secrets are random so a fast unsalted hash is enough
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// equalHash is a function that compares two digests in constant time
+func equalHash(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// randomHex is a function that returns n random bytes in hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// toJSON is a function that returns the key file representation of a key
+func toJSON(k internal.APIKey) (kj APIKeyJSON) {
+	optional := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		return &t
+	}
+
+	kj = APIKeyJSON{
+		ID:                k.ID,
+		Name:              k.Name,
+		Scopes:            k.Scopes,
+		Tenant:            k.Tenant,
+		Hash:              k.Hash,
+		PreviousHash:      k.PreviousHash,
+		PreviousExpiresAt: optional(k.PreviousExpiresAt),
+		CreatedAt:         k.CreatedAt,
+		RotatedAt:         optional(k.RotatedAt),
+		ExpiresAt:         optional(k.ExpiresAt),
+		RevokedAt:         optional(k.RevokedAt),
+	}
+	return
+}
+
+// fromJSON is a function that returns a key from its key file representation
+func fromJSON(kj APIKeyJSON) (k internal.APIKey) {
+	value := func(t *time.Time) time.Time {
+		if t == nil {
+			return time.Time{}
+		}
+		return *t
+	}
+
+	k = internal.APIKey{
+		ID:                kj.ID,
+		Name:              kj.Name,
+		Scopes:            kj.Scopes,
+		Tenant:            kj.Tenant,
+		Hash:              kj.Hash,
+		PreviousHash:      kj.PreviousHash,
+		PreviousExpiresAt: value(kj.PreviousExpiresAt),
+		CreatedAt:         kj.CreatedAt,
+		RotatedAt:         value(kj.RotatedAt),
+		ExpiresAt:         value(kj.ExpiresAt),
+		RevokedAt:         value(kj.RevokedAt),
+	}
+	return
+}
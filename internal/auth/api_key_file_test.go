@@ -0,0 +1,205 @@
+package auth_test
+
+import (
+	"app/internal"
+	"app/internal/auth"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newKeyFile is a function that returns an empty key file store in a temporary directory with its path
+func newKeyFile(t *testing.T) (st *auth.APIKeyFile, path string) {
+	t.Helper()
+	path = filepath.Join(t.TempDir(), "keys.json")
+	st, err := auth.NewAPIKeyFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+// hash is a function that returns the digest of a secret as the key file stores it
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAPIKeyFile_Issue(t *testing.T) {
+	cases := []struct {
+		name   string
+		key    string
+		tenant string
+		scopes []string
+		err    error
+	}{
+		{name: "unbound", key: "ci", scopes: []string{internal.ScopeVehiclesRead}},
+		{name: "bound to a tenant", key: "ci", tenant: "acme", scopes: []string{internal.ScopeVehiclesRead, internal.ScopeVehiclesWrite}},
+		{name: "name required", key: " ", scopes: []string{internal.ScopeVehiclesRead}, err: internal.ErrorAPIKeyInvalidName},
+		{name: "invalid tenant", key: "ci", tenant: "Acme Inc", scopes: []string{internal.ScopeVehiclesRead}, err: internal.ErrorTenantInvalid},
+		{name: "scope required", key: "ci", err: internal.ErrorAPIKeyInvalidScope},
+		{name: "unknown scope", key: "ci", scopes: []string{"vehicles:all"}, err: internal.ErrorAPIKeyInvalidScope},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			st, path := newKeyFile(t)
+			k, secret, err := st.Issue(c.key, c.tenant, c.scopes, 0)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err != nil {
+				if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("key file stat = %v, want it not written", err)
+				}
+				return
+			}
+
+			// - the key is authenticated by its secret, also once the key file is read again
+			reloaded, err := auth.NewAPIKeyFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range []*auth.APIKeyFile{st, reloaded} {
+				got, err := s.Authenticate(secret)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.ID != k.ID || got.Tenant != c.tenant || len(got.Scopes) != len(c.scopes) {
+					t.Errorf("key = %+v, want %+v", got, k)
+				}
+			}
+			// - only the hash of the secret is stored
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(b), secret) || !strings.Contains(string(b), hash(secret)) {
+				t.Errorf("key file = %s, want the hash of the secret only", b)
+			}
+		})
+	}
+}
+
+func TestAPIKeyFile_Rotate(t *testing.T) {
+	cases := []struct {
+		name     string
+		grace    time.Duration
+		previous error
+	}{
+		{name: "previous secret accepted during the grace", grace: time.Hour},
+		{name: "previous secret rejected without grace", previous: internal.ErrorAPIKeyInvalid},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			st, _ := newKeyFile(t)
+			k, previous, err := st.Issue("ci", "", []string{internal.ScopeVehiclesRead}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rotated, secret, err := st.Rotate(k.ID, c.grace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rotated.ID != k.ID || rotated.RotatedAt.IsZero() || secret == previous {
+				t.Errorf("rotated key = %+v, want the same ID, a rotation time and a new secret", rotated)
+			}
+			if _, err := st.Authenticate(secret); err != nil {
+				t.Errorf("new secret: error = %v, want none", err)
+			}
+			if _, err := st.Authenticate(previous); !errors.Is(err, c.previous) {
+				t.Errorf("previous secret: error = %v, want %v", err, c.previous)
+			}
+		})
+	}
+}
+
+func TestAPIKeyFile_Revoke(t *testing.T) {
+	st, _ := newKeyFile(t)
+	k, previous, err := st.Issue("ci", "", []string{internal.ScopeVehiclesRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, secret, err := st.Rotate(k.ID, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := st.Revoke(k.ID); err != nil {
+		t.Fatal(err)
+	}
+	// - both secrets stop being accepted, even the one in its grace
+	for _, s := range []string{secret, previous} {
+		if _, err := st.Authenticate(s); !errors.Is(err, internal.ErrorAPIKeyInvalid) {
+			t.Errorf("error = %v, want %v", err, internal.ErrorAPIKeyInvalid)
+		}
+	}
+	// - a revoked key can be neither rotated nor revoked again
+	if _, _, err := st.Rotate(k.ID, 0); !errors.Is(err, internal.ErrorAPIKeyNotFound) {
+		t.Errorf("rotate: error = %v, want %v", err, internal.ErrorAPIKeyNotFound)
+	}
+	if err := st.Revoke(k.ID); !errors.Is(err, internal.ErrorAPIKeyNotFound) {
+		t.Errorf("revoke: error = %v, want %v", err, internal.ErrorAPIKeyNotFound)
+	}
+	if err := st.Revoke("unknown"); !errors.Is(err, internal.ErrorAPIKeyNotFound) {
+		t.Errorf("revoke unknown: error = %v, want %v", err, internal.ErrorAPIKeyNotFound)
+	}
+	// - it is still listed
+	keys, err := st.FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].RevokedAt.IsZero() {
+		t.Errorf("keys = %+v, want the revoked key", keys)
+	}
+}
+
+func TestAPIKeyFile_Authenticate_Expiry(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	cases := []struct {
+		name   string
+		key    auth.APIKeyJSON
+		secret string
+		err    error
+	}{
+		{name: "not expired", key: auth.APIKeyJSON{Hash: hash("current"), ExpiresAt: &future}, secret: "current"},
+		{name: "expired", key: auth.APIKeyJSON{Hash: hash("current"), ExpiresAt: &past}, secret: "current", err: internal.ErrorAPIKeyInvalid},
+		{name: "previous secret in its grace", key: auth.APIKeyJSON{Hash: hash("current"), PreviousHash: hash("previous"), PreviousExpiresAt: &future}, secret: "previous"},
+		{name: "previous secret past its grace", key: auth.APIKeyJSON{Hash: hash("current"), PreviousHash: hash("previous"), PreviousExpiresAt: &past}, secret: "previous", err: internal.ErrorAPIKeyInvalid},
+		{name: "previous secret of an expired key", key: auth.APIKeyJSON{Hash: hash("current"), PreviousHash: hash("previous"), PreviousExpiresAt: &future, ExpiresAt: &past}, secret: "previous", err: internal.ErrorAPIKeyInvalid},
+		{name: "unknown secret", key: auth.APIKeyJSON{Hash: hash("current")}, secret: "other", err: internal.ErrorAPIKeyInvalid},
+		{name: "no secret", key: auth.APIKeyJSON{Hash: hash("current")}, err: internal.ErrorAPIKeyMissing},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.key.ID, c.key.Name, c.key.Scopes, c.key.CreatedAt = "k1", "ci", []string{internal.ScopeVehiclesRead}, now.Add(-24*time.Hour)
+			b, err := json.Marshal([]auth.APIKeyJSON{c.key})
+			if err != nil {
+				t.Fatal(err)
+			}
+			st, err := auth.NewAPIKeyFile(writeFile(t, t.TempDir(), "keys.json", b))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			k, err := st.Authenticate(c.secret)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err == nil && k.ID != "k1" {
+				t.Errorf("key = %+v, want k1", k)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package auth
+
+import (
+	"app/internal"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Algorithms of the supported JWT signatures
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// ConfigJWT is a struct that represents the configuration for JWT
+type ConfigJWT struct {
+	// HMACSecretFile is the file with the shared secret of HS256 tokens
+	HMACSecretFile string
+	// RSAPublicKeyFile is the PEM file with the public key of RS256 tokens
+	RSAPublicKeyFile string
+	// JWKSFile is a JSON Web Key Set file, its keys are selected by the kid header
+	JWKSFile string
+	// Issuer is the required iss claim, empty accepts any
+	Issuer string
+	// Audience is the required aud claim, empty accepts any
+	Audience string
+	// RolesClaim is the claim holding the roles, a list or a space separated string
+	RolesClaim string
+	// TenantClaim is the claim holding the tenant the token is bound to
+	TenantClaim string
+	// Leeway is the clock skew tolerated on exp and nbf
+	Leeway time.Duration
+}
+
+// Enabled is a method that reports whether any verification key is configured
+func (c ConfigJWT) Enabled() bool {
+	return c.HMACSecretFile != "" || c.RSAPublicKeyFile != "" || c.JWKSFile != ""
+}
+
+// NewJWT is a function that returns a new instance of JWT, reading the verification keys
+func NewJWT(cfg ConfigJWT) (j *JWT, err error) {
+	// default values
+	if cfg.RolesClaim == "" {
+		cfg.RolesClaim = "roles"
+	}
+	if cfg.TenantClaim == "" {
+		cfg.TenantClaim = "tenant"
+	}
+	if cfg.Leeway == 0 {
+		cfg.Leeway = 30 * time.Second
+	}
+
+	j = &JWT{cfg: cfg, now: time.Now}
+	if cfg.HMACSecretFile != "" {
+		var b []byte
+		b, err = os.ReadFile(cfg.HMACSecretFile)
+		if err != nil {
+			err = fmt.Errorf("%w: %w", internal.ErrorJWTKeys, err)
+			return
+		}
+		secret := []byte(strings.TrimSpace(string(b)))
+		if len(secret) < 32 {
+			err = fmt.Errorf("%w: HS256 secret must have at least 32 bytes", internal.ErrorJWTKeys)
+			return
+		}
+		j.keys = append(j.keys, verificationKey{alg: AlgorithmHS256, secret: secret})
+	}
+	if cfg.RSAPublicKeyFile != "" {
+		var pub *rsa.PublicKey
+		pub, err = readRSAPublicKey(cfg.RSAPublicKeyFile)
+		if err != nil {
+			err = fmt.Errorf("%w: %w", internal.ErrorJWTKeys, err)
+			return
+		}
+		j.keys = append(j.keys, verificationKey{alg: AlgorithmRS256, rsa: pub})
+	}
+	if cfg.JWKSFile != "" {
+		var keys []verificationKey
+		keys, err = readJWKS(cfg.JWKSFile)
+		if err != nil {
+			err = fmt.Errorf("%w: %w", internal.ErrorJWTKeys, err)
+			return
+		}
+		j.keys = append(j.keys, keys...)
+	}
+	if len(j.keys) == 0 {
+		err = fmt.Errorf("%w: no key configured", internal.ErrorJWTKeys)
+	}
+	return
+}
+
+// JWT is a struct that verifies HS256 and RS256 JSON Web Tokens and maps their claims to a principal
+type JWT struct {
+	// cfg is the configuration of the verification
+	cfg ConfigJWT
+	// keys are the verification keys
+	keys []verificationKey
+	// now returns the current time
+	now func() time.Time
+}
+
+// verificationKey is a struct that represents a key able to verify a signature
+type verificationKey struct {
+	// kid is the identifier of the key in a JWKS, empty for keys read from a file
+	kid string
+	// alg is the algorithm the key verifies
+	alg string
+	// secret is the shared secret of HS256
+	secret []byte
+	// rsa is the public key of RS256
+	rsa *rsa.PublicKey
+}
+
+// header is a struct that represents the JOSE header of a token
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify is a method that checks the signature and the claims of a token and returns its principal
+func (j *JWT) Verify(token string) (p internal.Principal, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		err = fmt.Errorf("%w: malformed", internal.ErrorTokenInvalid)
+		return
+	}
+
+	// - header
+	var h header
+	if err = decodeSegment(parts[0], &h); err != nil {
+		return
+	}
+	signature, decodeErr := base64.RawURLEncoding.DecodeString(parts[2])
+	if decodeErr != nil {
+		err = fmt.Errorf("%w: malformed signature", internal.ErrorTokenInvalid)
+		return
+	}
+
+	// - signature: only the configured algorithms are accepted, never "none"
+	if err = j.verifySignature(h, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return
+	}
+
+	// - claims
+	var claims map[string]any
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return
+	}
+	if err = j.validateClaims(claims); err != nil {
+		return
+	}
+
+	p = internal.Principal{Method: internal.AuthMethodJWT}
+	p.Subject, _ = claims["sub"].(string)
+	p.Name, _ = claims["name"].(string)
+	p.Tenant, _ = claims[j.cfg.TenantClaim].(string)
+	for _, role := range stringList(claims[j.cfg.RolesClaim]) {
+		if slices.Contains(internal.Roles, role) && !slices.Contains(p.Roles, role) {
+			p.Roles = append(p.Roles, role)
+		}
+	}
+	return
+}
+
+// verifySignature is a method that checks a signature with the keys matching the header
+func (j *JWT) verifySignature(h header, signed []byte, signature []byte) (err error) {
+	for _, k := range j.keys {
+		if k.alg != h.Alg || (h.Kid != "" && k.kid != "" && k.kid != h.Kid) {
+			continue
+		}
+		switch k.alg {
+		case AlgorithmHS256:
+			mac := hmac.New(sha256.New, k.secret)
+			mac.Write(signed)
+			if hmac.Equal(mac.Sum(nil), signature) {
+				return
+			}
+		case AlgorithmRS256:
+			sum := sha256.Sum256(signed)
+			if rsa.VerifyPKCS1v15(k.rsa, crypto.SHA256, sum[:], signature) == nil {
+				return
+			}
+		}
+	}
+
+	err = fmt.Errorf("%w: signature not verified for alg %q", internal.ErrorTokenInvalid, h.Alg)
+	return
+}
+
+// validateClaims is a method that checks the registered claims of a token
+func (j *JWT) validateClaims(claims map[string]any) (err error) {
+	now := j.now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		err = fmt.Errorf("%w: exp claim required", internal.ErrorTokenInvalid)
+		return
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(j.cfg.Leeway)) {
+		err = internal.ErrorTokenExpired
+		return
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(j.cfg.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		err = fmt.Errorf("%w: not valid yet", internal.ErrorTokenInvalid)
+		return
+	}
+	if sub, _ := claims["sub"].(string); sub == "" {
+		err = fmt.Errorf("%w: sub claim required", internal.ErrorTokenInvalid)
+		return
+	}
+	if j.cfg.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != j.cfg.Issuer {
+			err = fmt.Errorf("%w: unexpected issuer", internal.ErrorTokenInvalid)
+			return
+		}
+	}
+	if j.cfg.Audience != "" && !slices.Contains(stringList(claims["aud"]), j.cfg.Audience) {
+		err = fmt.Errorf("%w: unexpected audience", internal.ErrorTokenInvalid)
+		return
+	}
+	return
+}
+
+// decodeSegment is a function that decodes a base64url JSON segment of a token
+func decodeSegment(segment string, v any) (err error) {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: malformed", internal.ErrorTokenInvalid)
+	}
+	return
+}
+
+// stringList is a function that returns a claim holding a string, a space separated string or a list of strings
+func stringList(claim any) (s []string) {
+	switch c := claim.(type) {
+	case string:
+		s = strings.Fields(c)
+	case []any:
+		for _, v := range c {
+			if str, ok := v.(string); ok {
+				s = append(s, str)
+			}
+		}
+	}
+	return
+}
+
+// readRSAPublicKey is a function that reads a PEM encoded RSA public key (PKIX or PKCS#1)
+func readRSAPublicKey(path string) (pub *rsa.PublicKey, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		err = fmt.Errorf("%s: no PEM block", path)
+		return
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		var key any
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return
+		}
+		var ok bool
+		if pub, ok = key.(*rsa.PublicKey); !ok {
+			err = fmt.Errorf("%s: not an RSA public key", path)
+		}
+	}
+	return
+}
+
+// jwk is a struct that represents a key of a JSON Web Key Set
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// readJWKS is a function that reads the RSA and symmetric keys of a JSON Web Key Set file
+func readJWKS(path string) (keys []verificationKey, err error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err = json.Unmarshal(b, &set); err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+		return
+	}
+
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			n, nErr := base64.RawURLEncoding.DecodeString(k.N)
+			e, eErr := base64.RawURLEncoding.DecodeString(k.E)
+			if nErr != nil || eErr != nil || len(e) == 0 || len(e) > 4 {
+				err = fmt.Errorf("%s: invalid RSA key %q", path, k.Kid)
+				return
+			}
+			pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+			keys = append(keys, verificationKey{kid: k.Kid, alg: AlgorithmRS256, rsa: pub})
+		case "oct":
+			secret, kErr := base64.RawURLEncoding.DecodeString(k.K)
+			if kErr != nil || len(secret) < 32 {
+				err = fmt.Errorf("%s: invalid symmetric key %q", path, k.Kid)
+				return
+			}
+			keys = append(keys, verificationKey{kid: k.Kid, alg: AlgorithmHS256, secret: secret})
+		}
+	}
+	if len(keys) == 0 {
+		err = fmt.Errorf("%s: no signing key", path)
+	}
+	return
+}
@@ -0,0 +1,274 @@
+package auth_test
+
+import (
+	"app/internal"
+	"app/internal/auth"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// jwtKeys is a struct that represents the signing keys of the tests and the files of their verification keys
+type jwtKeys struct {
+	// secret is the HS256 secret, written to secretFile
+	secret     []byte
+	secretFile string
+	// rsa is the RS256 key, its public key written in PEM to publicPEM and publicFile
+	rsa        *rsa.PrivateKey
+	publicPEM  []byte
+	publicFile string
+	// other is an RS256 key of no file
+	other *rsa.PrivateKey
+}
+
+// newJWTKeys is a function that returns new signing keys with their verification files in a temporary directory
+func newJWTKeys(t *testing.T) (k jwtKeys) {
+	t.Helper()
+	dir := t.TempDir()
+
+	// - printable, the secret file is trimmed
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	k.secret = []byte(hex.EncodeToString(b))
+	k.secretFile = writeFile(t, dir, "secret", k.secret)
+
+	var err error
+	if k.rsa, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+	if k.other, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&k.rsa.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	k.publicFile = writeFile(t, dir, "public.pem", k.publicPEM)
+	return
+}
+
+// writeFile is a function that writes a file in dir returning its path
+func writeFile(t *testing.T, dir string, name string, b []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// sign is a function that returns a token of claims with the header alg and kid, signed with key:
+// an HS256 secret ([]byte), an RS256 key (*rsa.PrivateKey) or nothing (nil)
+func sign(t *testing.T, alg string, kid string, key any, claims map[string]any) string {
+	t.Helper()
+	h := map[string]string{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		h["kid"] = kid
+	}
+	hb, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(cb)
+
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		sum := sha256.Sum256([]byte(signed))
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, sum[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWT_Verify(t *testing.T) {
+	k := newJWTKeys(t)
+	j, err := auth.NewJWT(auth.ConfigJWT{
+		HMACSecretFile:   k.secretFile,
+		RSAPublicKeyFile: k.publicFile,
+		Issuer:           "https://issuer",
+		Audience:         "vehicles",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	// claims is a function that returns valid claims, with the ones given replaced and the ones set to nil removed
+	claims := func(c map[string]any) map[string]any {
+		valid := map[string]any{"sub": "u1", "exp": now.Add(time.Hour).Unix(), "iss": "https://issuer", "aud": "vehicles"}
+		for name, value := range c {
+			if value == nil {
+				delete(valid, name)
+				continue
+			}
+			valid[name] = value
+		}
+		return valid
+	}
+
+	cases := []struct {
+		name  string
+		token string
+		p     internal.Principal
+		err   error
+	}{
+		{
+			name:  "HS256, unknown roles dropped",
+			token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"roles": []string{"operator", "root"}, "tenant": "a"})),
+			p:     internal.Principal{Subject: "u1", Method: internal.AuthMethodJWT, Roles: []string{internal.RoleOperator}, Tenant: "a"},
+		},
+		{
+			name:  "RS256, roles space separated",
+			token: sign(t, auth.AlgorithmRS256, "", k.rsa, claims(map[string]any{"roles": "viewer fleet-admin", "name": "User"})),
+			p:     internal.Principal{Subject: "u1", Name: "User", Method: internal.AuthMethodJWT, Roles: []string{internal.RoleViewer, internal.RoleFleetAdmin}},
+		},
+		{
+			name:  "audience among several",
+			token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"aud": []string{"other", "vehicles"}})),
+			p:     internal.Principal{Subject: "u1", Method: internal.AuthMethodJWT},
+		},
+		{
+			name:  "expired within the leeway",
+			token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()})),
+			p:     internal.Principal{Subject: "u1", Method: internal.AuthMethodJWT},
+		},
+		{name: "malformed", token: "a.b", err: internal.ErrorTokenInvalid},
+		{name: "alg none", token: sign(t, "none", "", nil, claims(nil)), err: internal.ErrorTokenInvalid},
+		{name: "RS256 header signed with the secret", token: sign(t, auth.AlgorithmRS256, "", k.secret, claims(nil)), err: internal.ErrorTokenInvalid},
+		{name: "HS256 header signed with the RSA key", token: sign(t, auth.AlgorithmHS256, "", k.rsa, claims(nil)), err: internal.ErrorTokenInvalid},
+		{name: "HS256 signed with the public key as secret", token: sign(t, auth.AlgorithmHS256, "", k.publicPEM, claims(nil)), err: internal.ErrorTokenInvalid},
+		{name: "RS256 of an unknown key", token: sign(t, auth.AlgorithmRS256, "", k.other, claims(nil)), err: internal.ErrorTokenInvalid},
+		{name: "exp missing", token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"exp": nil})), err: internal.ErrorTokenInvalid},
+		{name: "sub missing", token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"sub": nil})), err: internal.ErrorTokenInvalid},
+		{name: "sub empty", token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"sub": ""})), err: internal.ErrorTokenInvalid},
+		{name: "expired", token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})), err: internal.ErrorTokenExpired},
+		{name: "not valid yet", token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})), err: internal.ErrorTokenInvalid},
+		{name: "unexpected issuer", token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"iss": "https://other"})), err: internal.ErrorTokenInvalid},
+		{name: "unexpected audience", token: sign(t, auth.AlgorithmHS256, "", k.secret, claims(map[string]any{"aud": "other"})), err: internal.ErrorTokenInvalid},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p, err := j.Verify(c.token)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if p.Subject != c.p.Subject || p.Name != c.p.Name || p.Method != c.p.Method || p.Tenant != c.p.Tenant || !slices.Equal(p.Roles, c.p.Roles) {
+				t.Errorf("principal = %+v, want %+v", p, c.p)
+			}
+		})
+	}
+}
+
+func TestJWT_Verify_JWKS(t *testing.T) {
+	k := newJWTKeys(t)
+	// rsaJWK is a function that returns the JWK of the public key of an RSA key
+	rsaJWK := func(kid string, use string, key *rsa.PrivateKey) map[string]string {
+		return map[string]string{
+			"kty": "RSA",
+			"kid": kid,
+			"use": use,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	}
+	set, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		rsaJWK("k1", "sig", k.rsa),
+		rsaJWK("k2", "", k.other),
+		{"kty": "oct", "kid": "k3", "k": base64.RawURLEncoding.EncodeToString(k.secret)},
+		rsaJWK("enc", "enc", k.rsa),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, err := auth.NewJWT(auth.ConfigJWT{JWKSFile: writeFile(t, t.TempDir(), "jwks.json", set)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := map[string]any{"sub": "u1", "exp": time.Now().Add(time.Hour).Unix()}
+	cases := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{name: "RSA key of the kid", token: sign(t, auth.AlgorithmRS256, "k1", k.rsa, claims)},
+		{name: "other RSA key of the kid", token: sign(t, auth.AlgorithmRS256, "k2", k.other, claims)},
+		{name: "symmetric key of the kid", token: sign(t, auth.AlgorithmHS256, "k3", k.secret, claims)},
+		{name: "no kid tries every key of the alg", token: sign(t, auth.AlgorithmRS256, "", k.other, claims)},
+		{name: "key of another kid", token: sign(t, auth.AlgorithmRS256, "k2", k.rsa, claims), err: internal.ErrorTokenInvalid},
+		{name: "unknown kid", token: sign(t, auth.AlgorithmRS256, "k9", k.rsa, claims), err: internal.ErrorTokenInvalid},
+		{name: "kid of an encryption key", token: sign(t, auth.AlgorithmRS256, "enc", k.rsa, claims), err: internal.ErrorTokenInvalid},
+		{name: "alg of another kty", token: sign(t, auth.AlgorithmHS256, "k1", k.secret, claims), err: internal.ErrorTokenInvalid},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p, err := j.Verify(c.token)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err == nil && p.Subject != "u1" {
+				t.Errorf("subject = %q, want u1", p.Subject)
+			}
+		})
+	}
+}
+
+func TestNewJWT(t *testing.T) {
+	k := newJWTKeys(t)
+	dir := t.TempDir()
+
+	cases := []struct {
+		name string
+		cfg  auth.ConfigJWT
+		err  error
+	}{
+		{name: "secret and public key", cfg: auth.ConfigJWT{HMACSecretFile: k.secretFile, RSAPublicKeyFile: k.publicFile}},
+		{name: "no key", err: internal.ErrorJWTKeys},
+		{name: "secret too short", cfg: auth.ConfigJWT{HMACSecretFile: writeFile(t, dir, "short", []byte("short"))}, err: internal.ErrorJWTKeys},
+		{name: "missing secret file", cfg: auth.ConfigJWT{HMACSecretFile: filepath.Join(dir, "missing")}, err: internal.ErrorJWTKeys},
+		{name: "public key not PEM", cfg: auth.ConfigJWT{RSAPublicKeyFile: k.secretFile}, err: internal.ErrorJWTKeys},
+		{name: "JWKS without signing key", cfg: auth.ConfigJWT{JWKSFile: writeFile(t, dir, "jwks.json", []byte(`{"keys":[]}`))}, err: internal.ErrorJWTKeys},
+		{name: "JWKS of a short symmetric key", cfg: auth.ConfigJWT{JWKSFile: writeFile(t, dir, "oct.json", []byte(`{"keys":[{"kty":"oct","k":"c2hvcnQ"}]}`))}, err: internal.ErrorJWTKeys},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			j, err := auth.NewJWT(c.cfg)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err == nil && j == nil {
+				t.Error("JWT = nil, want one")
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"app/internal"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// HeaderAPIKey is the header carrying the API key, "Authorization: Bearer <key>" is accepted as well
+const HeaderAPIKey = "X-API-Key"
+
+// ConfigAuthenticator is a struct that represents the configuration for Authenticator
+type ConfigAuthenticator struct {
+	// APIKeys is the store of API keys, nil disables the API keys
+	APIKeys internal.APIKeyStore
+	// JWT verifies the bearer tokens, nil disables the tokens
+	JWT *JWT
+	// Policy governs which handler each principal may invoke
+	Policy Policy
+}
+
+// NewAuthenticator is a function that returns a new instance of Authenticator,
+// without API keys nor JWT the authentication is disabled and every middleware lets the requests through
+func NewAuthenticator(cfg ConfigAuthenticator) *Authenticator {
+	// default values
+	if cfg.Policy == nil {
+		cfg.Policy = DefaultPolicy
+	}
+
+	return &Authenticator{st: cfg.APIKeys, jwt: cfg.JWT, policy: cfg.Policy}
+}
+
+// Authenticator is a struct with methods that return the middlewares authenticating and authorizing requests
+type Authenticator struct {
+	// st is the store of API keys
+	st internal.APIKeyStore
+	// jwt verifies the bearer tokens
+	jwt *JWT
+	// policy governs which handler each principal may invoke
+	policy Policy
+}
+
+// Enabled is a method that reports whether the requests are authenticated
+func (a *Authenticator) Enabled() bool { return a.st != nil || a.jwt != nil }
+
+// Authenticate is a method that returns a middleware answering 401 unless the request carries
+// an active API key or a valid JWT, the principal is added to the request context
+func (a *Authenticator) Authenticate() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !a.Enabled() {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := a.AuthenticateCredential(credentialFromRequest(r))
+			if err != nil {
+				slog.WarnContext(r.Context(), "authentication failed", slog.String("error", err.Error()))
+				w.Header().Set("WWW-Authenticate", `Bearer realm="vehicles"`)
+				switch {
+				case errors.Is(err, internal.ErrorAPIKeyMissing), errors.Is(err, internal.ErrorTokenExpired):
+					response.Error(w, http.StatusUnauthorized, err.Error())
+				case errors.Is(err, internal.ErrorTokenInvalid):
+					response.Error(w, http.StatusUnauthorized, internal.ErrorTokenInvalid.Error())
+				default:
+					response.Error(w, http.StatusUnauthorized, internal.ErrorAPIKeyInvalid.Error())
+				}
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(internal.WithPrincipal(r.Context(), p)))
+		})
+	}
+}
+
+// Allow is a method that returns a middleware answering 403 unless the policy lets the principal
+// of the request invoke handler, it must run after Authenticate
+func (a *Authenticator) Allow(handler string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !a.Enabled() {
+			return next
+		}
+		if _, ok := a.policy[handler]; !ok {
+			// fail closed, but say why at startup rather than on each request
+			slog.Error(internal.ErrorPolicyMissing.Error(), slog.String("handler", handler))
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, _ := internal.PrincipalFromContext(r.Context())
+			if !a.Authorize(handler, p) {
+				slog.WarnContext(r.Context(), "authorization denied",
+					slog.String("handler", handler),
+					slog.String("subject", p.Subject),
+					slog.String("method", p.Method),
+				)
+				response.Error(w, http.StatusForbidden, internal.ErrorForbidden.Error()+": "+handler)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AuthenticateCredential is a method that returns the principal of an API key or a JWT,
+// ErrorAPIKeyMissing if secret is empty
+func (a *Authenticator) AuthenticateCredential(secret string) (p internal.Principal, err error) {
+	switch {
+	case secret == "":
+		err = internal.ErrorAPIKeyMissing
+	// - a JWT has three dot separated segments, API keys have none
+	case a.jwt != nil && strings.Count(secret, ".") == 2:
+		p, err = a.jwt.Verify(secret)
+	case a.st != nil:
+		var k internal.APIKey
+		k, err = a.st.Authenticate(secret)
+		if err == nil {
+			p = internal.Principal{Subject: k.ID, Name: k.Name, Method: internal.AuthMethodAPIKey, Scopes: k.Scopes, Tenant: k.Tenant}
+		}
+	default:
+		err = internal.ErrorTokenInvalid
+	}
+	return
+}
+
+// Authorize is a method that reports whether the policy lets a principal invoke handler,
+// every principal may when the authentication is disabled
+func (a *Authenticator) Authorize(handler string, p internal.Principal) bool {
+	return !a.Enabled() || a.policy.Allows(handler, p)
+}
+
+// credentialFromRequest is a function that returns the API key or token of a request, empty if there is none
+func credentialFromRequest(r *http.Request) string {
+	if secret := r.Header.Get(HeaderAPIKey); secret != "" {
+		return strings.TrimSpace(secret)
+	}
+	scheme, secret, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(secret)
+	}
+	return ""
+}
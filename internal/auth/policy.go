@@ -0,0 +1,114 @@
+package auth
+
+import "app/internal"
+
+// Rule is a struct that represents who may invoke a handler
+type Rule struct {
+	// Scope is the scope an API key needs
+	Scope string
+	// Roles are the roles of which a token needs one
+	Roles []string
+}
+
+// Policy is a table of the rules by handler, a handler without a rule is denied to everyone
+type Policy map[string]Rule
+
+// rules shared by the handlers of the default policy
+var (
+	ruleRead   = Rule{Scope: internal.ScopeVehiclesRead, Roles: []string{internal.RoleViewer, internal.RoleOperator, internal.RoleFleetAdmin}}
+	ruleWrite  = Rule{Scope: internal.ScopeVehiclesWrite, Roles: []string{internal.RoleOperator, internal.RoleFleetAdmin}}
+	ruleDelete = Rule{Scope: internal.ScopeVehiclesDelete, Roles: []string{internal.RoleFleetAdmin}}
+	ruleAdmin  = Rule{Scope: internal.ScopeAdmin, Roles: []string{internal.RoleFleetAdmin}}
+)
+
+// DefaultPolicy is the policy of the handlers registered by the application
+var DefaultPolicy = Policy{
+	// handler.VehicleDefault
+	"VehicleDefault.GetAll":                    ruleRead,
+	"VehicleDefault.GetByID":                   ruleRead,
+	"VehicleDefault.Create":                    ruleWrite,
+	"VehicleDefault.GetByBrandAndRangeYear":    ruleRead,
+	"VehicleDefault.GetByColorAndYear":         ruleRead,
+	"VehicleDefault.GetAverageSpeedByBrand":    ruleRead,
+	"VehicleDefault.CreateBatch":               ruleWrite,
+	"VehicleDefault.UpdateMaxSpeed":            ruleWrite,
+	"VehicleDefault.GetByFuelType":             ruleRead,
+	"VehicleDefault.Delete":                    ruleDelete,
+	"VehicleDefault.GetByTransmissionType":     ruleRead,
+	"VehicleDefault.UpdateFuelType":            ruleWrite,
+	"VehicleDefault.GetAverageCapacityByBrand": ruleRead,
+	"VehicleDefault.GetByDimensions":           ruleRead,
+	"VehicleDefault.GetByWeightRange":          ruleRead,
+	// handler.VehicleV2
+	"VehicleV2.List":                      ruleRead,
+	"VehicleV2.Get":                       ruleRead,
+	"VehicleV2.GetStats":                  ruleRead,
+	"VehicleV2.Search":                    ruleRead,
+	"VehicleV2.GetEnums":                  ruleRead,
+	"VehicleV2.GetByRegistration":         ruleRead,
+	"VehicleV2.GetRegistrationDuplicates": ruleRead,
+	"VehicleV2.GetByVIN":                  ruleRead,
+	"VehicleV2.Create":                    ruleWrite,
+	"VehicleV2.CreateBatch":               ruleWrite,
+	"VehicleV2.Update":                    ruleWrite,
+	"VehicleV2.Delete":                    ruleDelete,
+	// handler.CatalogDefault, shared by the tenants so only administrators change it
+	"CatalogDefault.GetBrands":   ruleRead,
+	"CatalogDefault.GetBrand":    ruleRead,
+	"CatalogDefault.CreateBrand": ruleAdmin,
+	"CatalogDefault.UpdateBrand": ruleAdmin,
+	"CatalogDefault.DeleteBrand": ruleAdmin,
+	"CatalogDefault.CreateModel": ruleAdmin,
+	"CatalogDefault.UpdateModel": ruleAdmin,
+	"CatalogDefault.DeleteModel": ruleAdmin,
+	// rpc.VehicleServer
+	"VehicleServer.ListVehicles":            ruleRead,
+	"VehicleServer.GetVehicle":              ruleRead,
+	"VehicleServer.CreateVehicle":           ruleWrite,
+	"VehicleServer.CreateVehicles":          ruleWrite,
+	"VehicleServer.FindByColorAndYear":      ruleRead,
+	"VehicleServer.FindByBrandAndRangeYear": ruleRead,
+	"VehicleServer.FindByFuelType":          ruleRead,
+	"VehicleServer.FindByTransmissionType":  ruleRead,
+	"VehicleServer.FindByDimensions":        ruleRead,
+	"VehicleServer.FindByWeightRange":       ruleRead,
+	"VehicleServer.AverageSpeedByBrand":     ruleRead,
+	"VehicleServer.AverageCapacityByBrand":  ruleRead,
+	"VehicleServer.UpdateMaxSpeed":          ruleWrite,
+	"VehicleServer.UpdateFuelType":          ruleWrite,
+	"VehicleServer.DeleteVehicle":           ruleDelete,
+	"VehicleServer.WatchChanges":            ruleRead,
+	// handler.GraphQLDefault, by the root fields of the operations
+	"GraphQLDefault.Query":  ruleRead,
+	"GraphQLDefault.Mutate": ruleWrite,
+	"GraphQLDefault.Delete": ruleDelete,
+	// handler.AdminDefault
+	"AdminDefault.Reload":          ruleAdmin,
+	"AdminDefault.GetReloadStatus": ruleAdmin,
+	// handler.APIKeyDefault
+	"APIKeyDefault.GetAll": ruleAdmin,
+	"APIKeyDefault.Issue":  ruleAdmin,
+	"APIKeyDefault.Rotate": ruleAdmin,
+	"APIKeyDefault.Revoke": ruleAdmin,
+	// handler.TenantDefault
+	"TenantDefault.GetTenants":     ruleAdmin,
+	"TenantDefault.GetAllVehicles": ruleAdmin,
+	// handler.UsageDefault
+	"UsageDefault.GetUsage": ruleAdmin,
+}
+
+// Allows is a method that reports whether a principal may invoke a handler
+func (p Policy) Allows(handler string, pr internal.Principal) bool {
+	rule, ok := p[handler]
+	if !ok {
+		return false
+	}
+
+	switch pr.Method {
+	case internal.AuthMethodAPIKey:
+		return pr.HasScope(rule.Scope)
+	case internal.AuthMethodJWT:
+		return pr.HasAnyRole(rule.Roles...)
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package auth_test
+
+import (
+	"app/internal"
+	"app/internal/auth"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestAuthenticator_Allow(t *testing.T) {
+	st, err := auth.NewAPIKeyFile(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := auth.NewAuthenticator(auth.ConfigAuthenticator{APIKeys: st})
+
+	// - the roles allowed along each scope, so the rules of the policy are not read back from it
+	roles := map[string][]string{
+		internal.ScopeVehiclesRead:   {internal.RoleViewer, internal.RoleOperator, internal.RoleFleetAdmin},
+		internal.ScopeVehiclesWrite:  {internal.RoleOperator, internal.RoleFleetAdmin},
+		internal.ScopeVehiclesDelete: {internal.RoleFleetAdmin},
+		internal.ScopeAdmin:          {internal.RoleFleetAdmin},
+	}
+	// allow is a function that returns the status of a request of a principal to a handler, none if p is nil
+	allow := func(handler string, p *internal.Principal) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if p != nil {
+			req = req.WithContext(internal.WithPrincipal(req.Context(), *p))
+		}
+		res := httptest.NewRecorder()
+		a.Allow(handler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(res, req)
+		return res.Code
+	}
+	// want is a function that returns the status expected for a request allowed or not
+	want := func(allowed bool) int {
+		if allowed {
+			return http.StatusOK
+		}
+		return http.StatusForbidden
+	}
+
+	for handler, rule := range auth.DefaultPolicy {
+		t.Run(handler, func(t *testing.T) {
+			if _, ok := roles[rule.Scope]; !ok {
+				t.Fatalf("scope = %q, want one of %v", rule.Scope, internal.Scopes)
+			}
+			for _, scope := range internal.Scopes {
+				p := internal.Principal{Subject: "k1", Method: internal.AuthMethodAPIKey, Scopes: []string{scope}}
+				if got := allow(handler, &p); got != want(scope == rule.Scope) {
+					t.Errorf("API key of the scope %s: status = %d, want %d", scope, got, want(scope == rule.Scope))
+				}
+			}
+			for _, role := range internal.Roles {
+				p := internal.Principal{Subject: "u1", Method: internal.AuthMethodJWT, Roles: []string{role}}
+				allowed := slices.Contains(roles[rule.Scope], role)
+				if got := allow(handler, &p); got != want(allowed) {
+					t.Errorf("token of the role %s: status = %d, want %d", role, got, want(allowed))
+				}
+			}
+			// - a token is never allowed by scopes, nor an API key by roles
+			every := internal.Principal{Subject: "u1", Method: internal.AuthMethodJWT, Scopes: internal.Scopes}
+			if got := allow(handler, &every); got != http.StatusForbidden {
+				t.Errorf("token of every scope: status = %d, want %d", got, http.StatusForbidden)
+			}
+			every = internal.Principal{Subject: "k1", Method: internal.AuthMethodAPIKey, Roles: internal.Roles}
+			if got := allow(handler, &every); got != http.StatusForbidden {
+				t.Errorf("API key of every role: status = %d, want %d", got, http.StatusForbidden)
+			}
+			if got := allow(handler, nil); got != http.StatusForbidden {
+				t.Errorf("no principal: status = %d, want %d", got, http.StatusForbidden)
+			}
+		})
+	}
+
+	t.Run("handler without a rule", func(t *testing.T) {
+		p := internal.Principal{Subject: "u1", Method: internal.AuthMethodJWT, Roles: internal.Roles}
+		if got := allow("VehicleDefault.Unknown", &p); got != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", got, http.StatusForbidden)
+		}
+	})
+
+	t.Run("authentication disabled", func(t *testing.T) {
+		disabled := auth.NewAuthenticator(auth.ConfigAuthenticator{})
+		res := httptest.NewRecorder()
+		disabled.Allow("VehicleDefault.Delete")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})).ServeHTTP(res, httptest.NewRequest(http.MethodDelete, "/", nil))
+		if res.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", res.Code, http.StatusOK)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"context"
+	"errors"
+)
+
+// BodyType is the body type of the models of a catalog
+type BodyType string
+
+// Body types of the models of a catalog
+const (
+	BodyTypeSedan       BodyType = "sedan"
+	BodyTypeCoupe       BodyType = "coupe"
+	BodyTypeConvertible BodyType = "convertible"
+	BodyTypeHatchback   BodyType = "hatchback"
+	BodyTypeWagon       BodyType = "wagon"
+	BodyTypeSUV         BodyType = "suv"
+	BodyTypeTruck       BodyType = "truck"
+	BodyTypeVan         BodyType = "van"
+	BodyTypeMinivan     BodyType = "minivan"
+)
+
+// BodyTypes are all the body types of the models of a catalog
+var BodyTypes = []BodyType{
+	BodyTypeSedan, BodyTypeCoupe, BodyTypeConvertible, BodyTypeHatchback, BodyTypeWagon,
+	BodyTypeSUV, BodyTypeTruck, BodyTypeVan, BodyTypeMinivan,
+}
+
+// CatalogModel is a struct that represents a model of a brand of the catalog
+type CatalogModel struct {
+	// Name is the canonical name of the model
+	Name string
+	// Aliases are other names the vehicles may give the model
+	Aliases []string
+	// BodyType is the body type of the model
+	BodyType BodyType
+	// YearFrom is the first year of production, zero if unknown
+	YearFrom int
+	// YearTo is the last year of production, zero if still produced or unknown
+	YearTo int
+}
+
+// Produced is a method that reports whether the model was produced in a year
+func (m CatalogModel) Produced(year int) bool {
+	return (m.YearFrom == 0 || year >= m.YearFrom) && (m.YearTo == 0 || year <= m.YearTo)
+}
+
+// CatalogBrand is a struct that represents a brand of the catalog with its models
+type CatalogBrand struct {
+	// Name is the canonical name of the brand
+	Name string
+	// Aliases are other names the vehicles may give the brand
+	Aliases []string
+	// Country is the country of origin of the brand
+	Country string
+	// Models are the models of the brand
+	Models []CatalogModel
+}
+
+// CatalogLink is a struct that represents the entries of the catalog a vehicle is linked to by its brand and model
+type CatalogLink struct {
+	// Brand is the brand, with its models
+	Brand CatalogBrand
+	// Model is the model
+	Model CatalogModel
+}
+
+// CatalogFilter is a struct that represents the catalog entries the vehicles are linked to, an empty field matches any
+type CatalogFilter struct {
+	// Brand is the name or an alias of the brand
+	Brand string
+	// Model is the name or an alias of the model
+	Model string
+	// BodyType is the body type of the model
+	BodyType BodyType
+	// Country is the country of origin of the brand
+	Country string
+}
+
+// Catalog is an interface that represents the brands and models the vehicles are linked to
+type Catalog interface {
+	// Resolve is a method that returns the entries of a brand and a model given by their names or aliases
+	Resolve(brand string, model string) (link CatalogLink, err error)
+
+	// Match is a method that reports whether the entries of a brand and a model match a filter,
+	// false if they are not in the catalog
+	Match(brand string, model string, filter CatalogFilter) (ok bool)
+}
+
+// CatalogRepository is an interface that represents a repository of the catalog, the brands are identified
+// by their names or aliases and the models by theirs within their brand
+type CatalogRepository interface {
+	Catalog
+
+	// FindAllContext is a method that returns all the brands sorted by name
+	FindAllContext(ctx context.Context) (b []CatalogBrand, err error)
+
+	// FindBrandContext is a method that returns a brand
+	FindBrandContext(ctx context.Context, brand string) (b CatalogBrand, err error)
+
+	// CreateBrandContext is a method that adds a brand with its models
+	CreateBrandContext(ctx context.Context, b CatalogBrand) (err error)
+
+	// UpdateBrandContext is a method that replaces a brand, its models included
+	UpdateBrandContext(ctx context.Context, brand string, b CatalogBrand) (err error)
+
+	// DeleteBrandContext is a method that removes a brand with its models
+	DeleteBrandContext(ctx context.Context, brand string) (err error)
+
+	// CreateModelContext is a method that adds a model to a brand
+	CreateModelContext(ctx context.Context, brand string, m CatalogModel) (err error)
+
+	// UpdateModelContext is a method that replaces a model of a brand
+	UpdateModelContext(ctx context.Context, brand string, model string, m CatalogModel) (err error)
+
+	// DeleteModelContext is a method that removes a model of a brand
+	DeleteModelContext(ctx context.Context, brand string, model string) (err error)
+
+	// ReplaceAllContext is a method that replaces every brand, as loaded by a CatalogLoader
+	ReplaceAllContext(ctx context.Context, b []CatalogBrand) (err error)
+}
+
+// CatalogLoader is an interface that represents the loader for the catalog
+type CatalogLoader interface {
+	// Load is a method that loads the brands with their models
+	Load() (b []CatalogBrand, err error)
+}
+
+// Errors in the catalog
+var (
+	ErrorCatalogBrandNotFound = errors.New("Brand not found in the catalog")
+	ErrorCatalogModelNotFound = errors.New("Model not found in the catalog")
+	ErrorCatalogConflict      = errors.New("Name or alias already in the catalog")
+	ErrorCatalogInvalid       = errors.New("Invalid catalog entry")
+	ErrorVehicleNotInCatalog  = errors.New("Vehicle does not match the catalog")
+)
@@ -0,0 +1,592 @@
+package config
+
+import (
+	"app/internal/application"
+	"app/internal/loader"
+	"app/internal/logging"
+	"app/internal/normalize"
+	"app/internal/registration"
+	"app/internal/tracing"
+	"app/internal/vocabulary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Errors in configuration
+var (
+	ErrorUnknownSetting = errors.New("Unknown setting")
+	ErrorInvalidSetting = errors.New("Invalid setting")
+	ErrorConfigFile     = errors.New("Invalid config file")
+)
+
+// EnvPrefix is the prefix of the environment variables read by Load
+const EnvPrefix = "APP_"
+
+// setting is a struct that represents a configurable value of ConfigServerChi
+// - key is the dotted key in the config file, the env var and flag names are derived from it
+type setting struct {
+	// key is the dotted key of the setting in the config file, e.g. server.read_timeout
+	key string
+	// usage is the description shown in the help of the flags
+	usage string
+	// field returns a pointer to the field of the configuration holding the value
+	field func(cfg *application.ConfigServerChi) any
+}
+
+// settings are every configurable value, new settings only need to be added here
+var settings = []setting{
+	{
+		key:   "server.address",
+		usage: "address where the server will be listening",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerAddress },
+	},
+	{
+		key:   "grpc.address",
+		usage: "address where the gRPC server will be listening, empty (the default) disables it",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.GRPCAddress },
+	},
+	{
+		key:   "server.read_timeout",
+		usage: "maximum duration for reading an entire request",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerReadTimeout },
+	},
+	{
+		key:   "server.write_timeout",
+		usage: "maximum duration before timing out writes of a response",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerWriteTimeout },
+	},
+	{
+		key:   "server.idle_timeout",
+		usage: "maximum time to wait for the next request on keep-alive connections",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerIdleTimeout },
+	},
+	{
+		key:   "server.shutdown_timeout",
+		usage: "deadline to drain in-flight requests on shutdown",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerShutdownTimeout },
+	},
+	{
+		key:   "server.request_timeout",
+		usage: "deadline to handle a request on the vehicle routes",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerRequestTimeout },
+	},
+	{
+		key:   "server.batch_timeout",
+		usage: "deadline to handle a request on the batch route",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerBatchTimeout },
+	},
+	{
+		key:   "server.max_body_bytes",
+		usage: "largest request body read, a larger one is answered 413",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ServerMaxBodyBytes },
+	},
+	{
+		key:   "storage.backend",
+		usage: "storage used by the repository (memory)",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.StorageBackend },
+	},
+	{
+		key:   "log.level",
+		usage: "minimum level of the logs: debug, info, warn or error",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.LogLevel },
+	},
+	{
+		key:   "log.format",
+		usage: "format of the logs: json or text",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.LogFormat },
+	},
+	{
+		key:   "auth.key_file",
+		usage: "JSON file with the hashed API keys, empty disables authentication",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthKeyFile },
+	},
+	{
+		key:   "auth.rotation_grace",
+		usage: "time the previous secret of a rotated API key is still accepted",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthRotationGrace },
+	},
+	{
+		key:   "auth.jwt.hmac_secret_file",
+		usage: "file with the shared secret of HS256 tokens",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthJWTHMACSecretFile },
+	},
+	{
+		key:   "auth.jwt.rsa_public_key_file",
+		usage: "PEM file with the public key of RS256 tokens",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthJWTRSAPublicKeyFile },
+	},
+	{
+		key:   "auth.jwt.jwks_file",
+		usage: "JSON Web Key Set file with the token keys, selected by kid",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthJWTJWKSFile },
+	},
+	{
+		key:   "auth.jwt.issuer",
+		usage: "required iss claim of the tokens, empty accepts any",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthJWTIssuer },
+	},
+	{
+		key:   "auth.jwt.audience",
+		usage: "required aud claim of the tokens, empty accepts any",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthJWTAudience },
+	},
+	{
+		key:   "auth.jwt.roles_claim",
+		usage: "claim of the tokens holding the roles: viewer, operator or fleet-admin",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthJWTRolesClaim },
+	},
+	{
+		key:   "auth.jwt.tenant_claim",
+		usage: "claim of the tokens holding the tenant they are bound to",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.AuthJWTTenantClaim },
+	},
+	{
+		key:   "ratelimit.read_per_minute",
+		usage: "requests per minute of each client on the routes that read vehicles, 0 (the default) disables the limit",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitRead.PerMinute },
+	},
+	{
+		key:   "ratelimit.read_burst",
+		usage: "burst of requests of each client on the routes that read vehicles, defaults to the per minute rate",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitRead.Burst },
+	},
+	{
+		key:   "ratelimit.read_daily_quota",
+		usage: "requests per UTC day of each client on the routes that read vehicles, 0 disables the quota",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitRead.DailyQuota },
+	},
+	{
+		key:   "ratelimit.write_per_minute",
+		usage: "requests per minute of each client on the routes that create, update or delete a vehicle, 0 (the default) disables the limit",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitWrite.PerMinute },
+	},
+	{
+		key:   "ratelimit.write_burst",
+		usage: "burst of requests of each client on the routes that create, update or delete a vehicle, defaults to the per minute rate",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitWrite.Burst },
+	},
+	{
+		key:   "ratelimit.write_daily_quota",
+		usage: "requests per UTC day of each client on the routes that create, update or delete a vehicle, 0 disables the quota",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitWrite.DailyQuota },
+	},
+	{
+		key:   "ratelimit.batch_per_minute",
+		usage: "requests per minute of each client on the batch route, 0 (the default) disables the limit",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitBatch.PerMinute },
+	},
+	{
+		key:   "ratelimit.batch_burst",
+		usage: "burst of requests of each client on the batch route, defaults to the per minute rate",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitBatch.Burst },
+	},
+	{
+		key:   "ratelimit.batch_daily_quota",
+		usage: "requests per UTC day of each client on the batch route, 0 disables the quota",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RateLimitBatch.DailyQuota },
+	},
+	{
+		key:   "graphql.max_depth",
+		usage: "maximum depth of nested fields of a GraphQL operation",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.GraphQLMaxDepth },
+	},
+	{
+		key:   "graphql.max_complexity",
+		usage: "maximum complexity of a GraphQL operation, each field costs 1 and the fields under a list 10 times more",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.GraphQLMaxComplexity },
+	},
+	{
+		key:   "tracing.exporter",
+		usage: "destination of the request spans: none, stdout or file",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.TracingExporter },
+	},
+	{
+		key:   "tracing.file_path",
+		usage: "file the spans are appended to when tracing.exporter is file",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.TracingFilePath },
+	},
+	{
+		key:   "loader.file_path",
+		usage: "path to the JSON file that contains the vehicles",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.LoaderFilePath },
+	},
+	{
+		key:   "loader.sources",
+		usage: "comma separated files, directories or glob patterns merged into the fleet",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.LoaderSources },
+	},
+	{
+		key:   "loader.tenant_sources",
+		usage: "comma separated tenant=source entries loading a fleet per tenant, replaces loader.file_path and loader.sources",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.LoaderTenantSources },
+	},
+	{
+		key:   "loader.merge_policy",
+		usage: "policy on collisions between sources: first-wins, last-wins, fail or renumber",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.LoaderMergePolicy },
+	},
+	{
+		key:   "loader.reload_interval",
+		usage: "time between checks of the loader files for changes, 0 (the default) disables hot reload",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.ReloadInterval },
+	},
+	{
+		key:   "normalize.synonyms",
+		usage: "comma separated field:alias=canonical entries matched as the same value by the filters and the catalog of brand, model, color, fuel_type and transmission",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.NormalizeSynonyms },
+	},
+	{
+		key:   "vocabulary.fuel_types",
+		usage: "comma separated fuel types allowed for the vehicles written, compared with the keys of normalize.synonyms",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.VocabularyFuelTypes },
+	},
+	{
+		key:   "vocabulary.transmissions",
+		usage: "comma separated transmissions allowed for the vehicles written, compared with the keys of normalize.synonyms",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.VocabularyTransmissions },
+	},
+	{
+		key:   "catalog.file_path",
+		usage: "JSON file with the brands and models the vehicles written must match, empty (the default) disables the catalog",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.CatalogFilePath },
+	},
+	{
+		key:   "registration.countries",
+		usage: "comma separated countries whose registration formats are allowed for the vehicles written: any or one of the built-in or registration.formats countries",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RegistrationCountries },
+	},
+	{
+		key:   "registration.formats",
+		usage: "comma separated country=regexp entries adding or replacing the format of a country, matched against the registration upper case without whitespace, hyphens and dots (repetitions with commas only in the config file)",
+		field: func(cfg *application.ConfigServerChi) any { return &cfg.RegistrationFormats },
+	},
+}
+
+// Options is a struct that represents the command line options that are not settings
+type Options struct {
+	// ConfigFile is the path of the YAML config file
+	ConfigFile string
+	// PrintConfig reports that the effective configuration must be printed instead of running the server
+	PrintConfig bool
+}
+
+// Default is a function that returns the configuration used when nothing is set, the one NewServerChi
+// completes the settings left empty with
+func Default() (cfg *application.ConfigServerChi) {
+	cfg = application.DefaultConfigServerChi()
+	return
+}
+
+// Load is a function that builds the configuration from, in increasing precedence:
+// defaults, the config file (--config or APP_CONFIG), environment variables (APP_*) and flags
+func Load(args []string, lookupEnv func(string) (string, bool)) (cfg *application.ConfigServerChi, opts Options, err error) {
+	cfg = Default()
+
+	// flags: parsed first to know the config file, applied last
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.StringVar(&opts.ConfigFile, "config", "", "path of the YAML config file (env "+EnvPrefix+"CONFIG)")
+	fs.BoolVar(&opts.PrintConfig, "print-config", false, "print the effective configuration and exit")
+	flagValues := make(map[string]string)
+	for _, s := range settings {
+		key := s.key
+		fs.Func(flagName(key), s.usage+" (env "+envName(key)+")", func(value string) error {
+			flagValues[key] = value
+			return nil
+		})
+	}
+	err = fs.Parse(args)
+	if err != nil {
+		return
+	}
+
+	// - config file
+	if opts.ConfigFile == "" {
+		opts.ConfigFile, _ = lookupEnv(EnvPrefix + "CONFIG")
+	}
+	if opts.ConfigFile != "" {
+		err = applyFile(cfg, opts.ConfigFile)
+		if err != nil {
+			return
+		}
+	}
+
+	// - environment variables
+	for _, s := range settings {
+		if value, ok := lookupEnv(envName(s.key)); ok {
+			err = set(cfg, s, value)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// - flags
+	for _, s := range settings {
+		if value, ok := flagValues[s.key]; ok {
+			err = set(cfg, s, value)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	err = Validate(cfg)
+	return
+}
+
+// Validate is a function that checks the values of a configuration, joining all the errors found
+func Validate(cfg *application.ConfigServerChi) (err error) {
+	var errs []error
+	invalid := func(key string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: %s %s", ErrorInvalidSetting, key, fmt.Sprintf(format, args...)))
+	}
+
+	if _, _, splitErr := net.SplitHostPort(cfg.ServerAddress); splitErr != nil {
+		invalid("server.address", "must be host:port, got %q", cfg.ServerAddress)
+	}
+	if cfg.GRPCAddress != "" {
+		if _, _, splitErr := net.SplitHostPort(cfg.GRPCAddress); splitErr != nil {
+			invalid("grpc.address", "must be host:port or empty, got %q", cfg.GRPCAddress)
+		}
+	}
+	durations := map[string]time.Duration{
+		"server.read_timeout":     cfg.ServerReadTimeout,
+		"server.write_timeout":    cfg.ServerWriteTimeout,
+		"server.idle_timeout":     cfg.ServerIdleTimeout,
+		"server.shutdown_timeout": cfg.ServerShutdownTimeout,
+		"server.request_timeout":  cfg.ServerRequestTimeout,
+		"server.batch_timeout":    cfg.ServerBatchTimeout,
+		"loader.reload_interval":  cfg.ReloadInterval,
+		"auth.rotation_grace":     cfg.AuthRotationGrace,
+	}
+	for _, s := range settings {
+		if d, ok := durations[s.key]; ok && d < 0 {
+			invalid(s.key, "must not be negative, got %s", d)
+		}
+	}
+	// - a response written after the write timeout is lost, the handlers must time out before it
+	if cfg.ServerWriteTimeout > 0 && cfg.ServerWriteTimeout < cfg.ServerRequestTimeout {
+		invalid("server.write_timeout", "must not be shorter than server.request_timeout %s, got %s", cfg.ServerRequestTimeout, cfg.ServerWriteTimeout)
+	}
+	if cfg.ServerWriteTimeout > 0 && cfg.ServerWriteTimeout < cfg.ServerBatchTimeout {
+		invalid("server.write_timeout", "must not be shorter than server.batch_timeout %s, got %s", cfg.ServerBatchTimeout, cfg.ServerWriteTimeout)
+	}
+	for _, s := range settings {
+		if n, ok := s.field(cfg).(*int); ok && *n < 0 {
+			invalid(s.key, "must not be negative, got %d", *n)
+		}
+	}
+	if cfg.StorageBackend != application.StorageBackendMemory {
+		invalid("storage.backend", "must be %s, got %q", application.StorageBackendMemory, cfg.StorageBackend)
+	}
+	if _, logErr := logging.New(io.Discard, cfg.LogLevel, cfg.LogFormat); logErr != nil {
+		switch {
+		case errors.Is(logErr, logging.ErrorInvalidLevel):
+			invalid("log.level", "must be debug, info, warn or error, got %q", cfg.LogLevel)
+		default:
+			invalid("log.format", "must be json or text, got %q", cfg.LogFormat)
+		}
+	}
+	switch cfg.TracingExporter {
+	case tracing.ExporterNone, tracing.ExporterStdout:
+	case tracing.ExporterFile:
+		if cfg.TracingFilePath == "" {
+			invalid("tracing.file_path", "is required by the file exporter")
+		}
+	default:
+		invalid("tracing.exporter", "must be none, stdout or file, got %q", cfg.TracingExporter)
+	}
+	if cfg.LoaderFilePath == "" && len(cfg.LoaderSources) == 0 {
+		invalid("loader.file_path", "or loader.sources is required")
+	}
+	if _, tenantErr := loader.ParseTenantSources(cfg.LoaderTenantSources); tenantErr != nil {
+		invalid("loader.tenant_sources", "%s", tenantErr)
+	}
+	if _, policyErr := loader.ParseMergePolicy(cfg.LoaderMergePolicy); policyErr != nil {
+		invalid("loader.merge_policy", "must be first-wins, last-wins, fail or renumber, got %q", cfg.LoaderMergePolicy)
+	}
+	synonyms, synonymErr := normalize.ParseSynonyms(cfg.NormalizeSynonyms)
+	if synonymErr != nil {
+		invalid("normalize.synonyms", "%s", synonymErr)
+	}
+	nm := normalize.NewFolding(synonyms)
+	if _, vocabularyErr := vocabulary.NewRegistry(nm, cfg.VocabularyFuelTypes, vocabulary.DefaultTransmissions); vocabularyErr != nil {
+		invalid("vocabulary.fuel_types", "%s", vocabularyErr)
+	}
+	if _, vocabularyErr := vocabulary.NewRegistry(nm, vocabulary.DefaultFuelTypes, cfg.VocabularyTransmissions); vocabularyErr != nil {
+		invalid("vocabulary.transmissions", "%s", vocabularyErr)
+	}
+	formats, formatErr := registration.ParseFormats(cfg.RegistrationFormats)
+	if formatErr != nil {
+		invalid("registration.formats", "%s", formatErr)
+	}
+	if _, registrationErr := registration.NewRegistry(cfg.RegistrationCountries, formats); registrationErr != nil {
+		invalid("registration.countries", "%s", registrationErr)
+	}
+
+	err = errors.Join(errs...)
+	return
+}
+
+// Print is a function that writes a configuration in the config file format
+func Print(w io.Writer, cfg *application.ConfigServerChi) (err error) {
+	doc := make(map[string]map[string]any)
+	for _, s := range settings {
+		section, name, _ := strings.Cut(s.key, ".")
+		if doc[section] == nil {
+			doc[section] = make(map[string]any)
+		}
+
+		switch field := s.field(cfg).(type) {
+		case *time.Duration:
+			doc[section][name] = field.String()
+		case *[]string:
+			doc[section][name] = *field
+		case *int:
+			doc[section][name] = *field
+		case *string:
+			doc[section][name] = *field
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	err = enc.Encode(doc)
+	if err != nil {
+		return
+	}
+	err = enc.Close()
+	return
+}
+
+// applyFile is a function that applies the settings of a YAML config file
+func applyFile(cfg *application.ConfigServerChi, path string) (err error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrorConfigFile, err)
+		return
+	}
+
+	var doc map[string]any
+	err = yaml.Unmarshal(bytes, &doc)
+	if err != nil {
+		err = fmt.Errorf("%w: %s: %w", ErrorConfigFile, path, err)
+		return
+	}
+
+	values := make(map[string]string)
+	flatten("", doc, values)
+
+	// keys are applied in a stable order so errors are reproducible
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s, ok := lookup(key)
+		if !ok {
+			err = fmt.Errorf("%w: %s in %s", ErrorUnknownSetting, key, path)
+			return
+		}
+		err = set(cfg, s, values[key])
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// flatten is a function that turns a nested YAML document into dotted keys and string values
+func flatten(prefix string, node map[string]any, values map[string]string) {
+	for key, value := range node {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			flatten(key, value, values)
+		case []any:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(value)
+		}
+	}
+}
+
+// set is a function that parses a raw value and stores it in the field of the setting
+func set(cfg *application.ConfigServerChi, s setting, value string) (err error) {
+	value = strings.TrimSpace(value)
+
+	switch field := s.field(cfg).(type) {
+	case *string:
+		*field = value
+	case *[]string:
+		*field = nil
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*field = append(*field, item)
+			}
+		}
+	case *int:
+		*field, err = strconv.Atoi(value)
+		if err != nil {
+			err = fmt.Errorf("%w: %s must be an integer, got %q", ErrorInvalidSetting, s.key, value)
+		}
+	case *time.Duration:
+		var d time.Duration
+		d, err = time.ParseDuration(value)
+		if err != nil {
+			// plain numbers are seconds
+			seconds, convErr := strconv.ParseFloat(value, 64)
+			if convErr != nil {
+				err = fmt.Errorf("%w: %s must be a duration, got %q", ErrorInvalidSetting, s.key, value)
+				return
+			}
+			d, err = time.Duration(seconds*float64(time.Second)), nil
+		}
+		*field = d
+	}
+
+	return
+}
+
+// lookup is a function that returns the setting with the given key
+func lookup(key string) (s setting, ok bool) {
+	for _, s = range settings {
+		if s.key == key {
+			ok = true
+			return
+		}
+	}
+	return
+}
+
+// envName is a function that returns the environment variable of a setting key
+func envName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// flagName is a function that returns the flag of a setting key
+func flagName(key string) string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(key)
+}
@@ -0,0 +1,278 @@
+package config_test
+
+import (
+	"app/internal/application"
+	"app/internal/config"
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// env is a function that returns a lookup of the environment variables given
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (value string, ok bool) {
+		value, ok = vars[name]
+		return
+	}
+}
+
+// writeConfig is a function that writes a config file in a temporary directory returning its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	file := writeConfig(t, `
+server:
+  address: ":9000"
+  read_timeout: 7
+log:
+  level: debug
+ratelimit:
+  read_per_minute: 10
+registration:
+  countries: [es, fr]
+`)
+	other := writeConfig(t, `
+server:
+  address: ":9100"
+`)
+	defaults := config.Default()
+
+	cases := []struct {
+		name        string
+		args        []string
+		env         map[string]string
+		address     string
+		readTimeout time.Duration
+		level       string
+		perMinute   int
+		countries   []string
+	}{
+		{
+			name:        "defaults",
+			address:     defaults.ServerAddress,
+			readTimeout: defaults.ServerReadTimeout,
+			level:       defaults.LogLevel,
+			countries:   defaults.RegistrationCountries,
+		},
+		{
+			name:        "file over the defaults",
+			args:        []string{"--config", file},
+			address:     ":9000",
+			readTimeout: 7 * time.Second,
+			level:       "debug",
+			perMinute:   10,
+			countries:   []string{"es", "fr"},
+		},
+		{
+			name:        "file of the environment",
+			env:         map[string]string{"APP_CONFIG": file},
+			address:     ":9000",
+			readTimeout: 7 * time.Second,
+			level:       "debug",
+			perMinute:   10,
+			countries:   []string{"es", "fr"},
+		},
+		{
+			name:        "file of the flag over the one of the environment",
+			args:        []string{"--config", other},
+			env:         map[string]string{"APP_CONFIG": file},
+			address:     ":9100",
+			readTimeout: defaults.ServerReadTimeout,
+			level:       defaults.LogLevel,
+			countries:   defaults.RegistrationCountries,
+		},
+		{
+			name:        "environment over the file",
+			args:        []string{"--config", file},
+			env:         map[string]string{"APP_LOG_LEVEL": "warn", "APP_SERVER_READ_TIMEOUT": "1500ms", "APP_REGISTRATION_COUNTRIES": " uk , "},
+			address:     ":9000",
+			readTimeout: 1500 * time.Millisecond,
+			level:       "warn",
+			perMinute:   10,
+			countries:   []string{"uk"},
+		},
+		{
+			name:        "flags over the environment",
+			args:        []string{"--config", file, "--log-level", "error", "--ratelimit-read-per-minute=20"},
+			env:         map[string]string{"APP_LOG_LEVEL": "warn", "APP_RATELIMIT_READ_PER_MINUTE": "15"},
+			address:     ":9000",
+			readTimeout: 7 * time.Second,
+			level:       "error",
+			perMinute:   20,
+			countries:   []string{"es", "fr"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg, _, err := config.Load(c.args, env(c.env))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.ServerAddress != c.address || cfg.ServerReadTimeout != c.readTimeout || cfg.LogLevel != c.level || cfg.RateLimitRead.PerMinute != c.perMinute {
+				t.Errorf("config = %s %s %s %d, want %s %s %s %d",
+					cfg.ServerAddress, cfg.ServerReadTimeout, cfg.LogLevel, cfg.RateLimitRead.PerMinute,
+					c.address, c.readTimeout, c.level, c.perMinute)
+			}
+			if !reflect.DeepEqual(cfg.RegistrationCountries, c.countries) {
+				t.Errorf("registration countries = %v, want %v", cfg.RegistrationCountries, c.countries)
+			}
+		})
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		args []string
+		env  map[string]string
+		err  error
+	}{
+		{name: "help", args: []string{"-h"}, err: flag.ErrHelp},
+		{name: "missing file", args: []string{"--config", filepath.Join(t.TempDir(), "missing.yaml")}, err: config.ErrorConfigFile},
+		{name: "file not YAML", args: []string{"--config", writeConfig(t, "server: [")}, err: config.ErrorConfigFile},
+		{name: "unknown setting of the file", args: []string{"--config", writeConfig(t, "server:\n  port: 80\n")}, err: config.ErrorUnknownSetting},
+		{name: "integer of the environment", env: map[string]string{"APP_GRAPHQL_MAX_DEPTH": "deep"}, err: config.ErrorInvalidSetting},
+		{name: "duration of a flag", args: []string{"--server-read-timeout", "soon"}, err: config.ErrorInvalidSetting},
+		{name: "validated at last", args: []string{"--log-level", "trace"}, err: config.ErrorInvalidSetting},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, _, err := config.Load(c.args, env(c.env))
+			if !errors.Is(err, c.err) {
+				t.Errorf("error = %v, want %v", err, c.err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config func(cfg *application.ConfigServerChi)
+		keys   []string
+	}{
+		{name: "defaults", config: func(cfg *application.ConfigServerChi) {}},
+		{name: "server address", config: func(cfg *application.ConfigServerChi) { cfg.ServerAddress = "8080" }, keys: []string{"server.address"}},
+		{name: "gRPC address", config: func(cfg *application.ConfigServerChi) { cfg.GRPCAddress = "localhost" }, keys: []string{"grpc.address"}},
+		{name: "negative duration", config: func(cfg *application.ConfigServerChi) { cfg.ServerIdleTimeout = -time.Second }, keys: []string{"server.idle_timeout"}},
+		{name: "negative integer", config: func(cfg *application.ConfigServerChi) { cfg.RateLimitBatch.Burst = -1 }, keys: []string{"ratelimit.batch_burst"}},
+		{
+			name:   "write timeout shorter than the request timeout",
+			config: func(cfg *application.ConfigServerChi) { cfg.ServerWriteTimeout, cfg.ServerBatchTimeout = 3*time.Second, time.Second },
+			keys:   []string{"server.write_timeout", "server.request_timeout"},
+		},
+		{
+			name:   "write timeout shorter than the batch timeout",
+			config: func(cfg *application.ConfigServerChi) { cfg.ServerWriteTimeout = 10 * time.Second },
+			keys:   []string{"server.write_timeout", "server.batch_timeout"},
+		},
+		{
+			name:   "write timeout disabled",
+			config: func(cfg *application.ConfigServerChi) { cfg.ServerWriteTimeout = 0 },
+		},
+		{name: "storage backend", config: func(cfg *application.ConfigServerChi) { cfg.StorageBackend = "redis" }, keys: []string{"storage.backend"}},
+		{name: "log level", config: func(cfg *application.ConfigServerChi) { cfg.LogLevel = "trace" }, keys: []string{"log.level"}},
+		{name: "log format", config: func(cfg *application.ConfigServerChi) { cfg.LogFormat = "xml" }, keys: []string{"log.format"}},
+		{name: "tracing exporter", config: func(cfg *application.ConfigServerChi) { cfg.TracingExporter = "zipkin" }, keys: []string{"tracing.exporter"}},
+		{name: "tracing file required", config: func(cfg *application.ConfigServerChi) { cfg.TracingExporter = "file" }, keys: []string{"tracing.file_path"}},
+		{name: "loader sources required", config: func(cfg *application.ConfigServerChi) { cfg.LoaderFilePath = "" }, keys: []string{"loader.file_path"}},
+		{name: "tenant sources", config: func(cfg *application.ConfigServerChi) { cfg.LoaderTenantSources = []string{"acme"} }, keys: []string{"loader.tenant_sources"}},
+		{name: "merge policy", config: func(cfg *application.ConfigServerChi) { cfg.LoaderMergePolicy = "newest" }, keys: []string{"loader.merge_policy"}},
+		{name: "synonyms", config: func(cfg *application.ConfigServerChi) { cfg.NormalizeSynonyms = []string{"petrol"} }, keys: []string{"normalize.synonyms"}},
+		{name: "fuel types", config: func(cfg *application.ConfigServerChi) { cfg.VocabularyFuelTypes = nil }, keys: []string{"vocabulary.fuel_types"}},
+		{name: "transmissions", config: func(cfg *application.ConfigServerChi) { cfg.VocabularyTransmissions = nil }, keys: []string{"vocabulary.transmissions"}},
+		{name: "registration formats", config: func(cfg *application.ConfigServerChi) { cfg.RegistrationFormats = []string{"nl=[A-Z"} }, keys: []string{"registration.formats"}},
+		{name: "registration countries", config: func(cfg *application.ConfigServerChi) { cfg.RegistrationCountries = []string{"nl"} }, keys: []string{"registration.countries"}},
+		{
+			name:   "every error joined",
+			config: func(cfg *application.ConfigServerChi) { cfg.ServerAddress, cfg.LogLevel = "8080", "trace" },
+			keys:   []string{"server.address", "log.level"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := config.Default()
+			c.config(cfg)
+
+			err := config.Validate(cfg)
+			if len(c.keys) == 0 {
+				if err != nil {
+					t.Errorf("error = %v, want none", err)
+				}
+				return
+			}
+			if !errors.Is(err, config.ErrorInvalidSetting) {
+				t.Fatalf("error = %v, want %v", err, config.ErrorInvalidSetting)
+			}
+			for _, key := range c.keys {
+				if !strings.Contains(err.Error(), key) {
+					t.Errorf("error = %v, want it to name %s", err, key)
+				}
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	cases := []struct {
+		name   string
+		config func(cfg *application.ConfigServerChi)
+	}{
+		{name: "defaults", config: func(cfg *application.ConfigServerChi) {}},
+		{
+			name: "every kind of setting changed",
+			config: func(cfg *application.ConfigServerChi) {
+				cfg.ServerAddress = "127.0.0.1:9000"
+				cfg.GRPCAddress = ":9090"
+				cfg.ServerReadTimeout = 1500 * time.Millisecond
+				cfg.ServerWriteTimeout = 0
+				cfg.ServerMaxBodyBytes = 1024
+				cfg.LogFormat = "text"
+				cfg.AuthJWTIssuer = "https://issuer"
+				cfg.RateLimitWrite.PerMinute, cfg.RateLimitWrite.DailyQuota = 30, 1000
+				cfg.TracingExporter, cfg.TracingFilePath = "file", "spans.jsonl"
+				cfg.LoaderSources = []string{"docs/db/*.json", "fleet"}
+				cfg.LoaderTenantSources = []string{"acme=docs/db/acme.json"}
+				cfg.LoaderMergePolicy = "renumber"
+				cfg.RegistrationCountries = []string{"nl", "es"}
+				cfg.RegistrationFormats = []string{"nl=[A-Z]{2}[0-9]{2}[A-Z]{2}"}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := config.Default()
+			c.config(cfg)
+
+			var b bytes.Buffer
+			if err := config.Print(&b, cfg); err != nil {
+				t.Fatal(err)
+			}
+			// - the printed configuration is a config file loading the same configuration
+			loaded, _, err := config.Load([]string{"--config", writeConfig(t, b.String())}, env(nil))
+			if err != nil {
+				t.Fatalf("error = %v, config file:\n%s", err, b.String())
+			}
+			if !reflect.DeepEqual(loaded, cfg) {
+				t.Errorf("loaded config = %+v, want %+v\nconfig file:\n%s", loaded, cfg, b.String())
+			}
+		})
+	}
+}
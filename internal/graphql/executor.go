@@ -0,0 +1,326 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Types of the operations
+const (
+	OperationQuery    = ast.OperationTypeQuery
+	OperationMutation = ast.OperationTypeMutation
+)
+
+// ListComplexity is the estimated number of elements of a list, the complexity of the fields selected
+// under a list is multiplied by it
+const ListComplexity = 10
+
+// Default limits of the introspection fields, the standard introspection query of the GraphQL tools is within them
+const (
+	DefaultMaxIntrospectionDepth      = 15
+	DefaultMaxIntrospectionComplexity = 100000
+)
+
+// Errors of the requests
+var (
+	ErrorQueryTooDeep      = errors.New("Query is too deep")
+	ErrorQueryTooComplex   = errors.New("Query is too complex")
+	ErrorOperationNotFound = errors.New("Operation not found")
+)
+
+// Request is a struct that represents a GraphQL request
+type Request struct {
+	// Query is the document with the operations
+	Query string
+	// OperationName is the operation of the document to execute, it may be empty if there is only one
+	OperationName string
+	// Variables are the values of the variables of the operation
+	Variables map[string]any
+}
+
+// Operation is a struct that represents an operation parsed, validated and measured, ready to execute
+type Operation struct {
+	// Type is the type of the operation, OperationQuery or OperationMutation
+	Type string
+	// Fields are the root fields selected by the operation
+	Fields []string
+	// Depth is the deepest level of nested fields, the root fields are at level 1
+	Depth int
+	// Complexity is the estimated number of fields resolved
+	Complexity int
+	// IntrospectionDepth is the deepest level of the introspection fields, measured apart from Depth
+	IntrospectionDepth int
+	// IntrospectionComplexity is the estimated number of introspection fields resolved, measured apart from Complexity
+	IntrospectionComplexity int
+
+	// request is the request of the operation
+	request Request
+	// doc is the parsed document
+	doc *ast.Document
+}
+
+// ConfigExecutor is a struct that represents the configuration for Executor
+type ConfigExecutor struct {
+	// MaxDepth is the maximum depth of an operation
+	MaxDepth int
+	// MaxComplexity is the maximum complexity of an operation
+	MaxComplexity int
+	// MaxIntrospectionDepth is the maximum depth of the introspection fields of an operation
+	MaxIntrospectionDepth int
+	// MaxIntrospectionComplexity is the maximum complexity of the introspection fields of an operation
+	MaxIntrospectionComplexity int
+}
+
+// NewExecutor is a function that returns a new instance of Executor
+func NewExecutor(schema gql.Schema, cfg ConfigExecutor) *Executor {
+	// default values
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = 8
+	}
+	if cfg.MaxComplexity <= 0 {
+		cfg.MaxComplexity = 1000
+	}
+	if cfg.MaxIntrospectionDepth <= 0 {
+		cfg.MaxIntrospectionDepth = DefaultMaxIntrospectionDepth
+	}
+	if cfg.MaxIntrospectionComplexity <= 0 {
+		cfg.MaxIntrospectionComplexity = DefaultMaxIntrospectionComplexity
+	}
+
+	return &Executor{
+		schema:                     schema,
+		maxDepth:                   cfg.MaxDepth,
+		maxComplexity:              cfg.MaxComplexity,
+		maxIntrospectionDepth:      cfg.MaxIntrospectionDepth,
+		maxIntrospectionComplexity: cfg.MaxIntrospectionComplexity,
+	}
+}
+
+// Executor is a struct that executes the requests against a schema within the limits of depth and complexity
+type Executor struct {
+	// schema is the schema of the operations
+	schema gql.Schema
+	// maxDepth is the maximum depth of an operation
+	maxDepth int
+	// maxComplexity is the maximum complexity of an operation
+	maxComplexity int
+	// maxIntrospectionDepth is the maximum depth of the introspection fields of an operation
+	maxIntrospectionDepth int
+	// maxIntrospectionComplexity is the maximum complexity of the introspection fields of an operation
+	maxIntrospectionComplexity int
+}
+
+// Prepare is a method that parses, validates and measures the operation of a request,
+// the errors are the ones a response reports when the operation can not be executed
+func (e *Executor) Prepare(req Request) (op *Operation, errs []gqlerrors.FormattedError) {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		errs = gqlerrors.FormatErrors(err)
+		return
+	}
+	if vr := gql.ValidateDocument(&e.schema, doc, nil); !vr.IsValid {
+		errs = vr.Errors
+		return
+	}
+
+	def, err := operation(doc, req.OperationName)
+	if err != nil {
+		errs = gqlerrors.FormatErrors(err)
+		return
+	}
+	op = &Operation{Type: def.Operation, request: req, doc: doc}
+	root := e.schema.QueryType()
+	if op.Type == OperationMutation {
+		root = e.schema.MutationType()
+	}
+	m := &measure{schema: &e.schema, fragments: fragments(doc)}
+	op.Depth, op.Complexity = m.selections(def.SelectionSet, root, 1, func(name string) {
+		op.Fields = append(op.Fields, name)
+	})
+	op.IntrospectionDepth, op.IntrospectionComplexity = m.introspectionDepth, m.introspectionComplexity
+
+	// - limits
+	if op.Depth > e.maxDepth {
+		errs = append(errs, limitError(CodeTooDeep, fmt.Errorf("%w: depth %d, maximum %d", ErrorQueryTooDeep, op.Depth, e.maxDepth)))
+	}
+	if op.Complexity > e.maxComplexity {
+		errs = append(errs, limitError(CodeTooComplex, fmt.Errorf("%w: complexity %d, maximum %d", ErrorQueryTooComplex, op.Complexity, e.maxComplexity)))
+	}
+	if op.IntrospectionDepth > e.maxIntrospectionDepth {
+		errs = append(errs, limitError(CodeTooDeep, fmt.Errorf("%w: introspection depth %d, maximum %d", ErrorQueryTooDeep, op.IntrospectionDepth, e.maxIntrospectionDepth)))
+	}
+	if op.IntrospectionComplexity > e.maxIntrospectionComplexity {
+		errs = append(errs, limitError(CodeTooComplex, fmt.Errorf("%w: introspection complexity %d, maximum %d", ErrorQueryTooComplex, op.IntrospectionComplexity, e.maxIntrospectionComplexity)))
+	}
+	if errs != nil {
+		op = nil
+	}
+	return
+}
+
+// Execute is a method that executes an operation prepared by Prepare
+func (e *Executor) Execute(ctx context.Context, op *Operation) *gql.Result {
+	return gql.Execute(gql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           op.doc,
+		OperationName: op.request.OperationName,
+		Args:          op.request.Variables,
+		Context:       ctx,
+	})
+}
+
+// limitError is a function that returns the error of a response for an operation over a limit
+func limitError(code string, err error) gqlerrors.FormattedError {
+	fe := gqlerrors.NewFormattedError(err.Error())
+	fe.Extensions = map[string]any{"code": code}
+	return fe
+}
+
+// operation is a function that returns the operation of a document by its name,
+// the only operation of the document if name is empty
+func operation(doc *ast.Document, name string) (def *ast.OperationDefinition, err error) {
+	for _, d := range doc.Definitions {
+		od, ok := d.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if def != nil {
+				err = fmt.Errorf("%w: the document has several operations, operationName is required", ErrorOperationNotFound)
+				return
+			}
+			def = od
+			continue
+		}
+		if od.Name != nil && od.Name.Value == name {
+			def = od
+			return
+		}
+	}
+	if def == nil {
+		err = fmt.Errorf("%w: %q", ErrorOperationNotFound, name)
+	}
+	return
+}
+
+// fragments is a function that returns the fragments of a document by name
+func fragments(doc *ast.Document) map[string]*ast.FragmentDefinition {
+	f := make(map[string]*ast.FragmentDefinition)
+	for _, d := range doc.Definitions {
+		if fd, ok := d.(*ast.FragmentDefinition); ok {
+			f[fd.Name.Value] = fd
+		}
+	}
+	return f
+}
+
+// measure is a struct that measures the depth and complexity of the selections of a validated document
+type measure struct {
+	// schema is the schema of the document
+	schema *gql.Schema
+	// fragments are the fragments of the document by name
+	fragments map[string]*ast.FragmentDefinition
+	// introspection reports whether the selections measured are under an introspection field
+	introspection bool
+	// introspectionDepth is the deepest level of the introspection fields measured
+	introspectionDepth int
+	// introspectionComplexity is the complexity of the introspection fields measured
+	introspectionComplexity int
+}
+
+// selections is a method that returns the depth and complexity of a selection set on parent at level,
+// root is called with the name of each field of the set. The introspection fields are measured apart, in introspectionDepth
+// and introspectionComplexity, so the schema can be read by the usual introspection query without raising the limits of the data
+func (m *measure) selections(set *ast.SelectionSet, parent gql.Type, level int, root func(name string)) (depth, complexity int) {
+	if set == nil {
+		return
+	}
+	for _, s := range set.Selections {
+		var d, c int
+		switch s := s.(type) {
+		case *ast.Field:
+			name := s.Name.Value
+			if root != nil {
+				root(name)
+			}
+			if strings.HasPrefix(name, "__") && !m.introspection {
+				m.introspection = true
+				d, c = m.field(s, parent, level)
+				m.introspection = false
+				m.introspectionDepth = max(m.introspectionDepth, d)
+				m.introspectionComplexity += c
+				continue
+			}
+			d, c = m.field(s, parent, level)
+		case *ast.InlineFragment:
+			t := parent
+			if s.TypeCondition != nil {
+				t = m.schema.Type(s.TypeCondition.Name.Value)
+			}
+			d, c = m.selections(s.SelectionSet, t, level, root)
+		case *ast.FragmentSpread:
+			if f, ok := m.fragments[s.Name.Value]; ok {
+				d, c = m.selections(f.SelectionSet, m.schema.Type(f.TypeCondition.Name.Value), level, root)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return
+}
+
+// field is a method that returns the depth and complexity of a field of parent at level
+func (m *measure) field(f *ast.Field, parent gql.Type, level int) (depth, complexity int) {
+	depth, complexity = level, 1
+
+	def := fieldDef(parent, f.Name.Value)
+	if def == nil || f.SelectionSet == nil {
+		return
+	}
+
+	// - the fields under a list are resolved once by element
+	factor := 1
+	t := def.Type
+	for {
+		if nn, ok := t.(*gql.NonNull); ok {
+			t = nn.OfType
+		}
+		l, ok := t.(*gql.List)
+		if !ok {
+			break
+		}
+		factor *= ListComplexity
+		t = l.OfType
+	}
+
+	d, c := m.selections(f.SelectionSet, t, level+1, nil)
+	depth = max(depth, d)
+	complexity += factor * c
+	return
+}
+
+// fieldDef is a function that returns the definition of a field of parent, the introspection fields included, nil if it has none
+func fieldDef(parent gql.Type, name string) *gql.FieldDefinition {
+	switch name {
+	case gql.SchemaMetaFieldDef.Name:
+		return gql.SchemaMetaFieldDef
+	case gql.TypeMetaFieldDef.Name:
+		return gql.TypeMetaFieldDef
+	case gql.TypeNameMetaFieldDef.Name:
+		return gql.TypeNameMetaFieldDef
+	}
+	switch t := parent.(type) {
+	case *gql.Object:
+		return t.Fields()[name]
+	case *gql.Interface:
+		return t.Fields()[name]
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package graphql_test
+
+import (
+	"app/internal/graphql"
+	"testing"
+
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestExecutor_Prepare_Limits(t *testing.T) {
+	schema, err := graphql.NewSchema(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := graphql.NewExecutor(schema, graphql.ConfigExecutor{
+		MaxDepth:                   2,
+		MaxComplexity:              20,
+		MaxIntrospectionDepth:      3,
+		MaxIntrospectionComplexity: 40,
+	})
+
+	cases := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{
+			name:  "within the limits",
+			query: `{ vehicle(id: 1) { id brand __typename } }`,
+		},
+		{
+			name:  "too deep",
+			query: `{ vehicle(id: 1) { dimensions { height } } }`,
+			code:  graphql.CodeTooDeep,
+		},
+		{
+			name:  "too deep through a fragment",
+			query: `{ vehicle(id: 1) { ...dimensions } } fragment dimensions on Vehicle { dimensions { height } }`,
+			code:  graphql.CodeTooDeep,
+		},
+		{
+			name:  "too complex, the fields under a list counted once by element",
+			query: `{ vehicles { id brand } }`,
+			code:  graphql.CodeTooComplex,
+		},
+		{
+			name:  "introspection within its limits",
+			query: `{ __schema { queryType { name } } }`,
+		},
+		{
+			name:  "introspection too deep",
+			query: `{ __type(name: "Vehicle") { fields { type { name } } } }`,
+			code:  graphql.CodeTooDeep,
+		},
+		{
+			name:  "introspection too complex",
+			query: `{ a: __schema { types { name kind description } } b: __schema { types { name kind description } } }`,
+			code:  graphql.CodeTooComplex,
+		},
+		{
+			name:  "introspection too deep next to data within the limits",
+			query: `{ vehicle(id: 1) { __typename } __type(name: "Vehicle") { fields { type { ofType { name } } } } }`,
+			code:  graphql.CodeTooDeep,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			op, errs := ex.Prepare(graphql.Request{Query: c.query})
+			if c.code == "" {
+				if errs != nil || op == nil {
+					t.Fatalf("errors = %v, want none", errs)
+				}
+				return
+			}
+			if op != nil || len(errs) != 1 {
+				t.Fatalf("errors = %v, want one %s", errs, c.code)
+			}
+			if code := errs[0].Extensions["code"]; code != c.code {
+				t.Errorf("code = %v, want %s", code, c.code)
+			}
+		})
+	}
+}
+
+func TestExecutor_Prepare_IntrospectionQuery(t *testing.T) {
+	schema, err := graphql.NewSchema(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ex := graphql.NewExecutor(schema, graphql.ConfigExecutor{})
+
+	// - the query of the GraphQL tools reads the schema within the default limits
+	op, errs := ex.Prepare(graphql.Request{Query: testutil.IntrospectionQuery})
+	if errs != nil {
+		t.Fatalf("errors = %v, want none", errs)
+	}
+	if op.IntrospectionDepth == 0 || op.IntrospectionComplexity == 0 {
+		t.Errorf("introspection depth %d and complexity %d, want them measured", op.IntrospectionDepth, op.IntrospectionComplexity)
+	}
+}
@@ -0,0 +1,523 @@
+package graphql
+
+import (
+	"app/internal"
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// Codes of the errors, in the extensions of each error of a response
+const (
+	CodeBadUserInput = "BAD_USER_INPUT"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeForbidden    = "FORBIDDEN"
+	CodeTimeout      = "TIMEOUT"
+	CodeCanceled     = "CANCELED"
+	CodeInternal     = "INTERNAL"
+	CodeTooDeep      = "QUERY_TOO_DEEP"
+	CodeTooComplex   = "QUERY_TOO_COMPLEX"
+)
+
+// Error is a struct that represents an error of a resolver with the code reported in its extensions
+type Error struct {
+	// Code is the code of the error
+	Code string
+	// Err is the error
+	Err error
+}
+
+// Error is a method that returns the message of the error
+func (e *Error) Error() string { return e.Err.Error() }
+
+// Unwrap is a method that returns the error
+func (e *Error) Unwrap() error { return e.Err }
+
+// Extensions is a method that returns the extensions of the error in a response
+func (e *Error) Extensions() map[string]any { return map[string]any{"code": e.Code} }
+
+// resolverError is a function that returns the error of a resolver from an error of the service,
+// the unexpected errors are logged and reported as ErrorInternalServer
+func resolverError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, internal.ErrorVehicleNotFound):
+		return &Error{Code: CodeNotFound, Err: err}
+	case errors.Is(err, internal.ErrorVehicleAlreadyExists):
+		return &Error{Code: CodeConflict, Err: err}
+	case errors.Is(err, internal.ErrorInvalidMaxSpeedRange), errors.Is(err, internal.ErrorInvalidFuelTypeUpdate),
+		errors.Is(err, internal.ErrorInvalidFuelType), errors.Is(err, internal.ErrorInvalidTransmissionType),
+		errors.Is(err, internal.ErrorInvalidTransmissionValue), errors.Is(err, internal.ErrorVehicleNotInCatalog),
+		errors.Is(err, internal.ErrorInvalidRegistration), errors.Is(err, internal.ErrorInvalidVIN),
+		errors.Is(err, internal.ErrorVINMismatch):
+		return &Error{Code: CodeBadUserInput, Err: err}
+	case errors.Is(err, context.DeadlineExceeded):
+		return &Error{Code: CodeTimeout, Err: internal.ErrorRequestTimeout}
+	case errors.Is(err, context.Canceled):
+		return &Error{Code: CodeCanceled, Err: internal.ErrorRequestCanceled}
+	}
+	slog.ErrorContext(ctx, "graphql resolver failed", slog.String("error", err.Error()))
+	return &Error{Code: CodeInternal, Err: internal.ErrorInternalServer}
+}
+
+// badInput is a function that returns the error of a resolver for an invalid argument
+func badInput(err error) error {
+	return &Error{Code: CodeBadUserInput, Err: err}
+}
+
+// NewSchema is a function that returns the schema of the vehicles resolved through sv
+func NewSchema(sv internal.VehicleService) (gql.Schema, error) {
+	r := &resolver{sv: sv}
+
+	dimensions := gql.NewObject(gql.ObjectConfig{
+		Name:        "Dimensions",
+		Description: "Dimensions of a vehicle",
+		Fields: gql.Fields{
+			"height": vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Height }),
+			"length": vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Length }),
+			"width":  vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Width }),
+		},
+	})
+	vehicle := gql.NewObject(gql.ObjectConfig{
+		Name:        "Vehicle",
+		Description: "Vehicle of the tenant of the request",
+		Fields: gql.Fields{
+			"id":           vehicleField(gql.Int, func(v internal.Vehicle) any { return v.Id }),
+			"brand":        vehicleField(gql.String, func(v internal.Vehicle) any { return v.Brand }),
+			"model":        vehicleField(gql.String, func(v internal.Vehicle) any { return v.Model }),
+			"registration": vehicleField(gql.String, func(v internal.Vehicle) any { return v.Registration }),
+			"vin":          vehicleField(gql.String, func(v internal.Vehicle) any { return v.VIN }),
+			"color":        vehicleField(gql.String, func(v internal.Vehicle) any { return v.Color }),
+			"year":         vehicleField(gql.Int, func(v internal.Vehicle) any { return v.FabricationYear }),
+			"passengers":   vehicleField(gql.Int, func(v internal.Vehicle) any { return v.Capacity }),
+			"maxSpeed":     vehicleField(gql.Float, func(v internal.Vehicle) any { return v.MaxSpeed }),
+			"fuelType":     vehicleField(gql.String, func(v internal.Vehicle) any { return v.FuelType }),
+			"transmission": vehicleField(gql.String, func(v internal.Vehicle) any { return v.Transmission }),
+			"weight":       vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Weight }),
+			"dimensions":   vehicleField(dimensions, func(v internal.Vehicle) any { return v }),
+		},
+	})
+
+	rangeInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name:        "RangeInput",
+		Description: "Closed range of numbers",
+		Fields: gql.InputObjectConfigFieldMap{
+			"min": {Type: gql.NewNonNull(gql.Float)},
+			"max": {Type: gql.NewNonNull(gql.Float)},
+		},
+	})
+	yearRangeInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name:        "YearRangeInput",
+		Description: "Closed range of fabrication years",
+		Fields: gql.InputObjectConfigFieldMap{
+			"start": {Type: gql.NewNonNull(gql.Int)},
+			"end":   {Type: gql.NewNonNull(gql.Int)},
+		},
+	})
+	filter := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "VehicleFilter",
+		Description: "Criteria of the finders of the REST API, the vehicles match every criterion given. " +
+			"color goes with year, brand with years and height with width",
+		Fields: gql.InputObjectConfigFieldMap{
+			"color":        {Type: gql.String},
+			"year":         {Type: gql.Int},
+			"brand":        {Type: gql.String},
+			"years":        {Type: yearRangeInput},
+			"fuelType":     {Type: gql.String},
+			"transmission": {Type: gql.String},
+			"height":       {Type: rangeInput},
+			"width":        {Type: rangeInput},
+			"weight":       {Type: rangeInput},
+		},
+	})
+	dimensionsInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "DimensionsInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"height": {Type: gql.Float},
+			"length": {Type: gql.Float},
+			"width":  {Type: gql.Float},
+		},
+	})
+	vehicleInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name:        "VehicleInput",
+		Description: "Vehicle to create, the fields required by the REST API are non null",
+		Fields: gql.InputObjectConfigFieldMap{
+			"id":           {Type: gql.Int},
+			"brand":        {Type: gql.NewNonNull(gql.String)},
+			"model":        {Type: gql.NewNonNull(gql.String)},
+			"registration": {Type: gql.NewNonNull(gql.String)},
+			"vin":          {Type: gql.String},
+			"color":        {Type: gql.NewNonNull(gql.String)},
+			"year":         {Type: gql.NewNonNull(gql.Int)},
+			"passengers":   {Type: gql.Int},
+			"maxSpeed":     {Type: gql.Float},
+			"fuelType":     {Type: gql.String},
+			"transmission": {Type: gql.String},
+			"weight":       {Type: gql.Float},
+			"dimensions":   {Type: dimensionsInput},
+		},
+	})
+
+	id := &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int), Description: "ID of the vehicle"}
+	brand := &gql.ArgumentConfig{Type: gql.NewNonNull(gql.String), Description: "Brand of the vehicles"}
+	vehicles := gql.NewNonNull(gql.NewList(gql.NewNonNull(vehicle)))
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"vehicle": {
+				Type:        gql.NewNonNull(vehicle),
+				Description: "Vehicle by its ID",
+				Args:        gql.FieldConfigArgument{"id": id},
+				Resolve:     r.vehicle,
+			},
+			"vehicles": {
+				Type:        vehicles,
+				Description: "Vehicles matching the filter by ID, every vehicle without filter",
+				Args:        gql.FieldConfigArgument{"filter": {Type: filter}},
+				Resolve:     r.vehicles,
+			},
+			"averageSpeedByBrand": {
+				Type:        gql.NewNonNull(gql.Float),
+				Description: "Average maximum speed of the vehicles of a brand",
+				Args:        gql.FieldConfigArgument{"brand": brand},
+				Resolve:     r.averageSpeedByBrand,
+			},
+			"averageCapacityByBrand": {
+				Type:        gql.NewNonNull(gql.Float),
+				Description: "Average capacity in passengers of the vehicles of a brand",
+				Args:        gql.FieldConfigArgument{"brand": brand},
+				Resolve:     r.averageCapacityByBrand,
+			},
+		},
+	})
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: gql.Fields{
+			"createVehicle": {
+				Type:        gql.NewNonNull(vehicle),
+				Description: "Creates a vehicle",
+				Args:        gql.FieldConfigArgument{"input": {Type: gql.NewNonNull(vehicleInput)}},
+				Resolve:     r.createVehicle,
+			},
+			"createVehicles": {
+				Type:        vehicles,
+				Description: "Creates several vehicles, none is created if any fails",
+				Args:        gql.FieldConfigArgument{"input": {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(vehicleInput)))}},
+				Resolve:     r.createVehicles,
+			},
+			"updateMaxSpeed": {
+				Type:        gql.NewNonNull(vehicle),
+				Description: "Updates the maximum speed of a vehicle",
+				Args:        gql.FieldConfigArgument{"id": id, "maxSpeed": {Type: gql.NewNonNull(gql.Float)}},
+				Resolve:     r.updateMaxSpeed,
+			},
+			"updateFuelType": {
+				Type:        gql.NewNonNull(vehicle),
+				Description: "Updates the fuel type of a vehicle",
+				Args:        gql.FieldConfigArgument{"id": id, "fuelType": {Type: gql.NewNonNull(gql.String)}},
+				Resolve:     r.updateFuelType,
+			},
+			"deleteVehicle": {
+				Type:        gql.NewNonNull(gql.Int),
+				Description: "Deletes a vehicle, returns its ID",
+				Args:        gql.FieldConfigArgument{"id": id},
+				Resolve:     r.deleteVehicle,
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// vehicleField is a function that returns a non null field of a vehicle
+func vehicleField(t gql.Output, get func(v internal.Vehicle) any) *gql.Field {
+	return &gql.Field{
+		Type: gql.NewNonNull(t),
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			v, _ := p.Source.(internal.Vehicle)
+			return get(v), nil
+		},
+	}
+}
+
+// resolver is a struct with the resolvers of the root fields
+type resolver struct {
+	// sv is the service that will be used by the resolvers
+	sv internal.VehicleService
+}
+
+// vehicle is a method that resolves a vehicle by its ID
+func (r *resolver) vehicle(p gql.ResolveParams) (any, error) {
+	id, err := argID(p)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := r.sv.FindByIDContext(p.Context, id)
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return v, nil
+}
+
+// vehicles is a method that resolves the vehicles matching a filter, each group of criteria is
+// resolved by its finder and the vehicles found by every finder are kept
+func (r *resolver) vehicles(p gql.ResolveParams) (any, error) {
+	ctx := p.Context
+	f, _ := p.Args["filter"].(map[string]any)
+
+	var finders []func() (map[int]internal.Vehicle, error)
+	color, hasColor := f["color"].(string)
+	year, hasYear := f["year"].(int)
+	if hasColor || hasYear {
+		if !hasColor || !hasYear {
+			return nil, badInput(internal.ErrorInvalidColorAndYear)
+		}
+		if year < 0 {
+			return nil, badInput(internal.ErrorInvalidYear)
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return r.sv.FindByColorAndYearContext(ctx, color, year)
+		})
+	}
+	brand, hasBrand := f["brand"].(string)
+	years, hasYears := f["years"].(map[string]any)
+	if hasBrand || hasYears {
+		if !hasBrand || !hasYears {
+			return nil, badInput(internal.ErrorInvalidBrandAndRangeYear)
+		}
+		start, end := years["start"].(int), years["end"].(int)
+		if start < 0 || end < 0 {
+			return nil, badInput(internal.ErrorInvalidYear)
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return r.sv.FindByBrandAndRangeYearContext(ctx, brand, start, end)
+		})
+	}
+	if fuelType, ok := f["fuelType"].(string); ok {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return r.sv.FindByFuelTypeContext(ctx, fuelType)
+		})
+	}
+	if transmission, ok := f["transmission"].(string); ok {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return r.sv.FindByTransmissionTypeContext(ctx, transmission)
+		})
+	}
+	height, hasHeight := f["height"].(map[string]any)
+	width, hasWidth := f["width"].(map[string]any)
+	if hasHeight || hasWidth {
+		if !hasHeight || !hasWidth {
+			return nil, badInput(internal.ErrorInvalidHeightAndWidth)
+		}
+		minHeight, maxHeight := bounds(height)
+		minWidth, maxWidth := bounds(width)
+		if minHeight > maxHeight || minWidth > maxWidth {
+			return nil, badInput(internal.ErrorInvalidDimension)
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return r.sv.FindByDimensionsContext(ctx, minHeight, maxHeight, minWidth, maxWidth)
+		})
+	}
+	if weight, ok := f["weight"].(map[string]any); ok {
+		minWeight, maxWeight := bounds(weight)
+		if minWeight > maxWeight {
+			return nil, badInput(internal.ErrorInvalidWeightRange)
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return r.sv.FindByWeightRangeContext(ctx, minWeight, maxWeight)
+		})
+	}
+	if len(finders) == 0 {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return r.sv.FindAllContext(ctx)
+		})
+	}
+
+	// - intersection of the vehicles found, no vehicle found is an empty list rather than an error
+	var found map[int]internal.Vehicle
+	for _, find := range finders {
+		v, err := find()
+		if errors.Is(err, internal.ErrorVehicleNotFound) {
+			return []internal.Vehicle{}, nil
+		}
+		if err != nil {
+			return nil, resolverError(ctx, err)
+		}
+		if found == nil {
+			found = v
+			continue
+		}
+		for id := range found {
+			if _, ok := v[id]; !ok {
+				delete(found, id)
+			}
+		}
+	}
+	return sorted(found), nil
+}
+
+// averageSpeedByBrand is a method that resolves the average maximum speed of the vehicles of a brand
+func (r *resolver) averageSpeedByBrand(p gql.ResolveParams) (any, error) {
+	brand, _ := p.Args["brand"].(string)
+	if brand == "" {
+		return nil, badInput(internal.ErrorInvalidBrand)
+	}
+
+	avg, err := r.sv.FindAverageSpeedByBrandContext(p.Context, brand)
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return avg, nil
+}
+
+// averageCapacityByBrand is a method that resolves the average capacity in passengers of the vehicles of a brand
+func (r *resolver) averageCapacityByBrand(p gql.ResolveParams) (any, error) {
+	brand, _ := p.Args["brand"].(string)
+	if brand == "" {
+		return nil, badInput(internal.ErrorInvalidBrand)
+	}
+
+	avg, err := r.sv.FindAverageCapacityByBrandContext(p.Context, brand)
+	if err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return avg, nil
+}
+
+// createVehicle is a method that creates a vehicle
+func (r *resolver) createVehicle(p gql.ResolveParams) (any, error) {
+	input, _ := p.Args["input"].(map[string]any)
+	v, err := vehicleFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.sv.CreateContext(p.Context, &v); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return v, nil
+}
+
+// createVehicles is a method that creates several vehicles, none is created if any fails
+func (r *resolver) createVehicles(p gql.ResolveParams) (any, error) {
+	inputs, _ := p.Args["input"].([]any)
+	if len(inputs) == 0 {
+		return nil, badInput(internal.ErrorInvalidVehicles)
+	}
+	vehicles := make([]internal.Vehicle, 0, len(inputs))
+	for _, in := range inputs {
+		input, _ := in.(map[string]any)
+		v, err := vehicleFromInput(input)
+		if err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, v)
+	}
+
+	if err := r.sv.CreateBatchContext(p.Context, vehicles); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return vehicles, nil
+}
+
+// updateMaxSpeed is a method that updates the maximum speed of a vehicle and resolves it updated
+func (r *resolver) updateMaxSpeed(p gql.ResolveParams) (any, error) {
+	id, err := argID(p)
+	if err != nil {
+		return nil, err
+	}
+	maxSpeed, _ := p.Args["maxSpeed"].(float64)
+
+	if err := r.sv.UpdateMaxSpeedContext(p.Context, id, maxSpeed); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return r.vehicle(p)
+}
+
+// updateFuelType is a method that updates the fuel type of a vehicle and resolves it updated
+func (r *resolver) updateFuelType(p gql.ResolveParams) (any, error) {
+	id, err := argID(p)
+	if err != nil {
+		return nil, err
+	}
+	fuelType, _ := p.Args["fuelType"].(string)
+	if fuelType == "" {
+		return nil, badInput(internal.ErrorInvalidFuelType)
+	}
+
+	if err := r.sv.UpdateFuelTypeContext(p.Context, id, fuelType); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return r.vehicle(p)
+}
+
+// deleteVehicle is a method that deletes a vehicle and resolves its ID
+func (r *resolver) deleteVehicle(p gql.ResolveParams) (any, error) {
+	id, err := argID(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.sv.DeleteContext(p.Context, id); err != nil {
+		return nil, resolverError(p.Context, err)
+	}
+	return id, nil
+}
+
+// argID is a function that returns the id argument of a field
+func argID(p gql.ResolveParams) (id int, err error) {
+	id, _ = p.Args["id"].(int)
+	if id < 0 {
+		err = badInput(internal.ErrorParseID)
+	}
+	return
+}
+
+// bounds is a function that returns the bounds of a RangeInput
+func bounds(r map[string]any) (min, max float64) {
+	min, _ = r["min"].(float64)
+	max, _ = r["max"].(float64)
+	return
+}
+
+// sorted is a function that returns the vehicles of a map in ID order
+func sorted(v map[int]internal.Vehicle) []internal.Vehicle {
+	list := make([]internal.Vehicle, 0, len(v))
+	for _, vehicle := range v {
+		list = append(list, vehicle)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+	return list
+}
+
+// vehicleFromInput is a function that returns the vehicle of a VehicleInput
+func vehicleFromInput(in map[string]any) (v internal.Vehicle, err error) {
+	v.Id, _ = in["id"].(int)
+	if v.Id < 0 {
+		err = badInput(internal.ErrorParseID)
+		return
+	}
+	v.Brand, _ = in["brand"].(string)
+	v.Model, _ = in["model"].(string)
+	v.Registration, _ = in["registration"].(string)
+	v.VIN, _ = in["vin"].(string)
+	v.Color, _ = in["color"].(string)
+	v.FabricationYear, _ = in["year"].(int)
+	v.Capacity, _ = in["passengers"].(int)
+	v.MaxSpeed, _ = in["maxSpeed"].(float64)
+	fuelType, _ := in["fuelType"].(string)
+	transmission, _ := in["transmission"].(string)
+	v.FuelType, v.Transmission = internal.FuelType(fuelType), internal.Transmission(transmission)
+	v.Weight, _ = in["weight"].(float64)
+	if d, ok := in["dimensions"].(map[string]any); ok {
+		v.Height, _ = d["height"].(float64)
+		v.Length, _ = d["length"].(float64)
+		v.Width, _ = d["width"].(float64)
+	}
+	return
+}
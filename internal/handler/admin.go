@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"app/internal"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// ReloadStatusJSON is a struct that represents the status of the reloads in JSON format
+type ReloadStatusJSON struct {
+	Source         string           `json:"source"`
+	DatasetSize    int              `json:"dataset_size"`
+	Reloads        int              `json:"reloads_total"`
+	Failures       int              `json:"failures_total"`
+	LastAttempt    string           `json:"last_attempt,omitempty"`
+	LastSuccess    string           `json:"last_success,omitempty"`
+	LastDurationMs float64          `json:"last_duration_ms"`
+	LastError      string           `json:"last_error,omitempty"`
+	Merge          *MergeReportJSON `json:"merge,omitempty"`
+}
+
+// JSON is a method that returns a ReloadStatusJSON from a ReloadStatus
+func (s *ReloadStatusJSON) JSON(status internal.ReloadStatus) ReloadStatusJSON {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	s.Source = status.Source
+	s.DatasetSize = status.DatasetSize
+	s.Reloads = status.Reloads
+	s.Failures = status.Failures
+	s.LastAttempt = formatTime(status.LastAttempt)
+	s.LastSuccess = formatTime(status.LastSuccess)
+	s.LastDurationMs = float64(status.LastDuration) / float64(time.Millisecond)
+	s.LastError = status.LastError
+	if status.Merge != nil {
+		merge := (&MergeReportJSON{}).JSON(*status.Merge)
+		s.Merge = &merge
+	}
+
+	return *s
+}
+
+// MergeReportJSON is a struct that represents how the sources of a reload were merged in JSON format
+type MergeReportJSON struct {
+	Files     []MergeFileJSON     `json:"files"`
+	Conflicts []MergeConflictJSON `json:"conflicts"`
+}
+
+// MergeFileJSON is a struct that represents a merged file and the number of vehicles kept from it in JSON format
+type MergeFileJSON struct {
+	File     string `json:"file"`
+	Vehicles int    `json:"vehicles"`
+}
+
+// MergeConflictJSON is a struct that represents a collision between merged files in JSON format
+type MergeConflictJSON struct {
+	ID             int    `json:"id"`
+	Registration   string `json:"registration"`
+	Source         string `json:"source"`
+	ConflictingIDs []int  `json:"conflicting_ids"`
+	Resolution     string `json:"resolution"`
+}
+
+// JSON is a method that returns a MergeReportJSON from a VehicleMergeReport
+func (m *MergeReportJSON) JSON(report internal.VehicleMergeReport) MergeReportJSON {
+	m.Files = make([]MergeFileJSON, 0, len(report.Files))
+	for _, file := range report.Files {
+		m.Files = append(m.Files, MergeFileJSON{File: file, Vehicles: report.Vehicles[file]})
+	}
+	m.Conflicts = make([]MergeConflictJSON, 0, len(report.Conflicts))
+	for _, c := range report.Conflicts {
+		m.Conflicts = append(m.Conflicts, MergeConflictJSON{
+			ID:             c.Id,
+			Registration:   c.Registration,
+			Source:         c.Source,
+			ConflictingIDs: c.ConflictingIds,
+			Resolution:     c.Resolution,
+		})
+	}
+
+	return *m
+}
+
+// NewAdminDefault is a function that returns a new instance of AdminDefault
+func NewAdminDefault(rl internal.VehicleReloader) *AdminDefault {
+	return &AdminDefault{rl: rl}
+}
+
+// AdminDefault is a struct with methods that represent handlers for administrative tasks
+type AdminDefault struct {
+	// rl is the reloader of the vehicles dataset
+	rl internal.VehicleReloader
+}
+
+// Reload is a method that returns a handler for the route POST /admin/reload
+func (h *AdminDefault) Reload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// process
+		// - reload dataset
+		status, err := h.rl.Reload()
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrorReloadInProgress):
+				response.Error(w, http.StatusConflict, err.Error())
+			default:
+				response.JSON(w, http.StatusUnprocessableEntity, map[string]any{
+					"message": "Reload failed, previous dataset kept",
+					"data":    (&ReloadStatusJSON{}).JSON(status),
+				})
+			}
+
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    (&ReloadStatusJSON{}).JSON(status),
+		})
+	}
+}
+
+// GetReloadStatus is a method that returns a handler for the route GET /admin/reload
+func (h *AdminDefault) GetReloadStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    (&ReloadStatusJSON{}).JSON(h.rl.Status()),
+		})
+	}
+}
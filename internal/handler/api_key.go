@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"app/internal"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// APIKeyJSON is a struct that represents an API key in JSON format, the secret hashes are never exposed
+type APIKeyJSON struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	Scopes            []string `json:"scopes"`
+	Tenant            string   `json:"tenant,omitempty"`
+	CreatedAt         string   `json:"created_at"`
+	RotatedAt         string   `json:"rotated_at,omitempty"`
+	PreviousExpiresAt string   `json:"previous_expires_at,omitempty"`
+	ExpiresAt         string   `json:"expires_at,omitempty"`
+	RevokedAt         string   `json:"revoked_at,omitempty"`
+	Secret            string   `json:"secret,omitempty"`
+}
+
+// JSON is a method that returns an APIKeyJSON from an APIKey
+func (k *APIKeyJSON) JSON(key internal.APIKey) APIKeyJSON {
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	k.ID = key.ID
+	k.Name = key.Name
+	k.Scopes = key.Scopes
+	k.Tenant = key.Tenant
+	k.CreatedAt = formatTime(key.CreatedAt)
+	k.RotatedAt = formatTime(key.RotatedAt)
+	k.PreviousExpiresAt = formatTime(key.PreviousExpiresAt)
+	k.ExpiresAt = formatTime(key.ExpiresAt)
+	k.RevokedAt = formatTime(key.RevokedAt)
+
+	return *k
+}
+
+// NewAPIKeyDefault is a function that returns a new instance of APIKeyDefault,
+// grace is the time a rotated secret is still accepted when the request does not set it
+func NewAPIKeyDefault(st internal.APIKeyStore, grace time.Duration) *APIKeyDefault {
+	return &APIKeyDefault{st: st, grace: grace}
+}
+
+// APIKeyDefault is a struct with methods that represent handlers for the administration of API keys
+type APIKeyDefault struct {
+	// st is the store of API keys
+	st internal.APIKeyStore
+	// grace is the default time a rotated secret is still accepted
+	grace time.Duration
+}
+
+// GetAll is a method that returns a handler for the route GET /admin/keys,
+// credentials bound to a tenant only see the keys of the tenant
+func (h *APIKeyDefault) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keys, err := h.st.FindAll()
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
+
+			return
+		}
+
+		bound := BoundTenant(r.Context())
+		data := make([]APIKeyJSON, 0, len(keys))
+		for _, k := range keys {
+			if bound != "" && k.Tenant != bound {
+				continue
+			}
+			data = append(data, (&APIKeyJSON{}).JSON(k))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    data,
+		})
+	}
+}
+
+// Issue is a method that returns a handler for the route POST /admin/keys,
+// credentials bound to a tenant only issue keys bound to the tenant, the one of the request if it is empty
+func (h *APIKeyDefault) Issue() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var req struct {
+			Name      string   `json:"name"`
+			Tenant    string   `json:"tenant"`
+			Scopes    []string `json:"scopes"`
+			ExpiresIn string   `json:"expires_in"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+
+			return
+		}
+		var ttl time.Duration
+		if req.ExpiresIn != "" {
+			var err error
+			ttl, err = time.ParseDuration(req.ExpiresIn)
+			if err != nil || ttl < 0 {
+				response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error()+": expires_in must be a positive duration")
+
+				return
+			}
+		}
+		if bound := BoundTenant(r.Context()); bound != "" {
+			if req.Tenant != "" && req.Tenant != bound {
+				slog.WarnContext(r.Context(), "tenant denied", slog.String("tenant", req.Tenant), slog.String("bound_tenant", bound))
+				response.Error(w, http.StatusForbidden, internal.ErrorTenantForbidden.Error())
+
+				return
+			}
+			req.Tenant = bound
+		}
+
+		// process
+		k, secret, err := h.st.Issue(req.Name, req.Tenant, req.Scopes, ttl)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrorAPIKeyInvalidName), errors.Is(err, internal.ErrorAPIKeyInvalidScope), errors.Is(err, internal.ErrorTenantInvalid):
+				response.Error(w, http.StatusBadRequest, err.Error())
+			default:
+				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
+			}
+
+			return
+		}
+		slog.InfoContext(r.Context(), "api key issued", slog.String("key_id", k.ID), slog.Any("scopes", k.Scopes))
+
+		// response: the secret is only shown once
+		data := (&APIKeyJSON{}).JSON(k)
+		data.Secret = secret
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "API key issued, store the secret now as it cannot be retrieved again",
+			"data":    data,
+		})
+	}
+}
+
+// Rotate is a method that returns a handler for the route POST /admin/keys/{id}/rotate,
+// credentials bound to a tenant only rotate the keys of the tenant
+func (h *APIKeyDefault) Rotate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id := chi.URLParam(r, "id")
+		var req struct {
+			Grace *string `json:"grace"`
+		}
+		// - the body is optional
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+
+			return
+		}
+		grace := h.grace
+		if req.Grace != nil {
+			var err error
+			grace, err = time.ParseDuration(*req.Grace)
+			if err != nil || grace < 0 {
+				response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error()+": grace must be a positive duration")
+
+				return
+			}
+		}
+
+		// process
+		if err := h.checkTenant(r.Context(), id); err != nil {
+			writeKeyError(w, err)
+
+			return
+		}
+		k, secret, err := h.st.Rotate(id, grace)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrorAPIKeyNotFound):
+				response.Error(w, http.StatusNotFound, err.Error())
+			default:
+				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
+			}
+
+			return
+		}
+		slog.InfoContext(r.Context(), "api key rotated", slog.String("key_id", k.ID), slog.Duration("grace", grace))
+
+		// response
+		data := (&APIKeyJSON{}).JSON(k)
+		data.Secret = secret
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "API key rotated, store the secret now as it cannot be retrieved again",
+			"data":    data,
+		})
+	}
+}
+
+// Revoke is a method that returns a handler for the route DELETE /admin/keys/{id},
+// credentials bound to a tenant only revoke the keys of the tenant
+func (h *APIKeyDefault) Revoke() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		if err := h.checkTenant(r.Context(), id); err != nil {
+			writeKeyError(w, err)
+
+			return
+		}
+		if err := h.st.Revoke(id); err != nil {
+			switch {
+			case errors.Is(err, internal.ErrorAPIKeyNotFound):
+				response.Error(w, http.StatusNotFound, err.Error())
+			default:
+				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
+			}
+
+			return
+		}
+		slog.InfoContext(r.Context(), "api key revoked", slog.String("key_id", id))
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"detail":  "API key " + id + " has been revoked",
+		})
+	}
+}
+
+// checkTenant is a method that returns an error when the credentials of ctx are bound to a tenant
+// and the key id is bound to another tenant or to none
+func (h *APIKeyDefault) checkTenant(ctx context.Context, id string) (err error) {
+	bound := BoundTenant(ctx)
+	if bound == "" {
+		return
+	}
+
+	keys, err := h.st.FindAll()
+	if err != nil {
+		return
+	}
+	for _, k := range keys {
+		if k.ID == id && k.Tenant != bound {
+			slog.WarnContext(ctx, "tenant denied", slog.String("key_id", id), slog.String("tenant", k.Tenant), slog.String("bound_tenant", bound))
+			err = internal.ErrorTenantForbidden
+			return
+		}
+	}
+	return
+}
+
+// writeKeyError is a function that writes the response of an error of checkTenant
+func writeKeyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, internal.ErrorTenantForbidden):
+		response.Error(w, http.StatusForbidden, internal.ErrorTenantForbidden.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
+	}
+}
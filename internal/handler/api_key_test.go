@@ -0,0 +1,176 @@
+package handler_test
+
+import (
+	"app/internal"
+	"app/internal/auth"
+	"app/internal/handler"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// newKeys is a function that returns a router of the key administration over a store
+// with the keys any (unbound), a and b (bound to their tenant), by name
+func newKeys(t *testing.T) (rt chi.Router, st *auth.APIKeyFile, ids map[string]string) {
+	t.Helper()
+	st, err := auth.NewAPIKeyFile(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids = make(map[string]string)
+	for _, k := range []struct{ name, tenant string }{{"any", ""}, {"a", "a"}, {"b", "b"}} {
+		key, _, err := st.Issue(k.name, k.tenant, []string{internal.ScopeAdmin}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[k.name] = key.ID
+	}
+
+	hd := handler.NewAPIKeyDefault(st, time.Hour)
+	rt = chi.NewRouter()
+	rt.Get("/admin/keys", hd.GetAll())
+	rt.Post("/admin/keys", hd.Issue())
+	rt.Post("/admin/keys/{id}/rotate", hd.Rotate())
+	rt.Delete("/admin/keys/{id}", hd.Revoke())
+	return
+}
+
+func TestAPIKeyDefault_GetAll(t *testing.T) {
+	cases := []struct {
+		name  string
+		bound string
+		keys  []string
+	}{
+		{name: "unbound admin sees every key", keys: []string{"any", "a", "b"}},
+		{name: "bound admin sees the keys of its tenant", bound: "a", keys: []string{"a"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rt, _, _ := newKeys(t)
+			req := withPrincipal(httptest.NewRequest(http.MethodGet, "/admin/keys", nil), c.bound)
+			res := httptest.NewRecorder()
+			rt.ServeHTTP(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", res.Code, http.StatusOK)
+			}
+			var body struct {
+				Data []handler.APIKeyJSON `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			names := make(map[string]bool, len(body.Data))
+			for _, k := range body.Data {
+				names[k.Name] = true
+			}
+			if len(names) != len(c.keys) {
+				t.Fatalf("keys = %v, want %v", names, c.keys)
+			}
+			for _, name := range c.keys {
+				if !names[name] {
+					t.Errorf("keys = %v, want %s among them", names, name)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIKeyDefault_Issue(t *testing.T) {
+	cases := []struct {
+		name   string
+		bound  string
+		tenant string
+		status int
+		issued string
+	}{
+		{name: "unbound admin issues an unbound key", status: http.StatusCreated},
+		{name: "unbound admin issues a key of any tenant", tenant: "b", status: http.StatusCreated, issued: "b"},
+		{name: "bound admin issues a key of its tenant by default", bound: "a", status: http.StatusCreated, issued: "a"},
+		{name: "bound admin issues a key of its tenant", bound: "a", tenant: "a", status: http.StatusCreated, issued: "a"},
+		{name: "bound admin issues a key of another tenant", bound: "a", tenant: "b", status: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rt, st, _ := newKeys(t)
+			body := `{"name":"new","tenant":"` + c.tenant + `","scopes":["vehicles:read"]}`
+			req := withPrincipal(httptest.NewRequest(http.MethodPost, "/admin/keys", strings.NewReader(body)), c.bound)
+			res := httptest.NewRecorder()
+			rt.ServeHTTP(res, req)
+
+			if res.Code != c.status {
+				t.Fatalf("status = %d, want %d: %s", res.Code, c.status, res.Body)
+			}
+			keys, err := st.FindAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var issued []internal.APIKey
+			for _, k := range keys {
+				if k.Name == "new" {
+					issued = append(issued, k)
+				}
+			}
+			if c.status != http.StatusCreated {
+				if len(issued) != 0 {
+					t.Errorf("keys issued = %+v, want none", issued)
+				}
+				return
+			}
+			if len(issued) != 1 || issued[0].Tenant != c.issued {
+				t.Errorf("keys issued = %+v, want one of the tenant %q", issued, c.issued)
+			}
+		})
+	}
+}
+
+func TestAPIKeyDefault_RotateRevoke(t *testing.T) {
+	cases := []struct {
+		name   string
+		bound  string
+		key    string
+		status int
+	}{
+		{name: "unbound admin on a key of a tenant", key: "b", status: http.StatusOK},
+		{name: "bound admin on a key of its tenant", bound: "a", key: "a", status: http.StatusOK},
+		{name: "bound admin on a key of another tenant", bound: "a", key: "b", status: http.StatusForbidden},
+		{name: "bound admin on an unbound key", bound: "a", key: "any", status: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		for _, method := range []string{http.MethodPost, http.MethodDelete} {
+			t.Run(c.name+" "+method, func(t *testing.T) {
+				rt, st, ids := newKeys(t)
+				target := "/admin/keys/" + ids[c.key]
+				if method == http.MethodPost {
+					target += "/rotate"
+				}
+				req := withPrincipal(httptest.NewRequest(method, target, nil), c.bound)
+				res := httptest.NewRecorder()
+				rt.ServeHTTP(res, req)
+
+				if res.Code != c.status {
+					t.Fatalf("status = %d, want %d: %s", res.Code, c.status, res.Body)
+				}
+				keys, err := st.FindAll()
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, k := range keys {
+					changed := !k.RotatedAt.IsZero() || !k.RevokedAt.IsZero()
+					if k.ID == ids[c.key] && changed != (c.status == http.StatusOK) {
+						t.Errorf("key = %+v, want it changed only when allowed", k)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/tools"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// PathCatalogBrands is the path of the brands of the catalog
+const PathCatalogBrands = "/v2/catalog/brands"
+
+// CatalogModelJSON is a struct that represents a model of the catalog in JSON format
+type CatalogModelJSON struct {
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases"`
+	BodyType string   `json:"body_type"`
+	YearFrom int      `json:"year_from"`
+	YearTo   int      `json:"year_to"`
+}
+
+// CatalogBrandJSON is a struct that represents a brand of the catalog with its models in JSON format
+type CatalogBrandJSON struct {
+	Name    string             `json:"name"`
+	Aliases []string           `json:"aliases"`
+	Country string             `json:"country"`
+	Models  []CatalogModelJSON `json:"models"`
+}
+
+// JSON is a method that returns a CatalogModelJSON from a CatalogModel
+func (m *CatalogModelJSON) JSON(model internal.CatalogModel) CatalogModelJSON {
+	m.Name = model.Name
+	m.Aliases = append([]string{}, model.Aliases...)
+	m.BodyType = string(model.BodyType)
+	m.YearFrom = model.YearFrom
+	m.YearTo = model.YearTo
+
+	return *m
+}
+
+// Model is a method that returns the CatalogModel described by a CatalogModelJSON
+func (m CatalogModelJSON) Model() internal.CatalogModel {
+	return internal.CatalogModel{
+		Name:     m.Name,
+		Aliases:  m.Aliases,
+		BodyType: internal.BodyType(m.BodyType),
+		YearFrom: m.YearFrom,
+		YearTo:   m.YearTo,
+	}
+}
+
+// JSON is a method that returns a CatalogBrandJSON from a CatalogBrand
+func (b *CatalogBrandJSON) JSON(brand internal.CatalogBrand) CatalogBrandJSON {
+	b.Name = brand.Name
+	b.Aliases = append([]string{}, brand.Aliases...)
+	b.Country = brand.Country
+	b.Models = make([]CatalogModelJSON, 0, len(brand.Models))
+	for _, m := range brand.Models {
+		b.Models = append(b.Models, (&CatalogModelJSON{}).JSON(m))
+	}
+
+	return *b
+}
+
+// Brand is a method that returns the CatalogBrand described by a CatalogBrandJSON
+func (b CatalogBrandJSON) Brand() internal.CatalogBrand {
+	brand := internal.CatalogBrand{
+		Name:    b.Name,
+		Aliases: b.Aliases,
+		Country: b.Country,
+		Models:  make([]internal.CatalogModel, 0, len(b.Models)),
+	}
+	for _, m := range b.Models {
+		brand.Models = append(brand.Models, m.Model())
+	}
+	return brand
+}
+
+// NewCatalogDefault is a function that returns a new instance of CatalogDefault
+func NewCatalogDefault(rp internal.CatalogRepository) *CatalogDefault {
+	return &CatalogDefault{rp: rp}
+}
+
+// CatalogDefault is a struct with methods that represent handlers for the catalog of brands and models,
+// the brands and models of the routes are given by their names or aliases
+type CatalogDefault struct {
+	// rp is the repository of the catalog
+	rp internal.CatalogRepository
+}
+
+// GetBrands is a method that returns a handler for the route GET /v2/catalog/brands
+func (h *CatalogDefault) GetBrands() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// process
+		brands, err := h.rp.FindAllContext(r.Context())
+		if err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		data := make([]CatalogBrandJSON, 0, len(brands))
+		for _, b := range brands {
+			data = append(data, (&CatalogBrandJSON{}).JSON(b))
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    data,
+			"meta":    map[string]any{"count": len(data)},
+		})
+	}
+}
+
+// GetBrand is a method that returns a handler for the route GET /v2/catalog/brands/{brand}
+func (h *CatalogDefault) GetBrand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		brand := tools.ParamsFromContext(r.Context()).String("brand")
+
+		// process
+		b, err := h.rp.FindBrandContext(r.Context(), brand)
+		if err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    (&CatalogBrandJSON{}).JSON(b),
+		})
+	}
+}
+
+// CreateBrand is a method that returns a handler for the route POST /v2/catalog/brands
+func (h *CatalogDefault) CreateBrand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: the required fields were validated against the OpenAPI document
+		var body CatalogBrandJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+
+		// process
+		if err := h.rp.CreateBrandContext(r.Context(), body.Brand()); err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		h.writeBrand(w, r.Context(), body.Name, http.StatusCreated)
+	}
+}
+
+// UpdateBrand is a method that returns a handler for the route PUT /v2/catalog/brands/{brand},
+// the brand is replaced with its models
+func (h *CatalogDefault) UpdateBrand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		brand := tools.ParamsFromContext(r.Context()).String("brand")
+		var body CatalogBrandJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+
+		// process
+		if err := h.rp.UpdateBrandContext(r.Context(), brand, body.Brand()); err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		h.writeBrand(w, r.Context(), body.Name, http.StatusOK)
+	}
+}
+
+// DeleteBrand is a method that returns a handler for the route DELETE /v2/catalog/brands/{brand},
+// the vehicles of the brand are not linked to the catalog anymore
+func (h *CatalogDefault) DeleteBrand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		brand := tools.ParamsFromContext(r.Context()).String("brand")
+
+		// process
+		if err := h.rp.DeleteBrandContext(r.Context(), brand); err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// CreateModel is a method that returns a handler for the route POST /v2/catalog/brands/{brand}/models
+func (h *CatalogDefault) CreateModel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		brand := tools.ParamsFromContext(r.Context()).String("brand")
+		var body CatalogModelJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+
+		// process
+		if err := h.rp.CreateModelContext(r.Context(), brand, body.Model()); err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		h.writeBrand(w, r.Context(), brand, http.StatusCreated)
+	}
+}
+
+// UpdateModel is a method that returns a handler for the route PUT /v2/catalog/brands/{brand}/models/{model}
+func (h *CatalogDefault) UpdateModel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		brand, model := params.String("brand"), params.String("model")
+		var body CatalogModelJSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+
+		// process
+		if err := h.rp.UpdateModelContext(r.Context(), brand, model, body.Model()); err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		h.writeBrand(w, r.Context(), brand, http.StatusOK)
+	}
+}
+
+// DeleteModel is a method that returns a handler for the route DELETE /v2/catalog/brands/{brand}/models/{model}
+func (h *CatalogDefault) DeleteModel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+
+		// process
+		if err := h.rp.DeleteModelContext(r.Context(), params.String("brand"), params.String("model")); err != nil {
+			writeCatalogError(w, err)
+			return
+		}
+
+		// response
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// writeBrand is a method that writes a brand as written, with its location, as the response of a status code
+func (h *CatalogDefault) writeBrand(w http.ResponseWriter, ctx context.Context, brand string, code int) {
+	b, err := h.rp.FindBrandContext(ctx, brand)
+	if err != nil {
+		writeCatalogError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", PathCatalogBrands+"/"+url.PathEscape(b.Name))
+	response.JSON(w, code, map[string]any{
+		"message": "Success",
+		"data":    (&CatalogBrandJSON{}).JSON(b),
+	})
+}
+
+// writeCatalogError is a function that writes the error of the catalog as the response of its status code
+func writeCatalogError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, internal.ErrorCatalogBrandNotFound), errors.Is(err, internal.ErrorCatalogModelNotFound):
+		response.Error(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, internal.ErrorCatalogConflict):
+		response.Error(w, http.StatusConflict, err.Error())
+	case errors.Is(err, internal.ErrorCatalogInvalid):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		response.Error(w, http.StatusGatewayTimeout, internal.ErrorRequestTimeout.Error())
+	case errors.Is(err, context.Canceled):
+		response.Error(w, http.StatusServiceUnavailable, internal.ErrorRequestCanceled.Error())
+	default:
+		response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
+	}
+}
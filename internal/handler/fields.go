@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"app/internal"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ParamFields is the query parameter selecting the fields of the vehicles of a response (sparse fieldset)
+const ParamFields = "fields"
+
+// vehicleField is a struct that represents a field of VehicleJSON and how it is encoded
+type vehicleField struct {
+	// name is the JSON name of the field
+	name string
+	// append appends the JSON value of the field of a vehicle to b
+	append func(b []byte, v internal.Vehicle) []byte
+}
+
+// vehicleFields are the fields of VehicleJSON, in its order
+var vehicleFields = []vehicleField{
+	{name: "id", append: func(b []byte, v internal.Vehicle) []byte { return strconv.AppendInt(b, int64(v.Id), 10) }},
+	{name: "brand", append: func(b []byte, v internal.Vehicle) []byte { return appendString(b, v.Brand) }},
+	{name: "model", append: func(b []byte, v internal.Vehicle) []byte { return appendString(b, v.Model) }},
+	{name: "registration", append: func(b []byte, v internal.Vehicle) []byte { return appendString(b, v.Registration) }},
+	{name: "color", append: func(b []byte, v internal.Vehicle) []byte { return appendString(b, v.Color) }},
+	{name: "year", append: func(b []byte, v internal.Vehicle) []byte { return strconv.AppendInt(b, int64(v.FabricationYear), 10) }},
+	{name: "passengers", append: func(b []byte, v internal.Vehicle) []byte { return strconv.AppendInt(b, int64(v.Capacity), 10) }},
+	{name: "max_speed", append: func(b []byte, v internal.Vehicle) []byte { return appendFloat(b, v.MaxSpeed) }},
+	{name: "fuel_type", append: func(b []byte, v internal.Vehicle) []byte { return appendString(b, string(v.FuelType)) }},
+	{name: "transmission", append: func(b []byte, v internal.Vehicle) []byte { return appendString(b, string(v.Transmission)) }},
+	{name: "weight", append: func(b []byte, v internal.Vehicle) []byte { return appendFloat(b, v.Weight) }},
+	{name: "height", append: func(b []byte, v internal.Vehicle) []byte { return appendFloat(b, v.Height) }},
+	{name: "length", append: func(b []byte, v internal.Vehicle) []byte { return appendFloat(b, v.Length) }},
+	{name: "width", append: func(b []byte, v internal.Vehicle) []byte { return appendFloat(b, v.Width) }},
+}
+
+// vehicleV2Fields are the fields of VehicleV2JSON, in its order: the fields of VehicleJSON then the ones added since
+var vehicleV2Fields = append(vehicleFields[:len(vehicleFields):len(vehicleFields)],
+	vehicleField{name: "vin", append: func(b []byte, v internal.Vehicle) []byte { return appendString(b, v.VIN) }},
+)
+
+// vehicleFieldGroups are the names selecting several fields or a field by its path in internal.Vehicle
+var vehicleFieldGroups = map[string][]string{
+	"dimensions":        {"height", "length", "width"},
+	"dimensions.height": {"height"},
+	"dimensions.length": {"length"},
+	"dimensions.width":  {"width"},
+}
+
+// VehicleFieldNames is a function that returns the names accepted by the fields parameter of the version 1
+func VehicleFieldNames() (names []string) {
+	return fieldNames(vehicleFields)
+}
+
+// VehicleV2FieldNames is a function that returns the names accepted by the fields parameter of the version 2
+func VehicleV2FieldNames() (names []string) {
+	return fieldNames(vehicleV2Fields)
+}
+
+// fieldNames is a function that returns the names of fields and of the groups
+func fieldNames(fields []vehicleField) (names []string) {
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+	groups := make([]string, 0, len(vehicleFieldGroups))
+	for name := range vehicleFieldGroups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	names = append(names, groups...)
+	return
+}
+
+// NewVehicleFields is a function that returns the projection of the vehicles on the fields of VehicleJSON named,
+// every field without names. The fields keep the order of VehicleJSON whatever the order of the names
+func NewVehicleFields(names []string) (f *VehicleFields, err error) {
+	return newVehicleFields(vehicleFields, names)
+}
+
+// NewVehicleV2Fields is a function that returns the projection of the vehicles on the fields of VehicleV2JSON named,
+// as NewVehicleFields
+func NewVehicleV2Fields(names []string) (f *VehicleFields, err error) {
+	return newVehicleFields(vehicleV2Fields, names)
+}
+
+// newVehicleFields is a function that returns the projection of the vehicles on the fields named among fields
+func newVehicleFields(fields []vehicleField, names []string) (f *VehicleFields, err error) {
+	f = &VehicleFields{}
+	if len(names) == 0 {
+		f.fields = fields
+		return
+	}
+
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		if group, ok := vehicleFieldGroups[name]; ok {
+			for _, n := range group {
+				selected[n] = true
+			}
+			continue
+		}
+		if !isVehicleField(fields, name) {
+			err = fmt.Errorf("%w: unknown field %q", internal.ErrorInvalidFields, name)
+			return
+		}
+		selected[name] = true
+	}
+	for _, field := range fields {
+		if selected[field.name] {
+			f.fields = append(f.fields, field)
+		}
+	}
+	return
+}
+
+// VehicleFields is a struct that encodes the vehicles of a response as VehicleJSON restricted to some fields,
+// straight from internal.Vehicle without building a VehicleJSON per vehicle
+type VehicleFields struct {
+	// fields are the fields encoded
+	fields []vehicleField
+}
+
+// Vehicle is a method that returns the JSON encoding of a vehicle as the data of a response
+func (f *VehicleFields) Vehicle(v internal.Vehicle) RawJSON {
+	return f.appendVehicle(make([]byte, 0, 32*len(f.fields)), v)
+}
+
+// Vehicles is a method that returns the JSON encoding of the vehicles by ID as the data of a response,
+// the IDs are sorted as encoding/json sorts the keys of a map
+func (f *VehicleFields) Vehicles(v map[int]internal.Vehicle) RawJSON {
+	keys := make([]string, 0, len(v))
+	for id := range v {
+		keys = append(keys, strconv.Itoa(id))
+	}
+	sort.Strings(keys)
+
+	b := make([]byte, 0, 2+len(v)*(8+32*len(f.fields)))
+	b = append(b, '{')
+	for i, key := range keys {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		id, _ := strconv.Atoi(key)
+		b = append(b, '"')
+		b = append(b, key...)
+		b = append(b, '"', ':')
+		b = f.appendVehicle(b, v[id])
+	}
+	return append(b, '}')
+}
+
+// List is a method that returns the JSON encoding of the vehicles as an array, in their order
+func (f *VehicleFields) List(v []internal.Vehicle) RawJSON {
+	b := make([]byte, 0, 2+len(v)*32*len(f.fields))
+	b = append(b, '[')
+	for i, vehicle := range v {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = f.appendVehicle(b, vehicle)
+	}
+	return append(b, ']')
+}
+
+// appendVehicle is a method that appends the JSON object of the fields of a vehicle to b
+func (f *VehicleFields) appendVehicle(b []byte, v internal.Vehicle) []byte {
+	b = append(b, '{')
+	for i, field := range f.fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, '"')
+		b = append(b, field.name...)
+		b = append(b, '"', ':')
+		b = field.append(b, v)
+	}
+	return append(b, '}')
+}
+
+// RawJSON is a JSON value already encoded, embedded as it is in a response
+type RawJSON []byte
+
+// MarshalJSON is a method that returns the encoded value
+func (j RawJSON) MarshalJSON() ([]byte, error) { return j, nil }
+
+// isVehicleField is a function that reports whether name is one of fields
+func isVehicleField(fields []vehicleField, name string) bool {
+	for _, f := range fields {
+		if f.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// appendFloat is a function that appends a number as encoding/json encodes a float64,
+// the values of the vehicles are finite
+func appendFloat(b []byte, f float64) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return append(b, '0')
+	}
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	b = strconv.AppendFloat(b, f, format, -1, 64)
+	if format == 'e' {
+		// - clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+// hexDigits are the digits of the \u escapes
+const hexDigits = "0123456789abcdef"
+
+// appendString is a function that appends a string as encoding/json encodes it since Go 1.22, HTML characters escaped
+func appendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+		case r == '\u2028' || r == '\u2029':
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
@@ -0,0 +1,244 @@
+package handler_test
+
+import (
+	"app/internal"
+	"app/internal/handler"
+	"app/internal/repository"
+	"app/internal/service"
+	"app/internal/tools"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fieldsVehicles are vehicles whose strings and numbers exercise the escaping and the number formats of encoding/json
+var fieldsVehicles = []internal.Vehicle{
+	{Id: 1, VehicleAttributes: internal.VehicleAttributes{
+		VIN: "1HGCM82633A004352", Brand: `quote " backslash \ slash /`, Model: "control \x00\x01\x1f\b\f\n\r\t\x7f", Registration: "<script>&amp;</script>",
+		Color: "non-ASCII ñ 日本 😀", FabricationYear: 2010, Capacity: 5, FuelType: "line separators \u2028\u2029", Transmission: "invalid UTF-8 \xff\xfe",
+		MaxSpeed: 0.1, Weight: 1e20, Dimensions: internal.Dimensions{Height: 1e21, Length: 1e-6, Width: 1e-7},
+	}},
+	{Id: 22, VehicleAttributes: internal.VehicleAttributes{
+		Brand: "", FabricationYear: -1, Capacity: math.MaxInt32,
+		MaxSpeed: 200, Weight: math.MaxFloat64,
+		Dimensions: internal.Dimensions{Height: math.SmallestNonzeroFloat64, Length: -2.5e-9, Width: math.Copysign(0, -1)},
+	}},
+	{Id: 3, VehicleAttributes: internal.VehicleAttributes{
+		Brand: "Acura", Model: "MDX", Color: "Red", MaxSpeed: 123456789.125, Weight: 1500.5,
+		Dimensions: internal.Dimensions{Height: 1.75, Length: 4.9, Width: 2},
+	}},
+}
+
+// jsonNames is a function that returns the JSON names of the fields of a struct in their order, embedded ones flattened
+func jsonNames(t reflect.Type) (names []string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			names = append(names, jsonNames(f.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		names = append(names, name)
+	}
+	return
+}
+
+func TestVehicleFields_Vehicle(t *testing.T) {
+	cases := []struct {
+		name      string
+		fields    func(names []string) (*handler.VehicleFields, error)
+		marshaled func(v internal.Vehicle) any
+		names     []string
+	}{
+		{
+			name:      "version 1",
+			fields:    handler.NewVehicleFields,
+			marshaled: func(v internal.Vehicle) any { return (&handler.VehicleJSON{}).JSON(v) },
+			names:     jsonNames(reflect.TypeOf(handler.VehicleJSON{})),
+		},
+		{
+			name:      "version 2",
+			fields:    handler.NewVehicleV2Fields,
+			marshaled: func(v internal.Vehicle) any { return (&handler.VehicleV2JSON{}).JSON(v) },
+			names:     jsonNames(reflect.TypeOf(handler.VehicleV2JSON{})),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, v := range fieldsVehicles {
+				b, err := json.Marshal(c.marshaled(v))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var want map[string]json.RawMessage
+				if err := json.Unmarshal(b, &want); err != nil {
+					t.Fatal(err)
+				}
+
+				// - every subset of the fields, named in reverse to check they keep the order of the struct
+				for subset := 1; subset < 1<<len(c.names); subset++ {
+					var names []string
+					expected := []byte{'{'}
+					for i, name := range c.names {
+						if subset&(1<<i) == 0 {
+							continue
+						}
+						names = append([]string{name}, names...)
+						if len(expected) > 1 {
+							expected = append(expected, ',')
+						}
+						expected = append(append(expected, `"`+name+`":`...), want[name]...)
+					}
+					expected = append(expected, '}')
+
+					f, err := c.fields(names)
+					if err != nil {
+						t.Fatalf("fields %v: error = %v", names, err)
+					}
+					got := f.Vehicle(v)
+					var decoded map[string]json.RawMessage
+					if err := json.Unmarshal(got, &decoded); err != nil || len(decoded) != len(names) {
+						t.Fatalf("vehicle %d, fields %v: decoded = %v %v, want the fields only", v.Id, names, decoded, err)
+					}
+					if !bytes.Equal(got, expected) {
+						t.Fatalf("vehicle %d, fields %v:\n got %s\nwant %s", v.Id, names, got, expected)
+					}
+				}
+
+				// - no names is every field
+				f, err := c.fields(nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := f.Vehicle(v); !bytes.Equal(got, b) {
+					t.Errorf("vehicle %d, every field:\n got %s\nwant %s", v.Id, got, b)
+				}
+			}
+		})
+	}
+}
+
+func TestVehicleFields_VehiclesList(t *testing.T) {
+	f, err := handler.NewVehicleFields(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := make(map[int]internal.Vehicle)
+	byIDJSON := make(map[int]handler.VehicleJSON)
+	var list []handler.VehicleJSON
+	for _, v := range fieldsVehicles {
+		byID[v.Id], byIDJSON[v.Id] = v, (&handler.VehicleJSON{}).JSON(v)
+		list = append(list, byIDJSON[v.Id])
+	}
+
+	// - the IDs sorted as strings, as encoding/json sorts the keys of a map: 1, 22, 3
+	want, err := json.Marshal(byIDJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Vehicles(byID); !bytes.Equal(got, want) {
+		t.Errorf("vehicles:\n got %s\nwant %s", got, want)
+	}
+	want, err = json.Marshal(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.List(fieldsVehicles); !bytes.Equal(got, want) {
+		t.Errorf("list:\n got %s\nwant %s", got, want)
+	}
+	if got := f.List(nil); string(got) != "[]" {
+		t.Errorf("empty list = %s, want []", got)
+	}
+	if got := f.Vehicles(nil); string(got) != "{}" {
+		t.Errorf("no vehicles = %s, want {}", got)
+	}
+}
+
+func TestNewVehicleFields(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields func(names []string) (*handler.VehicleFields, error)
+		names  []string
+		keys   []string
+		err    error
+	}{
+		{name: "in the order of the struct", fields: handler.NewVehicleFields, names: []string{"color", "id", "color"}, keys: []string{"id", "color"}},
+		{name: "group", fields: handler.NewVehicleFields, names: []string{"dimensions", "brand"}, keys: []string{"brand", "height", "length", "width"}},
+		{name: "field by its path", fields: handler.NewVehicleFields, names: []string{"dimensions.width"}, keys: []string{"width"}},
+		{name: "field of the version 2", fields: handler.NewVehicleV2Fields, names: []string{"vin", "id"}, keys: []string{"id", "vin"}},
+		{name: "field of the version 2 in the version 1", fields: handler.NewVehicleFields, names: []string{"vin"}, err: internal.ErrorInvalidFields},
+		{name: "unknown field", fields: handler.NewVehicleFields, names: []string{"brand", "colour"}, err: internal.ErrorInvalidFields},
+		{name: "unknown field in the version 2", fields: handler.NewVehicleV2Fields, names: []string{"dimensions.depth"}, err: internal.ErrorInvalidFields},
+		{name: "empty name", fields: handler.NewVehicleV2Fields, names: []string{""}, err: internal.ErrorInvalidFields},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f, err := c.fields(c.names)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err != nil {
+				return
+			}
+			var decoded map[string]json.RawMessage
+			if err := json.Unmarshal(f.Vehicle(fieldsVehicles[2]), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			keys := make([]string, 0, len(decoded))
+			for k := range decoded {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			want := slices.Clone(c.keys)
+			slices.Sort(want)
+			if !slices.Equal(keys, want) {
+				t.Errorf("fields = %v, want %v", keys, c.keys)
+			}
+		})
+	}
+}
+
+func TestVehicleDefault_GetAll_Fields(t *testing.T) {
+	cases := []struct {
+		name   string
+		fields []string
+		status int
+		body   string
+	}{
+		{name: "known fields", fields: []string{"brand"}, status: http.StatusOK, body: `"data":{"1":{"brand":"Acura"}}`},
+		{name: "unknown field", fields: []string{"brand", "colour"}, status: http.StatusBadRequest, body: `unknown field \"colour\"`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := internal.WithTenant(context.Background(), internal.DefaultTenant)
+			rp := repository.NewVehicleTenant(nil, nil, nil, nil, nil)
+			v := internal.Vehicle{Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Acura", Registration: "A1"}}
+			if err := rp.ReplaceAllContext(ctx, map[int]internal.Vehicle{1: v}); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/vehicles", nil)
+			req = req.WithContext(tools.WithParams(ctx, tools.Params{handler.ParamFields: c.fields}))
+			res := httptest.NewRecorder()
+			handler.NewVehicleDefault(service.NewVehicleDefault(rp)).GetAll()(res, req)
+
+			if res.Code != c.status {
+				t.Fatalf("status = %d, want %d: %s", res.Code, c.status, res.Body)
+			}
+			if !strings.Contains(res.Body.String(), c.body) {
+				t.Errorf("body = %s, want it to contain %s", res.Body, c.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/graphql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// GraphQLRequestJSON is a struct that represents the body of a GraphQL request
+type GraphQLRequestJSON struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Authorizer is an interface that represents the policy letting a principal invoke a handler
+type Authorizer interface {
+	// Authorize is a method that reports whether a principal may invoke handler
+	Authorize(handler string, p internal.Principal) bool
+}
+
+// graphQLRule is a struct that represents how a root field is governed
+type graphQLRule struct {
+	// handler is the name of the field in the authorization policy
+	handler string
+	// class is the class of the field in the rate limiter
+	class string
+}
+
+// graphQLMutations are the rules of the root fields of the mutations, the ones missing follow graphQLMutation
+var graphQLMutations = map[string]graphQLRule{
+	"createVehicle":  {handler: "GraphQLDefault.Mutate", class: internal.RateClassWrite},
+	"createVehicles": {handler: "GraphQLDefault.Mutate", class: internal.RateClassBatch},
+	"updateMaxSpeed": {handler: "GraphQLDefault.Mutate", class: internal.RateClassWrite},
+	"updateFuelType": {handler: "GraphQLDefault.Mutate", class: internal.RateClassWrite},
+	"deleteVehicle":  {handler: "GraphQLDefault.Delete", class: internal.RateClassWrite},
+}
+
+// graphQLMutation is the rule of the root fields of the mutations missing from graphQLMutations
+var graphQLMutation = graphQLRule{handler: "GraphQLDefault.Mutate", class: internal.RateClassWrite}
+
+// graphQLQuery is the rule of the root fields of the queries and the introspection fields
+var graphQLQuery = graphQLRule{handler: "GraphQLDefault.Query", class: internal.RateClassRead}
+
+// graphQLClasses are the rate limit classes from the least to the most expensive
+var graphQLClasses = []string{internal.RateClassRead, internal.RateClassWrite, internal.RateClassBatch}
+
+// ConfigGraphQLDefault is a struct that represents the configuration for GraphQLDefault
+type ConfigGraphQLDefault struct {
+	// Executor executes the operations
+	Executor *graphql.Executor
+	// Authorizer authorizes the root fields of each operation, nil lets every principal through
+	Authorizer Authorizer
+	// Classes are the middlewares (timeout and rate limit) of each rate limit class,
+	// an operation runs through the one of its most expensive root field
+	Classes map[string]func(http.Handler) http.Handler
+}
+
+// NewGraphQLDefault is a function that returns a new instance of GraphQLDefault
+func NewGraphQLDefault(cfg ConfigGraphQLDefault) *GraphQLDefault {
+	return &GraphQLDefault{ex: cfg.Executor, au: cfg.Authorizer, classes: cfg.Classes}
+}
+
+// GraphQLDefault is a struct with methods that represent handlers for the GraphQL API
+type GraphQLDefault struct {
+	// ex executes the operations
+	ex *graphql.Executor
+	// au authorizes the root fields of each operation
+	au Authorizer
+	// classes are the middlewares of each rate limit class
+	classes map[string]func(http.Handler) http.Handler
+}
+
+// Execute is a method that returns a handler for the route POST /graphql, the operation is
+// - rejected with 400 if it can not be parsed, is invalid or exceeds the depth or complexity limits
+// - rejected with 403 if the policy does not allow any of its root fields to the principal
+// - otherwise executed, the errors of the resolvers are reported along the data with 200
+func (h *GraphQLDefault) Execute() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var req GraphQLRequestJSON
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+
+		// process
+		// - parse, validate and measure
+		op, errs := h.ex.Prepare(graphql.Request{Query: req.Query, OperationName: req.OperationName, Variables: req.Variables})
+		if errs != nil {
+			response.JSON(w, http.StatusBadRequest, map[string]any{"errors": errs})
+			return
+		}
+		// - authorize each root field, the operation runs in the class of the most expensive one
+		p, _ := internal.PrincipalFromContext(r.Context())
+		class := 0
+		for _, field := range op.Fields {
+			rule := graphQLQuery
+			if op.Type == graphql.OperationMutation && !strings.HasPrefix(field, "__") {
+				var ok bool
+				if rule, ok = graphQLMutations[field]; !ok {
+					rule = graphQLMutation
+				}
+			}
+			if h.au != nil && !h.au.Authorize(rule.handler, p) {
+				slog.WarnContext(r.Context(), "authorization denied",
+					slog.String("handler", rule.handler),
+					slog.String("field", field),
+					slog.String("subject", p.Subject),
+					slog.String("method", p.Method),
+				)
+				fe := gqlerrors.NewFormattedError(internal.ErrorForbidden.Error() + ": " + rule.handler)
+				fe.Path = []any{field}
+				fe.Extensions = map[string]any{"code": graphql.CodeForbidden}
+				response.JSON(w, http.StatusForbidden, map[string]any{"errors": []gqlerrors.FormattedError{fe}})
+				return
+			}
+			for i, c := range graphQLClasses {
+				if c == rule.class && i > class {
+					class = i
+				}
+			}
+		}
+
+		var execute http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res := h.ex.Execute(r.Context(), op)
+
+			// response
+			response.JSON(w, http.StatusOK, res)
+		})
+		if mw, ok := h.classes[graphQLClasses[class]]; ok {
+			execute = mw(execute)
+		}
+		execute.ServeHTTP(w, r)
+	}
+}
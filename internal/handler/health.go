@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"app/internal"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// ConfigHealthDefault is a struct that represents the configuration for HealthDefault
+type ConfigHealthDefault struct {
+	// Reloader reports whether the dataset has been loaded
+	Reloader internal.VehicleReloader
+	// Repository is checked for reachability when it implements internal.VehicleRepositoryPinger
+	Repository internal.VehicleRepository
+	// ShuttingDown reports whether the application is shutting down
+	ShuttingDown func() bool
+}
+
+// NewHealthDefault is a function that returns a new instance of HealthDefault
+func NewHealthDefault(cfg ConfigHealthDefault) *HealthDefault {
+	// default values
+	if cfg.ShuttingDown == nil {
+		cfg.ShuttingDown = func() bool { return false }
+	}
+
+	return &HealthDefault{
+		rl:           cfg.Reloader,
+		rp:           cfg.Repository,
+		shuttingDown: cfg.ShuttingDown,
+	}
+}
+
+// HealthDefault is a struct with methods that represent handlers for the probes of the orchestrator
+type HealthDefault struct {
+	// rl reports whether the dataset has been loaded
+	rl internal.VehicleReloader
+	// rp is the repository checked for reachability
+	rp internal.VehicleRepository
+	// shuttingDown reports whether the application is shutting down
+	shuttingDown func() bool
+}
+
+// Healthz is a method that returns a handler for the route GET /healthz
+func (h *HealthDefault) Healthz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// the process is alive as long as it can answer
+		response.JSON(w, http.StatusOK, map[string]any{
+			"status": "ok",
+		})
+	}
+}
+
+// Readyz is a method that returns a handler for the route GET /readyz
+func (h *HealthDefault) Readyz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// process
+		// - run every check
+		checks := map[string]string{
+			"dataset":  "ok",
+			"storage":  "ok",
+			"shutdown": "ok",
+		}
+		ready := true
+		if h.rl.Status().LastSuccess.IsZero() {
+			checks["dataset"] = internal.ErrorDatasetNotLoaded.Error()
+			ready = false
+		}
+		if pn, ok := h.rp.(internal.VehicleRepositoryPinger); ok {
+			if err := pn.Ping(); err != nil {
+				checks["storage"] = err.Error()
+				ready = false
+			}
+		}
+		if h.shuttingDown() {
+			checks["shutdown"] = "Shutting down"
+			ready = false
+		}
+
+		// response
+		code, status := http.StatusOK, "ready"
+		if !ready {
+			code, status = http.StatusServiceUnavailable, "not ready"
+		}
+		response.JSON(w, code, map[string]any{
+			"status": status,
+			"checks": checks,
+		})
+	}
+}
+
+// Version is a method that returns a handler for the route GET /version
+func (h *HealthDefault) Version() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// build info
+		build := map[string]any{}
+		if info, ok := debug.ReadBuildInfo(); ok {
+			build["go_version"] = info.GoVersion
+			build["path"] = info.Path
+			build["version"] = info.Main.Version
+			for _, setting := range info.Settings {
+				switch setting.Key {
+				case "vcs.revision":
+					build["revision"] = setting.Value
+				case "vcs.time":
+					build["revision_time"] = setting.Value
+				case "vcs.modified":
+					build["modified"] = setting.Value == "true"
+				}
+			}
+		}
+
+		// dataset info
+		status := h.rl.Status()
+		dataset := map[string]any{
+			"size":   status.DatasetSize,
+			"source": status.Source,
+		}
+		if !status.LastSuccess.IsZero() {
+			dataset["last_reload"] = status.LastSuccess.Format(time.RFC3339)
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"build":   build,
+			"dataset": dataset,
+		})
+	}
+}
+
+// RequireReady is a method that returns a middleware answering 503 until the dataset has been loaded
+func (h *HealthDefault) RequireReady() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h.rl.Status().LastSuccess.IsZero() {
+				w.Header().Set("Retry-After", "1")
+				response.Error(w, http.StatusServiceUnavailable, internal.ErrorDatasetNotLoaded.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
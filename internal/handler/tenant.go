@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"app/internal"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// HeaderTenant is the header naming the tenant (fleet) of a request
+const HeaderTenant = "X-Tenant-ID"
+
+// NewTenantDefault is a function that returns a new instance of TenantDefault
+func NewTenantDefault(rp internal.VehicleTenantRepository) *TenantDefault {
+	return &TenantDefault{rp: rp}
+}
+
+// TenantDefault is a struct with methods that represent handlers for the tenants of the fleet
+type TenantDefault struct {
+	// rp is the repository with a namespace per tenant
+	rp internal.VehicleTenantRepository
+}
+
+// Resolve is a method that returns a middleware scoping the request context to its tenant.
+// Credentials bound to a tenant (tenant claim, API key tenant) always act on it and a different
+// header is answered 403, otherwise the header names the tenant, DefaultTenant if missing.
+// It must run after the authentication
+func (h *TenantDefault) Resolve() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant, err := h.ResolveTenant(r.Context(), r.Header.Get(HeaderTenant))
+			if err != nil {
+				switch {
+				case errors.Is(err, internal.ErrorTenantForbidden):
+					response.Error(w, http.StatusForbidden, internal.ErrorTenantForbidden.Error())
+				case errors.Is(err, internal.ErrorTenantNotFound):
+					response.Error(w, http.StatusNotFound, err.Error())
+				default:
+					response.Error(w, http.StatusBadRequest, internal.ErrorTenantInvalid.Error())
+				}
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(internal.WithTenant(r.Context(), tenant)))
+		})
+	}
+}
+
+// ResolveTenant is a method that returns the tenant a caller acts on given the tenant it requested,
+// following the rules of Resolve for any transport
+func (h *TenantDefault) ResolveTenant(ctx context.Context, requested string) (tenant string, err error) {
+	tenant = strings.TrimSpace(requested)
+	if p, ok := internal.PrincipalFromContext(ctx); ok && p.Tenant != "" {
+		if tenant != "" && tenant != p.Tenant {
+			slog.WarnContext(ctx, "tenant denied", slog.String("tenant", tenant), slog.String("bound_tenant", p.Tenant))
+			err = internal.ErrorTenantForbidden
+			return
+		}
+		tenant = p.Tenant
+	}
+	if tenant == "" {
+		tenant = internal.DefaultTenant
+	}
+
+	switch {
+	case !internal.ValidTenant(tenant):
+		err = internal.ErrorTenantInvalid
+	case !h.rp.HasTenant(tenant):
+		err = fmt.Errorf("%w: %s", internal.ErrorTenantNotFound, tenant)
+	}
+	return
+}
+
+// BoundTenant is a function that returns the tenant the credentials of a request are bound to, empty if
+// they may act on any tenant. The administration routes acting across tenants are scoped to it
+func BoundTenant(ctx context.Context) (tenant string) {
+	if p, ok := internal.PrincipalFromContext(ctx); ok {
+		tenant = p.Tenant
+	}
+	return
+}
+
+// GetTenants is a method that returns a handler for the route GET /admin/tenants,
+// credentials bound to a tenant only see it
+func (h *TenantDefault) GetTenants() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		counts := h.rp.Tenants()
+		if bound := BoundTenant(r.Context()); bound != "" {
+			n, ok := counts[bound]
+			counts = map[string]int{}
+			if ok {
+				counts[bound] = n
+			}
+		}
+
+		type TenantJSON struct {
+			Tenant   string `json:"tenant"`
+			Vehicles int    `json:"vehicles"`
+		}
+		data := make([]TenantJSON, 0, len(counts))
+		for tenant, n := range counts {
+			data = append(data, TenantJSON{Tenant: tenant, Vehicles: n})
+		}
+		sort.Slice(data, func(i, j int) bool { return data[i].Tenant < data[j].Tenant })
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    data,
+		})
+	}
+}
+
+// GetAllVehicles is a method that returns a handler for the route GET /admin/vehicles,
+// the vehicles of every tenant grouped by tenant, ?tenant=a,b restricts the tenants.
+// Credentials bound to a tenant only see it and requesting another one is answered 403
+func (h *TenantDefault) GetAllVehicles() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var only map[string]bool
+		if q := r.URL.Query().Get("tenant"); q != "" {
+			only = make(map[string]bool)
+			for _, t := range strings.Split(q, ",") {
+				only[strings.TrimSpace(t)] = true
+			}
+		}
+		if bound := BoundTenant(r.Context()); bound != "" {
+			for tenant := range only {
+				if tenant != bound {
+					slog.WarnContext(r.Context(), "tenant denied", slog.String("tenant", tenant), slog.String("bound_tenant", bound))
+					response.Error(w, http.StatusForbidden, internal.ErrorTenantForbidden.Error())
+					return
+				}
+			}
+			only = map[string]bool{bound: true}
+		}
+
+		// process
+		v, err := h.rp.FindAllTenants(r.Context())
+		if err != nil {
+			writeError(w, err)
+
+			return
+		}
+
+		// response
+		data := make(map[string][]VehicleJSON, len(v))
+		for tenant, vehicles := range v {
+			if only != nil && !only[tenant] {
+				continue
+			}
+			list := make([]VehicleJSON, 0, len(vehicles))
+			for _, vehicle := range vehicles {
+				list = append(list, (&VehicleJSON{}).JSON(vehicle))
+			}
+			sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+			data[tenant] = list
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    data,
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package handler_test
+
+import (
+	"app/internal"
+	"app/internal/handler"
+	"app/internal/repository"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTenants is a function that returns a repository with the tenants a and b, of one vehicle each
+func newTenants(t *testing.T) *repository.VehicleTenant {
+	t.Helper()
+	rp := repository.NewVehicleTenant(nil, nil, nil, nil, nil)
+	for i, tenant := range []string{"a", "b"} {
+		v := internal.Vehicle{Id: 1, VehicleAttributes: internal.VehicleAttributes{
+			Brand: "Acura", Model: "MDX", Registration: "A" + tenant, Color: "Red", FabricationYear: 2010 + i,
+		}}
+		if err := rp.ReplaceAllContext(internal.WithTenant(context.Background(), tenant), map[int]internal.Vehicle{1: v}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return rp
+}
+
+// withPrincipal is a function that returns a request authenticated as an admin bound to tenant, unbound if empty
+func withPrincipal(r *http.Request, tenant string) *http.Request {
+	p := internal.Principal{Subject: "admin", Method: internal.AuthMethodJWT, Roles: []string{internal.RoleFleetAdmin}, Tenant: tenant}
+	return r.WithContext(internal.WithPrincipal(r.Context(), p))
+}
+
+func TestTenantDefault_GetTenants(t *testing.T) {
+	cases := []struct {
+		name    string
+		bound   string
+		tenants []string
+	}{
+		{name: "unbound admin sees every tenant", tenants: []string{"a", "b"}},
+		{name: "bound admin sees its tenant only", bound: "b", tenants: []string{"b"}},
+		{name: "bound admin of a tenant without namespace sees none", bound: "c"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hd := handler.NewTenantDefault(newTenants(t))
+			req := withPrincipal(httptest.NewRequest(http.MethodGet, "/admin/tenants", nil), c.bound)
+			res := httptest.NewRecorder()
+			hd.GetTenants()(res, req)
+
+			if res.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", res.Code, http.StatusOK)
+			}
+			var body struct {
+				Data []struct {
+					Tenant string `json:"tenant"`
+				} `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Data) != len(c.tenants) {
+				t.Fatalf("tenants = %+v, want %v", body.Data, c.tenants)
+			}
+			for i, tenant := range c.tenants {
+				if body.Data[i].Tenant != tenant {
+					t.Errorf("tenant %d = %s, want %s", i, body.Data[i].Tenant, tenant)
+				}
+			}
+		})
+	}
+}
+
+func TestTenantDefault_GetAllVehicles(t *testing.T) {
+	cases := []struct {
+		name    string
+		bound   string
+		query   string
+		status  int
+		tenants []string
+	}{
+		{name: "unbound admin sees every tenant", status: http.StatusOK, tenants: []string{"a", "b"}},
+		{name: "unbound admin restricts the tenants", query: "?tenant=b", status: http.StatusOK, tenants: []string{"b"}},
+		{name: "bound admin sees its tenant only", bound: "a", status: http.StatusOK, tenants: []string{"a"}},
+		{name: "bound admin requests its tenant", bound: "a", query: "?tenant=a", status: http.StatusOK, tenants: []string{"a"}},
+		{name: "bound admin requests another tenant", bound: "a", query: "?tenant=a,b", status: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hd := handler.NewTenantDefault(newTenants(t))
+			req := withPrincipal(httptest.NewRequest(http.MethodGet, "/admin/vehicles"+c.query, nil), c.bound)
+			res := httptest.NewRecorder()
+			hd.GetAllVehicles()(res, req)
+
+			if res.Code != c.status {
+				t.Fatalf("status = %d, want %d", res.Code, c.status)
+			}
+			if c.status != http.StatusOK {
+				return
+			}
+			var body struct {
+				Data map[string][]handler.VehicleJSON `json:"data"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Data) != len(c.tenants) {
+				t.Fatalf("vehicles = %+v, want the ones of %v", body.Data, c.tenants)
+			}
+			for _, tenant := range c.tenants {
+				if len(body.Data[tenant]) != 1 {
+					t.Errorf("vehicles of %s = %+v, want one", tenant, body.Data[tenant])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"app/internal"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Timeout is a function that returns a middleware bounding the handling of a request to d,
+// when the handler gives up without responding it answers
+// - 504 if the deadline elapsed
+// - 503 if the request was canceled (client gone or server shutting down)
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			// the handler already answered
+			if ww.Status() != 0 {
+				return
+			}
+
+			switch err := ctx.Err(); {
+			case errors.Is(err, context.DeadlineExceeded):
+				response.Error(w, http.StatusGatewayTimeout, internal.ErrorRequestTimeout.Error())
+			case errors.Is(err, context.Canceled):
+				response.Error(w, http.StatusServiceUnavailable, internal.ErrorRequestCanceled.Error())
+			}
+		})
+	}
+}
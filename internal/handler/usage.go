@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"app/internal"
+	"net/http"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// ClientUsageJSON is a struct that represents the usage of a client in JSON format
+type ClientUsageJSON struct {
+	Client         string         `json:"client"`
+	Day            string         `json:"day"`
+	Requests       map[string]int `json:"requests"`
+	Throttled      map[string]int `json:"throttled"`
+	QuotaRejected  map[string]int `json:"quota_rejected"`
+	QuotaRemaining map[string]int `json:"quota_remaining,omitempty"`
+}
+
+// JSON is a method that returns a ClientUsageJSON from a ClientUsage
+func (u *ClientUsageJSON) JSON(usage internal.ClientUsage) ClientUsageJSON {
+	u.Client = usage.Client
+	u.Day = usage.Day
+	u.Requests = usage.Requests
+	u.Throttled = usage.Throttled
+	u.QuotaRejected = usage.QuotaRejected
+	u.QuotaRemaining = usage.QuotaRemaining
+
+	return *u
+}
+
+// NewUsageDefault is a function that returns a new instance of UsageDefault
+func NewUsageDefault(rl internal.RateLimiter) *UsageDefault {
+	return &UsageDefault{rl: rl}
+}
+
+// UsageDefault is a struct with methods that represent handlers for the usage of the clients
+type UsageDefault struct {
+	// rl is the rate limiter counting the requests
+	rl internal.RateLimiter
+}
+
+// GetUsage is a method that returns a handler for the route GET /admin/usage
+func (h *UsageDefault) GetUsage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		usage := h.rl.Usage()
+
+		data := make([]ClientUsageJSON, 0, len(usage))
+		for _, u := range usage {
+			data = append(data, (&ClientUsageJSON{}).JSON(u))
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    data,
+		})
+	}
+}
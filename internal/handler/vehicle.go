@@ -3,13 +3,12 @@ package handler
 import (
 	"app/internal"
 	"app/internal/tools"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
-	"strings"
 
 	"github.com/bootcamp-go/web/response"
 	"github.com/go-chi/chi/v5"
@@ -43,8 +42,8 @@ func (v *VehicleJSON) JSON(vehicle internal.Vehicle) VehicleJSON {
 	v.FabricationYear = vehicle.FabricationYear
 	v.Capacity = vehicle.Capacity
 	v.MaxSpeed = vehicle.MaxSpeed
-	v.FuelType = vehicle.FuelType
-	v.Transmission = vehicle.Transmission
+	v.FuelType = string(vehicle.FuelType)
+	v.Transmission = string(vehicle.Transmission)
 	v.Weight = vehicle.Weight
 	v.Height = vehicle.Height
 	v.Length = vehicle.Length
@@ -67,27 +66,26 @@ type VehicleDefault struct {
 // GetAll is a method that returns a handler for the route GET /vehicles
 func (h *VehicleDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// request
-		// ...
+		// request: validated against the OpenAPI document
+		fields, err := NewVehicleFields(tools.ParamsFromContext(r.Context()).Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		// process
 		// - get all vehicles
-		v, err := h.sv.FindAll()
+		v, err := h.sv.FindAllContext(r.Context())
 		if err != nil {
-			response.JSON(w, http.StatusInternalServerError, nil)
+			writeError(w, err)
 			return
 		}
 
-		// Prepare response in JSON format
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = (&VehicleJSON{}).JSON(value)
-		}
-
+		// Prepare response in JSON format: only the fields requested
 		response.JSON(w, http.StatusOK, map[string]any{
-			"count":   len(data),
+			"count":   len(v),
 			"message": "Success",
-			"data":    data,
+			"data":    fields.Vehicles(v),
 		})
 	}
 }
@@ -95,25 +93,27 @@ func (h *VehicleDefault) GetAll() http.HandlerFunc {
 // GetByID is a method that returns a handler for the route GET /vehicles/{id}
 func (h *VehicleDefault) GetByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		// request
-		id, err := strconv.Atoi(chi.URLParam(r, "id"))
-		if err != nil || id < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidID.Error())
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		id := params.Int("id")
+		fields, err := NewVehicleFields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
 		// process
 		// - get vehicle by id
-		v, err := h.sv.FindByID(id)
+		v, err := h.sv.FindByIDContext(r.Context(), id)
 		if err != nil {
-			response.Error(w, http.StatusNotFound, internal.ErrorVehicleNotFound.Error())
+			writeError(w, err)
 			return
 		}
 
 		// response
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "Success",
-			"data":    (&VehicleJSON{}).JSON(v),
+			"data":    fields.Vehicle(v),
 		})
 	}
 }
@@ -130,23 +130,7 @@ func (h *VehicleDefault) Create() http.HandlerFunc {
 			return
 		}
 
-		// parse body to map to check required fields
-		bodyMap := map[string]any{}
-		if err := json.Unmarshal(bytes, &bodyMap); err != nil {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
-
-			return
-		}
-
-		if err := tools.CheckFieldExistance(bodyMap, "brand", "model", "registration", "color", "year"); err != nil {
-			var fieldError *tools.FieldError
-			if errors.As(err, &fieldError) {
-				response.Error(w, http.StatusBadRequest, fmt.Sprintf("%s is required", fieldError.Field))
-				return
-			}
-		}
-
-		// unmarshal bytes into VehicleJSON
+		// unmarshal bytes into VehicleJSON: the required fields were validated against the OpenAPI document
 		var v VehicleJSON
 		if err := json.Unmarshal(bytes, &v); err != nil {
 			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
@@ -169,29 +153,19 @@ func (h *VehicleDefault) Create() http.HandlerFunc {
 			FabricationYear: v.FabricationYear,
 			Capacity:        v.Capacity,
 			MaxSpeed:        v.MaxSpeed,
-			FuelType:        v.FuelType,
-			Transmission:    v.Transmission,
+			FuelType:        internal.FuelType(v.FuelType),
+			Transmission:    internal.Transmission(v.Transmission),
 			Weight:          v.Weight,
 			Dimensions:      vehicleDimension,
 		}
 
-		if v.ID < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorParseID.Error())
-			return
-		}
-
 		vehicle := internal.Vehicle{
 			Id:                v.ID,
 			VehicleAttributes: vehicleAttributes,
 		}
 
-		if err := h.sv.Create(&vehicle); err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleAlreadyExists):
-				response.Error(w, http.StatusConflict, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+		if err := h.sv.CreateContext(r.Context(), &vehicle); err != nil {
+			writeError(w, err)
 
 			return
 		}
@@ -210,44 +184,27 @@ func (h *VehicleDefault) Create() http.HandlerFunc {
 // GetByColorAndYear is a method that returns a handler for the route GET /vehicles/color/{color}/year/{year}
 func (h *VehicleDefault) GetByColorAndYear() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		color := chi.URLParam(r, "color")
-		year := chi.URLParam(r, "year")
-
-		if color == "" || year == "" {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidColorAndYear.Error())
-
-			return
-		}
-
-		fabricationYear, err := strconv.Atoi(year)
-		if err != nil || fabricationYear < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidYear.Error())
-
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		color, fabricationYear := params.String("color"), params.Int("year")
+		fields, err := NewVehicleFields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		v, err := h.sv.FindByColorAndYear(color, fabricationYear)
+		v, err := h.sv.FindByColorAndYearContext(r.Context(), color, fabricationYear)
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+			writeError(w, err)
 
 			return
 		}
 
-		// Prepare response in JSON format
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = (&VehicleJSON{}).JSON(value)
-		}
-
+		// Prepare response in JSON format: only the fields requested
 		response.JSON(w, http.StatusOK, map[string]any{
-			"count":   len(data),
+			"count":   len(v),
 			"message": "Success",
-			"data":    data,
+			"data":    fields.Vehicles(v),
 		})
 
 	}
@@ -257,49 +214,27 @@ func (h *VehicleDefault) GetByColorAndYear() http.HandlerFunc {
 // GetByBrandAndRangeYear is a method that returns a handler for the route GET /vehicles/brand/{brand}/between/{start_year}/{end_year}
 func (h *VehicleDefault) GetByBrandAndRangeYear() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		brand := chi.URLParam(r, "brand")
-		startYear := chi.URLParam(r, "start_year")
-		endYear := chi.URLParam(r, "end_year")
-
-		if brand == "" || startYear == "" || endYear == "" {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBrandAndRangeYear.Error())
-			return
-		}
-
-		startYearInt, err := strconv.Atoi(startYear)
-		if err != nil || startYearInt < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidYear.Error())
-			return
-		}
-
-		endYearInt, err := strconv.Atoi(endYear)
-		if err != nil || endYearInt < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidYear.Error())
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		brand, startYear, endYear := params.String("brand"), params.Int("start_year"), params.Int("end_year")
+		fields, err := NewVehicleFields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		v, err := h.sv.FindByBrandAndRangeYear(brand, startYearInt, endYearInt)
+		v, err := h.sv.FindByBrandAndRangeYearContext(r.Context(), brand, startYear, endYear)
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+			writeError(w, err)
 
 			return
 		}
 
-		// Prepare response in JSON format
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = (&VehicleJSON{}).JSON(value)
-		}
-
+		// Prepare response in JSON format: only the fields requested
 		response.JSON(w, http.StatusOK, map[string]any{
-			"count":   len(data),
+			"count":   len(v),
 			"message": "Success",
-			"data":    data,
+			"data":    fields.Vehicles(v),
 		})
 
 	}
@@ -317,14 +252,9 @@ func (h *VehicleDefault) GetAverageSpeedByBrand() http.HandlerFunc {
 			return
 		}
 
-		averageSpeed, err := h.sv.FindAverageSpeedByBrand(brand)
+		averageSpeed, err := h.sv.FindAverageSpeedByBrandContext(r.Context(), brand)
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+			writeError(w, err)
 
 			return
 		}
@@ -362,12 +292,6 @@ func (h *VehicleDefault) CreateBatch() http.HandlerFunc {
 		var vehicles []internal.Vehicle
 
 		for _, v := range req.Vehicles {
-			if v.ID < 0 {
-				response.Error(w, http.StatusBadRequest, internal.ErrorParseID.Error())
-
-				return
-			}
-
 			// parse req to map to check required fields
 			/*
 				reqMap := map[string]any{}
@@ -391,6 +315,10 @@ func (h *VehicleDefault) CreateBatch() http.HandlerFunc {
 					Model:           v.Model,
 					FabricationYear: v.FabricationYear,
 					Color:           v.Color,
+					Capacity:        v.Capacity,
+					MaxSpeed:        v.MaxSpeed,
+					FuelType:        internal.FuelType(v.FuelType),
+					Transmission:    internal.Transmission(v.Transmission),
 					Weight:          v.Weight,
 					Dimensions: internal.Dimensions{
 						Height: v.Height,
@@ -404,13 +332,8 @@ func (h *VehicleDefault) CreateBatch() http.HandlerFunc {
 			vehicles = append(vehicles, vehicle)
 		}
 
-		if err := h.sv.CreateBatch(vehicles); err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleAlreadyExists):
-				response.Error(w, http.StatusConflict, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+		if err := h.sv.CreateBatchContext(r.Context(), vehicles); err != nil {
+			writeError(w, err)
 
 			return
 		}
@@ -428,19 +351,8 @@ func (h *VehicleDefault) CreateBatch() http.HandlerFunc {
 // UpdateMaxSpeed is a method that returns a handler for the route PATCH /vehicles/{id}/update-speed
 func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id := chi.URLParam(r, "id")
-		if id == "" {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidID.Error())
-
-			return
-		}
-
-		idInt, err := strconv.Atoi(id)
-		if err != nil || idInt < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorParseID.Error())
-
-			return
-		}
+		// request: validated against the OpenAPI document
+		idInt := tools.ParamsFromContext(r.Context()).Int("id")
 
 		var req struct {
 			MaxSpeed float64 `json:"max_speed"`
@@ -458,15 +370,8 @@ func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
 			return
 		}
 
-		if err := h.sv.UpdateMaxSpeed(idInt, req.MaxSpeed); err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			case errors.Is(err, internal.ErrorInvalidMaxSpeedRange):
-				response.Error(w, http.StatusBadRequest, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+		if err := h.sv.UpdateMaxSpeedContext(r.Context(), idInt, req.MaxSpeed); err != nil {
+			writeError(w, err)
 
 			return
 		}
@@ -490,28 +395,24 @@ func (h *VehicleDefault) GetByFuelType() http.HandlerFunc {
 			return
 		}
 
-		v, err := h.sv.FindByFuelType(fuelType)
+		fields, err := NewVehicleFields(tools.ParamsFromContext(r.Context()).Strings(ParamFields))
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
-
+			response.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		// Prepare response in JSON format
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = (&VehicleJSON{}).JSON(value)
+		v, err := h.sv.FindByFuelTypeContext(r.Context(), fuelType)
+		if err != nil {
+			writeError(w, err)
+
+			return
 		}
 
+		// Prepare response in JSON format: only the fields requested
 		response.JSON(w, http.StatusOK, map[string]any{
-			"count":   len(data),
+			"count":   len(v),
 			"message": "Success",
-			"data":    data,
+			"data":    fields.Vehicles(v),
 		})
 	}
 }
@@ -520,27 +421,12 @@ func (h *VehicleDefault) GetByFuelType() http.HandlerFunc {
 // Delete is a method that returns a handler for the route DELETE /vehicles/{id}
 func (h *VehicleDefault) Delete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id := chi.URLParam(r, "id")
-		if id == "" {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidID.Error())
+		// request: validated against the OpenAPI document
+		idVehicle := tools.ParamsFromContext(r.Context()).Int("id")
 
-			return
-		}
-
-		idVehicle, err := strconv.Atoi(id)
-		if err != nil || idVehicle < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorParseID.Error())
-			return
-		}
-
-		err = h.sv.Delete(idVehicle)
+		err := h.sv.DeleteContext(r.Context(), idVehicle)
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+			writeError(w, err)
 
 			return
 		}
@@ -560,28 +446,24 @@ func (h *VehicleDefault) GetByTransmissionType() http.HandlerFunc {
 			return
 		}
 
-		v, err := h.sv.FindByTransmissionType(transmissionType)
+		fields, err := NewVehicleFields(tools.ParamsFromContext(r.Context()).Strings(ParamFields))
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
-
+			response.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		// Prepare response in JSON format
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = (&VehicleJSON{}).JSON(value)
+		v, err := h.sv.FindByTransmissionTypeContext(r.Context(), transmissionType)
+		if err != nil {
+			writeError(w, err)
+
+			return
 		}
 
+		// Prepare response in JSON format: only the fields requested
 		response.JSON(w, http.StatusOK, map[string]any{
-			"count":   len(data),
+			"count":   len(v),
 			"message": "Success",
-			"data":    data,
+			"data":    fields.Vehicles(v),
 		})
 	}
 }
@@ -590,19 +472,8 @@ func (h *VehicleDefault) GetByTransmissionType() http.HandlerFunc {
 // UpdateFuelType is a method that returns a handler for the route PATCH /vehicles/{id}/update-fuel
 func (h *VehicleDefault) UpdateFuelType() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id := chi.URLParam(r, "id")
-		if id == "" {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidID.Error())
-
-			return
-		}
-
-		idInt, err := strconv.Atoi(id)
-		if err != nil || idInt < 0 {
-			response.Error(w, http.StatusBadRequest, internal.ErrorParseID.Error())
-
-			return
-		}
+		// request: validated against the OpenAPI document
+		idInt := tools.ParamsFromContext(r.Context()).Int("id")
 
 		var req struct {
 			FuelType string `json:"fuel_type"`
@@ -620,15 +491,8 @@ func (h *VehicleDefault) UpdateFuelType() http.HandlerFunc {
 			return
 		}
 
-		if err := h.sv.UpdateFuelType(idInt, req.FuelType); err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			case errors.Is(err, internal.ErrorInvalidFuelTypeUpdate):
-				response.Error(w, http.StatusBadRequest, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+		if err := h.sv.UpdateFuelTypeContext(r.Context(), idInt, req.FuelType); err != nil {
+			writeError(w, err)
 
 			return
 		}
@@ -652,14 +516,9 @@ func (h *VehicleDefault) GetAverageCapacityByBrand() http.HandlerFunc {
 			return
 		}
 
-		averageCapacity, err := h.sv.FindAverageCapacityByBrand(brand)
+		averageCapacity, err := h.sv.FindAverageCapacityByBrandContext(r.Context(), brand)
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+			writeError(w, err)
 
 			return
 		}
@@ -678,69 +537,27 @@ func (h *VehicleDefault) GetAverageCapacityByBrand() http.HandlerFunc {
 // GetByDimensions is a method that returns a handler for the route GET /vehicles/dimensions?height={height}&width={width}
 func (h *VehicleDefault) GetByDimensions() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		height := r.URL.Query().Get("height")
-		width := r.URL.Query().Get("width")
-
-		if height == "" || width == "" {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidHeightAndWidth.Error())
-
-			return
-		}
-
-		splitDimension := func(dimension string) (float64, float64, error) {
-			split := strings.Split(dimension, "-")
-			if len(split) != 2 {
-				return 0, 0, internal.ErrorInvalidDimension
-			}
-			min, err := strconv.ParseFloat(split[0], 64)
-			if err != nil {
-				return 0, 0, internal.ErrorInvalidQueryParamFormat
-			}
-			max, err := strconv.ParseFloat(split[1], 64)
-			if err != nil {
-				return 0, 0, internal.ErrorInvalidQueryParamFormat
-			}
-
-			return min, max, nil
-		}
-
-		minHeight, maxHeight, err := splitDimension(height)
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		height, width := params.Range("height"), params.Range("width")
+		fields, err := NewVehicleFields(params.Strings(ParamFields))
 		if err != nil {
 			response.Error(w, http.StatusBadRequest, err.Error())
-
 			return
 		}
 
-		minWidth, maxWidth, err := splitDimension(width)
+		v, err := h.sv.FindByDimensionsContext(r.Context(), height.Min, height.Max, width.Min, width.Max)
 		if err != nil {
-			response.Error(w, http.StatusBadRequest, err.Error())
+			writeError(w, err)
 
 			return
 		}
 
-		v, err := h.sv.FindByDimensions(minHeight, maxHeight, minWidth, maxWidth)
-		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
-
-			return
-		}
-
-		// Prepare response in JSON format
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = (&VehicleJSON{}).JSON(value)
-		}
-
+		// Prepare response in JSON format: only the fields requested
 		response.JSON(w, http.StatusOK, map[string]any{
-			"count":   len(data),
+			"count":   len(v),
 			"message": "Success",
-			"data":    data,
+			"data":    fields.Vehicles(v),
 		})
 	}
 }
@@ -749,53 +566,58 @@ func (h *VehicleDefault) GetByDimensions() http.HandlerFunc {
 // GetByWeightRange is a method that returns a handler for the route GET /vehicles/weight?min={weight_min}&max={weight_max}
 func (h *VehicleDefault) GetByWeightRange() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		minWeight := r.URL.Query().Get("min")
-		maxWeight := r.URL.Query().Get("max")
-
-		if minWeight == "" || maxWeight == "" {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidWeightRange.Error())
-
-			return
-		}
-
-		minWeightFloat, err := strconv.ParseFloat(minWeight, 64)
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		minWeight, maxWeight := params.Float("min"), params.Float("max")
+		fields, err := NewVehicleFields(params.Strings(ParamFields))
 		if err != nil {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidQueryParamFormat.Error())
-
-			return
-		}
-
-		maxWeightFloat, err := strconv.ParseFloat(maxWeight, 64)
-		if err != nil {
-			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidQueryParamFormat.Error())
-
+			response.Error(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		v, err := h.sv.FindByWeightRange(minWeightFloat, maxWeightFloat)
+		v, err := h.sv.FindByWeightRangeContext(r.Context(), minWeight, maxWeight)
 		if err != nil {
-			switch {
-			case errors.Is(err, internal.ErrorVehicleNotFound):
-				response.Error(w, http.StatusNotFound, err.Error())
-
-			default:
-				response.Error(w, http.StatusInternalServerError, internal.ErrorInternalServer.Error())
-			}
+			writeError(w, err)
 
 			return
 		}
 
-		// Prepare response in JSON format
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = (&VehicleJSON{}).JSON(value)
-		}
-
+		// Prepare response in JSON format: only the fields requested
 		response.JSON(w, http.StatusOK, map[string]any{
-			"count":   len(data),
+			"count":   len(v),
 			"message": "Success",
-			"data":    data,
+			"data":    fields.Vehicles(v),
 		})
 
 	}
 }
+
+// ErrorStatus is a function that returns the status code and the message of the response of an error
+// of the service, the errors that are not the caller's are answered without their details
+func ErrorStatus(err error) (code int, message string) {
+	switch {
+	case errors.Is(err, internal.ErrorVehicleNotFound):
+		code, message = http.StatusNotFound, err.Error()
+	case errors.Is(err, internal.ErrorVehicleAlreadyExists):
+		code, message = http.StatusConflict, err.Error()
+	case errors.Is(err, internal.ErrorInvalidMaxSpeedRange), errors.Is(err, internal.ErrorInvalidFuelTypeUpdate),
+		errors.Is(err, internal.ErrorInvalidFuelType), errors.Is(err, internal.ErrorInvalidTransmissionType),
+		errors.Is(err, internal.ErrorInvalidTransmissionValue), errors.Is(err, internal.ErrorVehicleNotInCatalog),
+		errors.Is(err, internal.ErrorInvalidRegistration), errors.Is(err, internal.ErrorInvalidVIN),
+		errors.Is(err, internal.ErrorVINMismatch):
+		code, message = http.StatusBadRequest, err.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		code, message = http.StatusGatewayTimeout, internal.ErrorRequestTimeout.Error()
+	case errors.Is(err, context.Canceled):
+		code, message = http.StatusServiceUnavailable, internal.ErrorRequestCanceled.Error()
+	default:
+		code, message = http.StatusInternalServerError, internal.ErrorInternalServer.Error()
+	}
+	return
+}
+
+// writeError is a function that writes the error of the service as the response of its status code
+func writeError(w http.ResponseWriter, err error) {
+	code, message := ErrorStatus(err)
+	response.Error(w, code, message)
+}
@@ -0,0 +1,44 @@
+package handler_test
+
+import (
+	"app/internal"
+	"app/internal/handler"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestErrorStatus(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		code    int
+		message string
+	}{
+		{name: "not found", err: internal.ErrorVehicleNotFound, code: http.StatusNotFound, message: internal.ErrorVehicleNotFound.Error()},
+		{name: "already exists", err: fmt.Errorf("%w: id 1", internal.ErrorVehicleAlreadyExists), code: http.StatusConflict, message: "Vehicle already exists: id 1"},
+		{name: "max speed range", err: internal.ErrorInvalidMaxSpeedRange, code: http.StatusBadRequest, message: internal.ErrorInvalidMaxSpeedRange.Error()},
+		{name: "fuel type update", err: internal.ErrorInvalidFuelTypeUpdate, code: http.StatusBadRequest, message: internal.ErrorInvalidFuelTypeUpdate.Error()},
+		{name: "fuel type", err: internal.ErrorInvalidFuelType, code: http.StatusBadRequest, message: internal.ErrorInvalidFuelType.Error()},
+		{name: "transmission type", err: internal.ErrorInvalidTransmissionType, code: http.StatusBadRequest, message: internal.ErrorInvalidTransmissionType.Error()},
+		{name: "transmission value", err: internal.ErrorInvalidTransmissionValue, code: http.StatusBadRequest, message: internal.ErrorInvalidTransmissionValue.Error()},
+		{name: "not in catalog", err: internal.ErrorVehicleNotInCatalog, code: http.StatusBadRequest, message: internal.ErrorVehicleNotInCatalog.Error()},
+		{name: "registration", err: internal.ErrorInvalidRegistration, code: http.StatusBadRequest, message: internal.ErrorInvalidRegistration.Error()},
+		{name: "VIN", err: fmt.Errorf("%w: too short", internal.ErrorInvalidVIN), code: http.StatusBadRequest, message: internal.ErrorInvalidVIN.Error() + ": too short"},
+		{name: "VIN mismatch", err: internal.ErrorVINMismatch, code: http.StatusBadRequest, message: internal.ErrorVINMismatch.Error()},
+		{name: "deadline", err: fmt.Errorf("find: %w", context.DeadlineExceeded), code: http.StatusGatewayTimeout, message: internal.ErrorRequestTimeout.Error()},
+		{name: "canceled", err: context.Canceled, code: http.StatusServiceUnavailable, message: internal.ErrorRequestCanceled.Error()},
+		{name: "anything else, without its details", err: errors.New("disk on fire"), code: http.StatusInternalServerError, message: internal.ErrorInternalServer.Error()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			code, message := handler.ErrorStatus(c.err)
+			if code != c.code || message != c.message {
+				t.Errorf("ErrorStatus(%v) = %d %q, want %d %q", c.err, code, message, c.code, c.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,606 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/tools"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// Paths of the vehicles in each version of the API
+const (
+	// PathVehicles is the unversioned path of the vehicles, an alias of PathVehiclesV1
+	PathVehicles = "/vehicles"
+	// PathVehiclesV1 is the path of the vehicles in the version 1 of the API
+	PathVehiclesV1 = "/v1/vehicles"
+	// PathVehiclesV2 is the path of the vehicles in the version 2 of the API
+	PathVehiclesV2 = "/v2/vehicles"
+)
+
+// Pagination of the lists of the version 2 of the API
+const (
+	// ParamPage is the query parameter with the page of a list, from 1
+	ParamPage = "page"
+	// ParamPerPage is the query parameter with the size of the pages of a list
+	ParamPerPage = "per_page"
+	// DefaultPerPage is the size of the pages when it is not given
+	DefaultPerPage = 20
+	// MaxPerPage is the largest size of the pages
+	MaxPerPage = 100
+)
+
+// PageJSON is a struct that represents the pagination metadata of a list in JSON format
+type PageJSON struct {
+	Count   int `json:"count"`
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+	Pages   int `json:"pages"`
+}
+
+// HitJSON is a struct that represents a vehicle found by a text search in JSON format
+type HitJSON struct {
+	Score   float64 `json:"score"`
+	Vehicle RawJSON `json:"vehicle"`
+}
+
+// EnumsJSON is a struct that represents the values allowed for the enumerated fields of the vehicles in JSON format
+type EnumsJSON struct {
+	FuelTypes     []internal.FuelType     `json:"fuel_types"`
+	Transmissions []internal.Transmission `json:"transmissions"`
+}
+
+// DuplicateJSON is a struct that represents a registration shared by several vehicles in JSON format
+type DuplicateJSON struct {
+	Registration string `json:"registration"`
+	IDs          []int  `json:"ids"`
+}
+
+// StatsJSON is a struct that represents the averages of the vehicles of a brand in JSON format
+type StatsJSON struct {
+	Brand           string  `json:"brand"`
+	AverageSpeed    float64 `json:"average_speed"`
+	AverageCapacity float64 `json:"average_capacity"`
+}
+
+// VehicleV2JSON is a struct that represents a vehicle in JSON format in the version 2, the VehicleJSON
+// of the version 1 and the fields added since
+type VehicleV2JSON struct {
+	VehicleJSON
+	VIN string `json:"vin"`
+}
+
+// JSON is a method that returns a VehicleV2JSON from a Vehicle
+func (v *VehicleV2JSON) JSON(vehicle internal.Vehicle) VehicleV2JSON {
+	v.VehicleJSON.JSON(vehicle)
+	v.VIN = vehicle.VIN
+
+	return *v
+}
+
+// Vehicle is a method that returns the Vehicle described by a VehicleV2JSON
+func (v VehicleV2JSON) Vehicle() internal.Vehicle {
+	return internal.Vehicle{
+		Id: v.ID,
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           v.Brand,
+			Model:           v.Model,
+			Registration:    v.Registration,
+			VIN:             v.VIN,
+			Color:           v.Color,
+			FabricationYear: v.FabricationYear,
+			Capacity:        v.Capacity,
+			MaxSpeed:        v.MaxSpeed,
+			FuelType:        internal.FuelType(v.FuelType),
+			Transmission:    internal.Transmission(v.Transmission),
+			Weight:          v.Weight,
+			Dimensions: internal.Dimensions{
+				Height: v.Height,
+				Length: v.Length,
+				Width:  v.Width,
+			},
+		},
+	}
+}
+
+// NewVehicleV2 is a function that returns a new instance of VehicleV2
+func NewVehicleV2(sv internal.VehicleService, vc internal.Vocabulary) *VehicleV2 {
+	return &VehicleV2{sv: sv, vc: vc}
+}
+
+// VehicleV2 is a struct with methods that represent the handlers of the version 2 of the vehicles API:
+// the same service as VehicleDefault behind lists as arrays with pagination metadata, filters as query
+// parameters and the same envelope for every response, {message, data[, meta]}
+type VehicleV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.VehicleService
+	// vc is the vocabulary of the vehicles written by the service
+	vc internal.Vocabulary
+}
+
+// List is a method that returns a handler for the route GET /v2/vehicles, the vehicles matching every
+// filter of the query sorted by ID. color goes with year, year_from and year_to with brand and height with width,
+// body_type and country match the catalog entries the vehicles are linked to
+func (h *VehicleV2) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		fields, err := NewVehicleV2Fields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		finders, err := h.finders(r.Context(), params)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// process
+		// - intersection of the vehicles found, no vehicle found is an empty list rather than an error
+		var found map[int]internal.Vehicle
+		for _, find := range finders {
+			v, err := find()
+			if errors.Is(err, internal.ErrorVehicleNotFound) {
+				found = map[int]internal.Vehicle{}
+				break
+			}
+			if err != nil {
+				writeErrorV2(w, err)
+				return
+			}
+			if found == nil {
+				found = v
+				continue
+			}
+			for id := range found {
+				if _, ok := v[id]; !ok {
+					delete(found, id)
+				}
+			}
+		}
+
+		// - page of the vehicles sorted by ID
+		list := make([]internal.Vehicle, 0, len(found))
+		for _, v := range found {
+			list = append(list, v)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+
+		page := PageJSON{Total: len(list), Page: params.Int(ParamPage), PerPage: params.Int(ParamPerPage)}
+		if page.Page == 0 {
+			page.Page = 1
+		}
+		if page.PerPage == 0 {
+			page.PerPage = DefaultPerPage
+		}
+		page.Pages = (page.Total + page.PerPage - 1) / page.PerPage
+		// - the offset is only computed when it is inside the list so a huge page does not overflow
+		start := len(list)
+		if page.Page-1 <= len(list)/page.PerPage {
+			start = min((page.Page-1)*page.PerPage, len(list))
+		}
+		end := min(start+page.PerPage, len(list))
+		list = list[start:end]
+		page.Count = len(list)
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    fields.List(list),
+			"meta":    page,
+		})
+	}
+}
+
+// finders is a method that returns a finder of the service for each group of filters of a list,
+// FindAll when there is no filter
+func (h *VehicleV2) finders(ctx context.Context, params tools.Params) (finders []func() (map[int]internal.Vehicle, error), err error) {
+	if params.Has("color") || params.Has("year") {
+		if !params.Has("color") || !params.Has("year") {
+			err = internal.ErrorInvalidColorAndYear
+			return
+		}
+		color, year := params.String("color"), params.Int("year")
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindByColorAndYearContext(ctx, color, year)
+		})
+	}
+	if params.Has("brand") || params.Has("year_from") || params.Has("year_to") {
+		if !params.Has("brand") {
+			err = internal.ErrorInvalidBrandAndRangeYear
+			return
+		}
+		brand, startYear, endYear := params.String("brand"), params.Int("year_from"), math.MaxInt
+		if params.Has("year_to") {
+			endYear = params.Int("year_to")
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindByBrandAndRangeYearContext(ctx, brand, startYear, endYear)
+		})
+	}
+	if params.Has("fuel_type") {
+		fuelType := params.String("fuel_type")
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindByFuelTypeContext(ctx, fuelType)
+		})
+	}
+	if params.Has("transmission") {
+		transmission := params.String("transmission")
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindByTransmissionTypeContext(ctx, transmission)
+		})
+	}
+	if params.Has("height") || params.Has("width") {
+		if !params.Has("height") || !params.Has("width") {
+			err = internal.ErrorInvalidHeightAndWidth
+			return
+		}
+		height, width := params.Range("height"), params.Range("width")
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindByDimensionsContext(ctx, height.Min, height.Max, width.Min, width.Max)
+		})
+	}
+	if params.Has("body_type") || params.Has("country") {
+		filter := internal.CatalogFilter{BodyType: internal.BodyType(params.String("body_type")), Country: params.String("country")}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindByCatalogContext(ctx, filter)
+		})
+	}
+	if params.Has("weight") {
+		weight := params.Range("weight")
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindByWeightRangeContext(ctx, weight.Min, weight.Max)
+		})
+	}
+	if len(finders) == 0 {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return h.sv.FindAllContext(ctx)
+		})
+	}
+	return
+}
+
+// Get is a method that returns a handler for the route GET /v2/vehicles/{id}
+func (h *VehicleV2) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		fields, err := NewVehicleV2Fields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// process
+		v, err := h.sv.FindByIDContext(r.Context(), params.Int("id"))
+		if err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    fields.Vehicle(v),
+		})
+	}
+}
+
+// Create is a method that returns a handler for the route POST /v2/vehicles
+func (h *VehicleV2) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: the required fields were validated against the OpenAPI document
+		var body VehicleV2JSON
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+
+		// process
+		v := body.Vehicle()
+		if err := h.sv.CreateContext(r.Context(), &v); err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		w.Header().Set("Location", fmt.Sprintf("%s/%d", PathVehiclesV2, v.Id))
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "Success",
+			"data":    (&VehicleV2JSON{}).JSON(v),
+		})
+	}
+}
+
+// CreateBatch is a method that returns a handler for the route POST /v2/vehicles/batch,
+// none of the vehicles is created if any fails
+func (h *VehicleV2) CreateBatch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: the required fields were validated against the OpenAPI document
+		var body struct {
+			Vehicles []VehicleV2JSON `json:"vehicles"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+		if len(body.Vehicles) == 0 {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidVehicles.Error())
+			return
+		}
+
+		// process
+		vehicles := make([]internal.Vehicle, 0, len(body.Vehicles))
+		for _, v := range body.Vehicles {
+			vehicles = append(vehicles, v.Vehicle())
+		}
+		if err := h.sv.CreateBatchContext(r.Context(), vehicles); err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		data := make([]VehicleV2JSON, 0, len(vehicles))
+		for _, v := range vehicles {
+			data = append(data, (&VehicleV2JSON{}).JSON(v))
+		}
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "Success",
+			"data":    data,
+			"meta":    map[string]any{"count": len(data)},
+		})
+	}
+}
+
+// Update is a method that returns a handler for the route PATCH /v2/vehicles/{id}, the fields
+// of the body are updated and the vehicle is returned as updated. The fuel type is checked against the
+// vocabulary before any field is written, so a rejected body leaves the vehicle as it was
+func (h *VehicleV2) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		id := tools.ParamsFromContext(r.Context()).Int("id")
+
+		var body struct {
+			MaxSpeed *float64 `json:"max_speed"`
+			FuelType *string  `json:"fuel_type"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+		if body.MaxSpeed == nil && body.FuelType == nil {
+			response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+			return
+		}
+
+		if body.FuelType != nil && h.vc != nil {
+			if err := h.vc.CheckFuelType(internal.FuelType(*body.FuelType)); err != nil {
+				writeErrorV2(w, err)
+				return
+			}
+		}
+
+		// process
+		if body.MaxSpeed != nil {
+			if err := h.sv.UpdateMaxSpeedContext(r.Context(), id, *body.MaxSpeed); err != nil {
+				writeErrorV2(w, err)
+				return
+			}
+		}
+		if body.FuelType != nil {
+			if err := h.sv.UpdateFuelTypeContext(r.Context(), id, *body.FuelType); err != nil {
+				writeErrorV2(w, err)
+				return
+			}
+		}
+		v, err := h.sv.FindByIDContext(r.Context(), id)
+		if err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    (&VehicleV2JSON{}).JSON(v),
+		})
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /v2/vehicles/{id}, answered without body
+func (h *VehicleV2) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		id := tools.ParamsFromContext(r.Context()).Int("id")
+
+		// process
+		if err := h.sv.DeleteContext(r.Context(), id); err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Search is a method that returns a handler for the route GET /v2/vehicles/search/text?q={query}, the vehicles
+// matching the words of the query by brand, model, color and registration, the most relevant first
+func (h *VehicleV2) Search() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		fields, err := NewVehicleV2Fields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// process
+		hits, err := h.sv.SearchContext(r.Context(), params.String("q"), params.Int("limit"))
+		if err != nil {
+			if errors.Is(err, internal.ErrorInvalidSearchQuery) {
+				response.Error(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		data := make([]HitJSON, 0, len(hits))
+		for _, hit := range hits {
+			data = append(data, HitJSON{Score: hit.Score, Vehicle: fields.Vehicle(hit.Vehicle)})
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    data,
+			"meta":    map[string]any{"count": len(data)},
+		})
+	}
+}
+
+// GetByRegistration is a method that returns a handler for the route GET /v2/vehicles/registration/{plate},
+// the vehicles whose registration has the normalized form of the plate sorted by ID, several only when the
+// loaded dataset has duplicates
+func (h *VehicleV2) GetByRegistration() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		fields, err := NewVehicleV2Fields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// process
+		found, err := h.sv.FindByRegistrationContext(r.Context(), params.String("plate"))
+		if err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		list := make([]internal.Vehicle, 0, len(found))
+		for _, v := range found {
+			list = append(list, v)
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    fields.List(list),
+			"meta":    map[string]any{"count": len(list)},
+		})
+	}
+}
+
+// GetByVIN is a method that returns a handler for the route GET /v2/vehicles/vin/{vin}, the vehicle whose VIN
+// has the normalized form of the VIN
+func (h *VehicleV2) GetByVIN() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		params := tools.ParamsFromContext(r.Context())
+		fields, err := NewVehicleV2Fields(params.Strings(ParamFields))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// process
+		v, err := h.sv.FindByVINContext(r.Context(), params.String("vin"))
+		if err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    fields.Vehicle(v),
+		})
+	}
+}
+
+// GetRegistrationDuplicates is a method that returns a handler for the route GET /v2/vehicles/registrations/duplicates,
+// the registrations shared by several vehicles of the loaded dataset, sorted
+func (h *VehicleV2) GetRegistrationDuplicates() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// process
+		d, err := h.sv.FindRegistrationDuplicatesContext(r.Context())
+		if err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		data := make([]DuplicateJSON, 0, len(d))
+		vehicles := 0
+		for _, value := range d {
+			data = append(data, DuplicateJSON{Registration: value.Registration, IDs: value.IDs})
+			vehicles += len(value.IDs)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    data,
+			"meta":    map[string]any{"count": len(data), "vehicles": vehicles},
+		})
+	}
+}
+
+// GetStats is a method that returns a handler for the route GET /v2/vehicles/stats?brand={brand},
+// the average speed and capacity of the vehicles of a brand
+func (h *VehicleV2) GetStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request: validated against the OpenAPI document
+		brand := tools.ParamsFromContext(r.Context()).String("brand")
+
+		// process
+		stats := StatsJSON{Brand: brand}
+		var err error
+		if stats.AverageSpeed, err = h.sv.FindAverageSpeedByBrandContext(r.Context(), brand); err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+		if stats.AverageCapacity, err = h.sv.FindAverageCapacityByBrandContext(r.Context(), brand); err != nil {
+			writeErrorV2(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    stats,
+		})
+	}
+}
+
+// GetEnums is a method that returns a handler for the route GET /v2/vehicles/meta/enums, the fuel types
+// and transmissions allowed for the vehicles written
+func (h *VehicleV2) GetEnums() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "Success",
+			"data":    EnumsJSON{FuelTypes: h.vc.FuelTypes(), Transmissions: h.vc.Transmissions()},
+		})
+	}
+}
+
+// writeErrorV2 is a function that writes the error of the service as writeError does, the vehicles
+// not found answered without the details of the error
+func writeErrorV2(w http.ResponseWriter, err error) {
+	code, message := ErrorStatus(err)
+	// - the version 2 does not tell which vehicles were not found
+	if code == http.StatusNotFound {
+		message = internal.ErrorVehicleNotFound.Error()
+	}
+	response.Error(w, code, message)
+}
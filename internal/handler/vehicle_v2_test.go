@@ -0,0 +1,75 @@
+package handler_test
+
+import (
+	"app/internal"
+	"app/internal/handler"
+	"app/internal/normalize"
+	"app/internal/repository"
+	"app/internal/service"
+	"app/internal/tools"
+	"app/internal/vocabulary"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVehicleV2_Update(t *testing.T) {
+	cases := []struct {
+		name     string
+		body     string
+		status   int
+		maxSpeed float64
+		fuelType internal.FuelType
+	}{
+		{name: "both fields", body: `{"max_speed":180,"fuel_type":"diesel"}`, status: http.StatusOK, maxSpeed: 180, fuelType: "diesel"},
+		{name: "max speed only", body: `{"max_speed":180}`, status: http.StatusOK, maxSpeed: 180, fuelType: "gasoline"},
+		{name: "fuel type only", body: `{"fuel_type":"electric"}`, status: http.StatusOK, maxSpeed: 200, fuelType: "electric"},
+		{name: "unknown fuel type rejects the max speed", body: `{"max_speed":180,"fuel_type":"steam"}`, status: http.StatusBadRequest, maxSpeed: 200, fuelType: "gasoline"},
+		{name: "max speed out of range rejects the fuel type", body: `{"max_speed":900,"fuel_type":"diesel"}`, status: http.StatusBadRequest, maxSpeed: 200, fuelType: "gasoline"},
+		{name: "no field", body: `{}`, status: http.StatusBadRequest, maxSpeed: 200, fuelType: "gasoline"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			vc, err := vocabulary.NewRegistry(normalize.NewFolding(nil), vocabulary.DefaultFuelTypes, vocabulary.DefaultTransmissions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := internal.WithTenant(context.Background(), internal.DefaultTenant)
+			rp := repository.NewVehicleTenant(nil, vc, nil, nil, nil)
+			v := internal.Vehicle{Id: 1, VehicleAttributes: internal.VehicleAttributes{
+				Brand: "Acura", Model: "MDX", Registration: "A1", Color: "Red", FabricationYear: 2010,
+				MaxSpeed: 200, FuelType: "gasoline", Transmission: "automatic",
+			}}
+			if err := rp.ReplaceAllContext(ctx, map[int]internal.Vehicle{1: v}); err != nil {
+				t.Fatal(err)
+			}
+			sv := service.NewVehicleFeed(service.NewVehicleDefault(rp))
+			subscribed, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			changes := sv.Subscribe(subscribed)
+
+			req := httptest.NewRequest(http.MethodPatch, "/v2/vehicles/1", strings.NewReader(c.body))
+			req = req.WithContext(tools.WithParams(ctx, tools.Params{"id": 1}))
+			res := httptest.NewRecorder()
+			handler.NewVehicleV2(sv, vc).Update()(res, req)
+
+			if res.Code != c.status {
+				t.Fatalf("status = %d, want %d: %s", res.Code, c.status, res.Body)
+			}
+			got, err := rp.FindByIDContext(ctx, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.MaxSpeed != c.maxSpeed || got.FuelType != c.fuelType {
+				t.Errorf("vehicle = %v %s, want %v %s", got.MaxSpeed, got.FuelType, c.maxSpeed, c.fuelType)
+			}
+			// - a rejected body publishes no change
+			if c.status != http.StatusOK && len(changes) != 0 {
+				t.Errorf("changes published = %d, want none", len(changes))
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"os"
+)
+
+// NewCatalogJSONFile is a function that returns a new instance of CatalogJSONFile
+func NewCatalogJSONFile(path string) *CatalogJSONFile {
+	return &CatalogJSONFile{
+		path: path,
+	}
+}
+
+// CatalogJSONFile is a struct that implements the CatalogLoader interface
+type CatalogJSONFile struct {
+	// path is the path to the file that contains the brands in JSON format
+	path string
+}
+
+// CatalogModelJSON is a struct that represents a model of the catalog in JSON format
+type CatalogModelJSON struct {
+	Name     string   `json:"name"`
+	Aliases  []string `json:"aliases"`
+	BodyType string   `json:"body_type"`
+	YearFrom int      `json:"year_from"`
+	YearTo   int      `json:"year_to"`
+}
+
+// CatalogBrandJSON is a struct that represents a brand of the catalog in JSON format
+type CatalogBrandJSON struct {
+	Name    string             `json:"name"`
+	Aliases []string           `json:"aliases"`
+	Country string             `json:"country"`
+	Models  []CatalogModelJSON `json:"models"`
+}
+
+// Load is a method that loads the brands with their models in the order they appear
+func (l *CatalogJSONFile) Load() (b []internal.CatalogBrand, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var brandsJSON []CatalogBrandJSON
+	err = json.NewDecoder(file).Decode(&brandsJSON)
+	if err != nil {
+		return
+	}
+
+	// serialize brands
+	b = make([]internal.CatalogBrand, 0, len(brandsJSON))
+	for _, br := range brandsJSON {
+		brand := internal.CatalogBrand{
+			Name:    br.Name,
+			Aliases: br.Aliases,
+			Country: br.Country,
+			Models:  make([]internal.CatalogModel, 0, len(br.Models)),
+		}
+		for _, m := range br.Models {
+			brand.Models = append(brand.Models, internal.CatalogModel{
+				Name:     m.Name,
+				Aliases:  m.Aliases,
+				BodyType: internal.BodyType(m.BodyType),
+				YearFrom: m.YearFrom,
+				YearTo:   m.YearTo,
+			})
+		}
+		b = append(b, brand)
+	}
+
+	return
+}
@@ -0,0 +1,28 @@
+package loader
+
+import (
+	"app/internal"
+	"fmt"
+	"strings"
+)
+
+// ParseTenantSources is a function that groups "tenant=source" entries by tenant,
+// a tenant may be repeated to merge several sources into its fleet
+func ParseTenantSources(entries []string) (sources map[string][]string, err error) {
+	sources = make(map[string][]string)
+	for _, entry := range entries {
+		tenant, source, ok := strings.Cut(entry, "=")
+		tenant, source = strings.TrimSpace(tenant), strings.TrimSpace(source)
+		if !ok || source == "" {
+			err = fmt.Errorf("%w: %q must be tenant=source", internal.ErrorTenantInvalid, entry)
+			return
+		}
+		if !internal.ValidTenant(tenant) {
+			err = fmt.Errorf("%w: %q", internal.ErrorTenantInvalid, tenant)
+			return
+		}
+
+		sources[tenant] = append(sources[tenant], source)
+	}
+	return
+}
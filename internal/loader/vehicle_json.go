@@ -25,6 +25,7 @@ type VehicleJSON struct {
 	Brand           string  `json:"brand"`
 	Model           string  `json:"model"`
 	Registration    string  `json:"registration"`
+	VIN             string  `json:"vin"`
 	Color           string  `json:"color"`
 	FabricationYear int     `json:"year"`
 	Capacity        int     `json:"passengers"`
@@ -39,6 +40,23 @@ type VehicleJSON struct {
 
 // Load is a method that loads the vehicles
 func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, err error) {
+	// read vehicles
+	vehicles, err := l.read()
+	if err != nil {
+		return
+	}
+
+	// index vehicles by id
+	v = make(map[int]internal.Vehicle)
+	for _, vh := range vehicles {
+		v[vh.Id] = vh
+	}
+
+	return
+}
+
+// read is a method that reads the vehicles of the file in the order they appear
+func (l *VehicleJSONFile) read() (v []internal.Vehicle, err error) {
 	// open file
 	file, err := os.Open(l.path)
 	if err != nil {
@@ -54,20 +72,21 @@ func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, err error) {
 	}
 
 	// serialize vehicles
-	v = make(map[int]internal.Vehicle)
+	v = make([]internal.Vehicle, 0, len(vehiclesJSON))
 	for _, vh := range vehiclesJSON {
-		v[vh.Id] = internal.Vehicle{
+		v = append(v, internal.Vehicle{
 			Id: vh.Id,
 			VehicleAttributes: internal.VehicleAttributes{
 				Brand:           vh.Brand,
 				Model:           vh.Model,
 				Registration:    vh.Registration,
+				VIN:             vh.VIN,
 				Color:           vh.Color,
 				FabricationYear: vh.FabricationYear,
 				Capacity:        vh.Capacity,
 				MaxSpeed:        vh.MaxSpeed,
-				FuelType:        vh.FuelType,
-				Transmission:    vh.Transmission,
+				FuelType:        internal.FuelType(vh.FuelType),
+				Transmission:    internal.Transmission(vh.Transmission),
 				Weight:          vh.Weight,
 				Dimensions: internal.Dimensions{
 					Height: vh.Height,
@@ -75,7 +94,7 @@ func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, err error) {
 					Width:  vh.Width,
 				},
 			},
-		}
+		})
 	}
 
 	return
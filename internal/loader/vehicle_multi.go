@@ -0,0 +1,335 @@
+package loader
+
+import (
+	"app/internal"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// MergePolicy is the policy applied when vehicles of different sources collide by ID or registration
+type MergePolicy string
+
+const (
+	// MergePolicyFirstWins keeps the vehicle that was loaded first and discards the incoming one
+	MergePolicyFirstWins MergePolicy = "first-wins"
+	// MergePolicyLastWins replaces the colliding vehicles with the incoming one
+	MergePolicyLastWins MergePolicy = "last-wins"
+	// MergePolicyFail aborts the load on the first collision
+	MergePolicyFail MergePolicy = "fail"
+	// MergePolicyRenumber assigns a new ID to the incoming vehicle and keeps both
+	MergePolicyRenumber MergePolicy = "renumber"
+)
+
+// ParseMergePolicy is a function that returns the merge policy matching the given name
+func ParseMergePolicy(name string) (p MergePolicy, err error) {
+	switch MergePolicy(name) {
+	case "":
+		p = MergePolicyFirstWins
+	case MergePolicyFirstWins, MergePolicyLastWins, MergePolicyFail, MergePolicyRenumber:
+		p = MergePolicy(name)
+	default:
+		err = fmt.Errorf("%w: %s", internal.ErrorLoaderInvalidPolicy, name)
+	}
+	return
+}
+
+// VehicleProvenance is a struct that represents where a loaded vehicle comes from
+type VehicleProvenance struct {
+	// Source is the file the vehicle was read from
+	Source string
+	// OriginalId is the ID the vehicle had in its source (differs from the final ID when renumbered)
+	OriginalId int
+}
+
+// MergeReport is a struct that represents the outcome of the last load of a VehicleMulti
+type MergeReport struct {
+	// Files are the files that were loaded, in order
+	Files []string
+	// Provenance is the origin of each loaded vehicle indexed by its final ID
+	Provenance map[int]VehicleProvenance
+	// Conflicts are the collisions found between sources
+	Conflicts []internal.VehicleMergeConflict
+}
+
+// NewVehicleMulti is a function that returns a new instance of VehicleMulti
+func NewVehicleMulti(sources []string, policy MergePolicy) *VehicleMulti {
+	// default values
+	if policy == "" {
+		policy = MergePolicyFirstWins
+	}
+
+	return &VehicleMulti{
+		sources: sources,
+		policy:  policy,
+	}
+}
+
+// VehicleMulti is a struct that implements the VehicleMergeLoader interface merging several JSON sources
+type VehicleMulti struct {
+	// sources are file paths, directories (every *.json file inside) or glob patterns
+	sources []string
+	// policy is the policy applied on collisions between sources
+	policy MergePolicy
+	// mu guards report
+	mu sync.RWMutex
+	// report is the outcome of the last successful load
+	report MergeReport
+}
+
+// Report is a method that returns the outcome of the last successful load
+func (l *VehicleMulti) Report() (r MergeReport) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	r = l.report
+	return
+}
+
+// MergeReport is a method that returns the outcome of the last successful load, the provenance summarized
+// as the number of vehicles kept from each file
+func (l *VehicleMulti) MergeReport() (r internal.VehicleMergeReport) {
+	report := l.Report()
+
+	r = internal.VehicleMergeReport{
+		Files:     report.Files,
+		Vehicles:  make(map[string]int, len(report.Files)),
+		Conflicts: report.Conflicts,
+	}
+	for _, file := range report.Files {
+		r.Vehicles[file] = 0
+	}
+	for _, p := range report.Provenance {
+		r.Vehicles[p.Source]++
+	}
+	return
+}
+
+// Load is a method that loads the vehicles of every source and merges them
+func (l *VehicleMulti) Load() (v map[int]internal.Vehicle, err error) {
+	files, err := l.files()
+	if err != nil {
+		return
+	}
+
+	// read every source first so renumbered vehicles get IDs above all the incoming ones
+	sources := make([][]internal.Vehicle, len(files))
+	m := newMerger(l.policy)
+	for i, file := range files {
+		sources[i], err = NewVehicleJSONFile(file).read()
+		if err != nil {
+			err = fmt.Errorf("%s: %w", file, err)
+			return
+		}
+		m.reserve(sources[i])
+	}
+
+	for i, file := range files {
+		err = m.merge(file, sources[i])
+		if err != nil {
+			return
+		}
+	}
+
+	// save report
+	l.mu.Lock()
+	l.report = MergeReport{
+		Files:      files,
+		Provenance: m.provenance,
+		Conflicts:  m.conflicts,
+	}
+	l.mu.Unlock()
+
+	v = m.db
+	return
+}
+
+// files is a method that expands the sources into the list of files to load
+func (l *VehicleMulti) files() (files []string, err error) {
+	if len(l.sources) == 0 {
+		err = internal.ErrorLoaderNoSources
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, source := range l.sources {
+		pattern := source
+		if info, statErr := os.Stat(source); statErr == nil && info.IsDir() {
+			pattern = filepath.Join(source, "*.json")
+		}
+
+		var matches []string
+		matches, err = filepath.Glob(pattern)
+		if err != nil {
+			return
+		}
+		if len(matches) == 0 {
+			err = fmt.Errorf("%w: %s", internal.ErrorLoaderSourceNotFound, source)
+			return
+		}
+
+		sort.Strings(matches)
+		for _, match := range matches {
+			if seen[match] {
+				continue
+			}
+			seen[match] = true
+			files = append(files, match)
+		}
+	}
+
+	return
+}
+
+// newMerger is a function that returns a new instance of merger
+func newMerger(policy MergePolicy) *merger {
+	return &merger{
+		policy:         policy,
+		db:             make(map[int]internal.Vehicle),
+		provenance:     make(map[int]VehicleProvenance),
+		byRegistration: make(map[string][]int),
+	}
+}
+
+// merger is a struct that accumulates the vehicles of several sources
+type merger struct {
+	// policy is the policy applied on collisions
+	policy MergePolicy
+	// db is the merged set of vehicles
+	db map[int]internal.Vehicle
+	// provenance is the origin of each vehicle in db
+	provenance map[int]VehicleProvenance
+	// byRegistration indexes the IDs in db by registration
+	byRegistration map[string][]int
+	// conflicts are the collisions found so far
+	conflicts []internal.VehicleMergeConflict
+	// maxId is the highest ID reserved or assigned so far, renumbered vehicles get the IDs above it
+	maxId int
+}
+
+// reserve is a method that keeps the IDs of incoming vehicles from being given to renumbered ones
+func (m *merger) reserve(vehicles []internal.Vehicle) {
+	for _, vh := range vehicles {
+		if vh.Id > m.maxId {
+			m.maxId = vh.Id
+		}
+	}
+}
+
+// merge is a method that merges the vehicles of a source into the accumulated set
+func (m *merger) merge(source string, vehicles []internal.Vehicle) (err error) {
+	// inside a single source the last record of an ID wins, as in VehicleJSONFile
+	order := make([]int, 0, len(vehicles))
+	bySourceId := make(map[int]internal.Vehicle, len(vehicles))
+	for _, vh := range vehicles {
+		if _, ok := bySourceId[vh.Id]; !ok {
+			order = append(order, vh.Id)
+		}
+		bySourceId[vh.Id] = vh
+	}
+
+	for _, id := range order {
+		vh := bySourceId[id]
+
+		conflicting := m.collisions(source, vh)
+		if len(conflicting) == 0 {
+			m.insert(source, vh, vh.Id)
+			continue
+		}
+
+		conflict := internal.VehicleMergeConflict{
+			Id:             vh.Id,
+			Registration:   vh.Registration,
+			Source:         source,
+			ConflictingIds: conflicting,
+		}
+
+		switch m.policy {
+		case MergePolicyFail:
+			err = fmt.Errorf("%w: vehicle %d from %s collides with vehicle(s) %v from %s",
+				internal.ErrorLoaderConflict, vh.Id, source, conflicting, m.provenance[conflicting[0]].Source)
+			return
+		case MergePolicyLastWins:
+			for _, cid := range conflicting {
+				m.remove(cid)
+			}
+			m.insert(source, vh, vh.Id)
+			conflict.Resolution = "replaced"
+		case MergePolicyRenumber:
+			newId := vh.Id
+			if _, ok := m.db[vh.Id]; ok {
+				newId = m.maxId + 1
+			}
+			m.insert(source, vh, newId)
+			conflict.Resolution = fmt.Sprintf("renumbered to %d", newId)
+		default:
+			conflict.Resolution = "discarded"
+		}
+
+		m.conflicts = append(m.conflicts, conflict)
+	}
+
+	return
+}
+
+// collisions is a method that returns the IDs of vehicles from other sources colliding with vh
+func (m *merger) collisions(source string, vh internal.Vehicle) (ids []int) {
+	if prov, ok := m.provenance[vh.Id]; ok && prov.Source != source {
+		ids = append(ids, vh.Id)
+	}
+
+	if vh.Registration == "" {
+		return
+	}
+	for _, id := range m.byRegistration[vh.Registration] {
+		if id == vh.Id || m.provenance[id].Source == source {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	return
+}
+
+// insert is a method that adds a vehicle to the merged set under the given ID
+func (m *merger) insert(source string, vh internal.Vehicle, id int) {
+	// a record of the same source with the same ID is overwritten
+	if _, ok := m.db[id]; ok {
+		m.remove(id)
+	}
+
+	originalId := vh.Id
+	vh.Id = id
+	m.db[id] = vh
+	m.provenance[id] = VehicleProvenance{Source: source, OriginalId: originalId}
+	if vh.Registration != "" {
+		m.byRegistration[vh.Registration] = append(m.byRegistration[vh.Registration], id)
+	}
+	if id > m.maxId {
+		m.maxId = id
+	}
+}
+
+// remove is a method that deletes a vehicle from the merged set
+func (m *merger) remove(id int) {
+	vh, ok := m.db[id]
+	if !ok {
+		return
+	}
+
+	delete(m.db, id)
+	delete(m.provenance, id)
+
+	ids := m.byRegistration[vh.Registration]
+	for i, rid := range ids {
+		if rid == id {
+			m.byRegistration[vh.Registration] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	if len(m.byRegistration[vh.Registration]) == 0 {
+		delete(m.byRegistration, vh.Registration)
+	}
+}
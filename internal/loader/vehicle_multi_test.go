@@ -0,0 +1,141 @@
+package loader_test
+
+import (
+	"app/internal"
+	"app/internal/loader"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeSource is a function that writes a JSON source of vehicles in a temporary directory
+func writeSource(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVehicleMulti_Load(t *testing.T) {
+	// a and b collide on the ID 1, b also has the ID 3 that a renumbered vehicle must not take
+	dir := t.TempDir()
+	a := writeSource(t, dir, "a.json", `[{"id":1,"registration":"AAA"},{"id":2,"registration":"BBB"}]`)
+	b := writeSource(t, dir, "b.json", `[{"id":1,"registration":"CCC"},{"id":3,"registration":"DDD"}]`)
+
+	cases := []struct {
+		name          string
+		policy        loader.MergePolicy
+		registrations map[int]string
+		resolution    string
+		err           error
+	}{
+		{
+			name:          "first wins",
+			policy:        loader.MergePolicyFirstWins,
+			registrations: map[int]string{1: "AAA", 2: "BBB", 3: "DDD"},
+			resolution:    "discarded",
+		},
+		{
+			name:          "last wins",
+			policy:        loader.MergePolicyLastWins,
+			registrations: map[int]string{1: "CCC", 2: "BBB", 3: "DDD"},
+			resolution:    "replaced",
+		},
+		{
+			name:   "fail",
+			policy: loader.MergePolicyFail,
+			err:    internal.ErrorLoaderConflict,
+		},
+		{
+			name:          "renumber",
+			policy:        loader.MergePolicyRenumber,
+			registrations: map[int]string{1: "AAA", 2: "BBB", 3: "DDD", 4: "CCC"},
+			resolution:    "renumbered to 4",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l := loader.NewVehicleMulti([]string{a, b}, c.policy)
+
+			v, err := l.Load()
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err != nil {
+				return
+			}
+
+			if len(v) != len(c.registrations) {
+				t.Fatalf("loaded %d vehicles, want %d: %v", len(v), len(c.registrations), v)
+			}
+			for id, registration := range c.registrations {
+				if vh, ok := v[id]; !ok || vh.Id != id || vh.Registration != registration {
+					t.Errorf("vehicle %d = %+v, want registration %s", id, vh, registration)
+				}
+			}
+
+			report := l.Report()
+			if len(report.Conflicts) != 1 {
+				t.Fatalf("conflicts = %+v, want 1", report.Conflicts)
+			}
+			if conflict := report.Conflicts[0]; conflict.Id != 1 || conflict.Source != b || conflict.Resolution != c.resolution {
+				t.Errorf("conflict = %+v, want vehicle 1 of %s %s", conflict, b, c.resolution)
+			}
+		})
+	}
+}
+
+func TestVehicleMulti_Load_RenumberProvenance(t *testing.T) {
+	dir := t.TempDir()
+	a := writeSource(t, dir, "a.json", `[{"id":1,"registration":"AAA"},{"id":2,"registration":"BBB"}]`)
+	b := writeSource(t, dir, "b.json", `[{"id":1,"registration":"CCC"},{"id":3,"registration":"DDD"}]`)
+
+	l := loader.NewVehicleMulti([]string{a, b}, loader.MergePolicyRenumber)
+	if _, err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int]loader.VehicleProvenance{
+		1: {Source: a, OriginalId: 1},
+		2: {Source: a, OriginalId: 2},
+		3: {Source: b, OriginalId: 3},
+		4: {Source: b, OriginalId: 1},
+	}
+	provenance := l.Report().Provenance
+	if len(provenance) != len(want) {
+		t.Fatalf("provenance = %v, want %v", provenance, want)
+	}
+	for id, p := range want {
+		if provenance[id] != p {
+			t.Errorf("provenance of %d = %+v, want %+v", id, provenance[id], p)
+		}
+	}
+}
+
+func TestVehicleMulti_MergeReport(t *testing.T) {
+	dir := t.TempDir()
+	a := writeSource(t, dir, "a.json", `[{"id":1,"registration":"AAA"},{"id":2,"registration":"BBB"}]`)
+	b := writeSource(t, dir, "b.json", `[{"id":1,"registration":"CCC"},{"id":3,"registration":"DDD"}]`)
+
+	l := loader.NewVehicleMulti([]string{a, b}, loader.MergePolicyFirstWins)
+	if _, err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	// - the vehicle discarded by first-wins is not counted in its file but reported as a conflict
+	report := l.MergeReport()
+	if len(report.Files) != 2 || report.Files[0] != a || report.Files[1] != b {
+		t.Errorf("files = %v, want %s and %s", report.Files, a, b)
+	}
+	if report.Vehicles[a] != 2 || report.Vehicles[b] != 1 {
+		t.Errorf("vehicles by file = %v, want 2 of %s and 1 of %s", report.Vehicles, a, b)
+	}
+	if len(report.Conflicts) != 1 || report.Conflicts[0].Resolution != "discarded" {
+		t.Errorf("conflicts = %+v, want vehicle 1 of %s discarded", report.Conflicts, b)
+	}
+}
@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"app/internal/tracing"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Errors in logging
+var (
+	ErrorInvalidLevel  = errors.New("Invalid log level, must be debug, info, warn or error")
+	ErrorInvalidFormat = errors.New("Invalid log format, must be json or text")
+)
+
+// Formats of the logs
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// HeaderRequestID is the header carrying the request ID in requests and responses
+const HeaderRequestID = "X-Request-ID"
+
+// requestIDKey is the key of the request ID in a context
+type requestIDKey struct{}
+
+// New is a function that returns a logger writing to w with the given level and format,
+// every record logged with a context carries the request ID of the context
+func New(w io.Writer, level string, format string) (l *slog.Logger, err error) {
+	var lv slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		lv = slog.LevelDebug
+	case "", "info":
+		lv = slog.LevelInfo
+	case "warn":
+		lv = slog.LevelWarn
+	case "error":
+		lv = slog.LevelError
+	default:
+		err = fmt.Errorf("%w: %s", ErrorInvalidLevel, level)
+		return
+	}
+
+	opts := &slog.HandlerOptions{Level: lv}
+	var h slog.Handler
+	switch strings.ToLower(format) {
+	case "", FormatJSON:
+		h = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		h = slog.NewTextHandler(w, opts)
+	default:
+		err = fmt.Errorf("%w: %s", ErrorInvalidFormat, format)
+		return
+	}
+
+	l = slog.New(&contextHandler{Handler: h})
+	return
+}
+
+// WithRequestID is a function that returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID is a function that returns the request ID carried by ctx, empty if there is none
+func RequestID(ctx context.Context) (id string) {
+	id, _ = ctx.Value(requestIDKey{}).(string)
+	return
+}
+
+// NewRequestID is a function that returns a random request ID
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// contextHandler is a slog.Handler that adds the values carried by the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle is a method that adds the request ID and the trace of ctx to the record
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := tracing.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID.String()), slog.String("span_id", sc.SpanID.String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs is a method that returns a handler with the attributes added, keeping the context values
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup is a method that returns a handler with the group added, keeping the context values
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"app/internal/tools"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxRequestIDLength is the longest incoming request ID accepted, longer ones are replaced
+const maxRequestIDLength = 128
+
+// Middleware is a function that returns a middleware assigning a request ID to each request
+// (the incoming X-Request-ID or a new one) and logging the request once served
+func Middleware(l *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			// request id
+			id := r.Header.Get(HeaderRequestID)
+			if id == "" || len(id) > maxRequestIDLength {
+				id = NewRequestID()
+			}
+			w.Header().Set(HeaderRequestID, id)
+			ctx := WithRequestID(r.Context(), id)
+
+			ww := tools.NewResponseCapture(w, r)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			// log
+			code := ww.StatusCode()
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", code),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("remote_addr", r.RemoteAddr),
+			}
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				attrs = append(attrs, slog.String("route", rctx.RoutePattern()))
+				if params := routeParams(rctx); len(params) > 0 {
+					attrs = append(attrs, slog.Any("params", params))
+				}
+			}
+			if r.URL.RawQuery != "" {
+				attrs = append(attrs, slog.String("query", r.URL.RawQuery))
+			}
+
+			// validation and domain errors are logged with the message sent to the client
+			level := slog.LevelInfo
+			switch {
+			case code >= http.StatusInternalServerError:
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error", ww.ErrorMessage()))
+			case code >= http.StatusBadRequest:
+				level = slog.LevelWarn
+				attrs = append(attrs, slog.String("error", ww.ErrorMessage()))
+			}
+
+			l.LogAttrs(ctx, level, "request", attrs...)
+		})
+	}
+}
+
+// routeParams is a function that returns the URL params matched by the router
+func routeParams(rctx *chi.Context) (params map[string]string) {
+	for i, key := range rctx.URLParams.Keys {
+		if key == "*" || i >= len(rctx.URLParams.Values) {
+			continue
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[key] = rctx.URLParams.Values[i]
+	}
+	return
+}
@@ -0,0 +1,246 @@
+package metrics
+
+import (
+	"app/internal"
+	"app/internal/tools"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namedErrors are the errors counted by name in app_errors_total, new internal errors must be added here
+var namedErrors = []struct {
+	name string
+	err  error
+}{
+	// loader
+	{"ErrorLoaderNoSources", internal.ErrorLoaderNoSources},
+	{"ErrorLoaderSourceNotFound", internal.ErrorLoaderSourceNotFound},
+	{"ErrorLoaderConflict", internal.ErrorLoaderConflict},
+	{"ErrorLoaderInvalidPolicy", internal.ErrorLoaderInvalidPolicy},
+	// reloader
+	{"ErrorReloadEmptyDataset", internal.ErrorReloadEmptyDataset},
+	{"ErrorReloadInvalidVehicle", internal.ErrorReloadInvalidVehicle},
+	{"ErrorReloadInProgress", internal.ErrorReloadInProgress},
+	{"ErrorDatasetNotLoaded", internal.ErrorDatasetNotLoaded},
+	// api keys
+	{"ErrorAPIKeyMissing", internal.ErrorAPIKeyMissing},
+	{"ErrorAPIKeyInvalid", internal.ErrorAPIKeyInvalid},
+	{"ErrorAPIKeyNotFound", internal.ErrorAPIKeyNotFound},
+	{"ErrorAPIKeyInvalidScope", internal.ErrorAPIKeyInvalidScope},
+	{"ErrorAPIKeyInvalidName", internal.ErrorAPIKeyInvalidName},
+	{"ErrorAPIKeyStore", internal.ErrorAPIKeyStore},
+	// authentication and authorization
+	{"ErrorTokenInvalid", internal.ErrorTokenInvalid},
+	{"ErrorTokenExpired", internal.ErrorTokenExpired},
+	{"ErrorJWTKeys", internal.ErrorJWTKeys},
+	{"ErrorForbidden", internal.ErrorForbidden},
+	{"ErrorPolicyMissing", internal.ErrorPolicyMissing},
+	// rate limits
+	{"ErrorRateLimited", internal.ErrorRateLimited},
+	{"ErrorQuotaExceeded", internal.ErrorQuotaExceeded},
+	// tenants
+	{"ErrorTenantInvalid", internal.ErrorTenantInvalid},
+	{"ErrorTenantNotFound", internal.ErrorTenantNotFound},
+	{"ErrorTenantForbidden", internal.ErrorTenantForbidden},
+	// vehicles
+	{"ErrorVehicleNotFound", internal.ErrorVehicleNotFound},
+	{"ErrorInternalServer", internal.ErrorInternalServer},
+	{"ErrorInvalidBodyRequest", internal.ErrorInvalidBodyRequest},
+	{"ErrorInvalidYear", internal.ErrorInvalidYear},
+	{"ErrorInvalidColorAndYear", internal.ErrorInvalidColorAndYear},
+	{"ErrorInvalidDimension", internal.ErrorInvalidDimension},
+	{"ErrorInvalidHeightAndWidth", internal.ErrorInvalidHeightAndWidth},
+	{"ErrorVehicleAlreadyExists", internal.ErrorVehicleAlreadyExists},
+	{"ErrorInvalidQueryParamFormat", internal.ErrorInvalidQueryParamFormat},
+	{"ErrorInvalidBrandAndRangeYear", internal.ErrorInvalidBrandAndRangeYear},
+	{"ErrorInvalidBrand", internal.ErrorInvalidBrand},
+	{"ErrorInvalidFuelType", internal.ErrorInvalidFuelType},
+	{"ErrorInvalidTransmissionType", internal.ErrorInvalidTransmissionType},
+	{"ErrorInvalidID", internal.ErrorInvalidID},
+	{"ErrorParseID", internal.ErrorParseID},
+	{"ErrorInvalidWeightRange", internal.ErrorInvalidWeightRange},
+	{"ErrorInvalidMaxSpeed", internal.ErrorInvalidMaxSpeed},
+	{"ErrorInvalidMaxSpeedRange", internal.ErrorInvalidMaxSpeedRange},
+	{"ErrorInvalidFuelTypeUpdate", internal.ErrorInvalidFuelTypeUpdate},
+	{"ErrorInvalidTransmissionValue", internal.ErrorInvalidTransmissionValue},
+	{"ErrorVehicleNotInCatalog", internal.ErrorVehicleNotInCatalog},
+	{"ErrorInvalidRegistration", internal.ErrorInvalidRegistration},
+	{"ErrorInvalidVIN", internal.ErrorInvalidVIN},
+	{"ErrorVINMismatch", internal.ErrorVINMismatch},
+	{"ErrorInvalidVehicles", internal.ErrorInvalidVehicles},
+	{"ErrorInvalidRequest", internal.ErrorInvalidRequest},
+	{"ErrorRequestTimeout", internal.ErrorRequestTimeout},
+	{"ErrorRequestCanceled", internal.ErrorRequestCanceled},
+	// context
+	{"ContextDeadlineExceeded", context.DeadlineExceeded},
+	{"ContextCanceled", context.Canceled},
+}
+
+// errorName is a function that returns the name of a known error, "other" if it is unknown
+func errorName(err error) string {
+	for _, ne := range namedErrors {
+		if errors.Is(err, ne.err) {
+			return ne.name
+		}
+	}
+	return "other"
+}
+
+// errorNameByMessage is a function that returns the name of the known error with the given message,
+// possibly followed by ": detail"
+func errorNameByMessage(message string) string {
+	for _, ne := range namedErrors {
+		if m := ne.err.Error(); message == m || strings.HasPrefix(message, m+": ") {
+			return ne.name
+		}
+	}
+	return "other"
+}
+
+// ConfigPrometheus is a struct that represents the configuration for Prometheus
+type ConfigPrometheus struct {
+	// Reloader provides the dataset size and reload counters
+	Reloader internal.VehicleReloader
+	// Repository provides the number of vehicles currently stored
+	Repository internal.VehicleRepository
+}
+
+// NewPrometheus is a function that returns a new instance of Prometheus
+func NewPrometheus(cfg ConfigPrometheus) *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "app_http_requests_total",
+			Help: "Number of HTTP requests by route pattern, method and status code.",
+		}, []string{"route", "method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "app_http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by route pattern and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		repository: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "app_repository_operation_duration_seconds",
+			Help:    "Latency of repository operations by operation and result.",
+			Buckets: []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1},
+		}, []string{"operation", "result"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "app_errors_total",
+			Help: "Number of errors returned by layer and error name.",
+		}, []string{"layer", "error"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.requests,
+		p.duration,
+		p.repository,
+		p.errors,
+	)
+
+	// dataset gauges are computed on each scrape
+	if cfg.Reloader != nil {
+		p.registry.MustRegister(
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name: "app_dataset_loaded_vehicles",
+				Help: "Number of vehicles loaded by the last successful reload.",
+			}, func() float64 { return float64(cfg.Reloader.Status().DatasetSize) }),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name: "app_dataset_reloads_total",
+				Help: "Number of successful dataset reloads.",
+			}, func() float64 { return float64(cfg.Reloader.Status().Reloads) }),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name: "app_dataset_reload_failures_total",
+				Help: "Number of failed dataset reloads.",
+			}, func() float64 { return float64(cfg.Reloader.Status().Failures) }),
+		)
+	}
+	if cfg.Repository != nil {
+		p.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "app_dataset_vehicles",
+			Help: "Number of vehicles currently stored in the repository.",
+		}, func() float64 {
+			// - every tenant counts
+			if tr, ok := cfg.Repository.(internal.VehicleTenantRepository); ok {
+				total := 0
+				for _, n := range tr.Tenants() {
+					total += n
+				}
+				return float64(total)
+			}
+			v, err := cfg.Repository.FindAll()
+			if err != nil {
+				return 0
+			}
+			return float64(len(v))
+		}))
+	}
+
+	return p
+}
+
+// Prometheus is a struct that collects the metrics of the application in Prometheus format
+type Prometheus struct {
+	// registry is the registry every collector is registered in
+	registry *prometheus.Registry
+	// requests counts the HTTP requests
+	requests *prometheus.CounterVec
+	// duration observes the latency of the HTTP requests
+	duration *prometheus.HistogramVec
+	// repository observes the latency of the repository operations
+	repository *prometheus.HistogramVec
+	// errors counts the errors returned by each layer
+	errors *prometheus.CounterVec
+}
+
+// Handler is a method that returns a handler for the route GET /metrics
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRepository is a method that records the duration and the error of a repository operation
+func (p *Prometheus) ObserveRepository(operation string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+		p.errors.WithLabelValues("repository", errorName(err)).Inc()
+	}
+	p.repository.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
+
+// Middleware is a method that returns a middleware recording the requests by chi route pattern
+func (p *Prometheus) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := tools.NewResponseCapture(w, r)
+
+			next.ServeHTTP(ww, r)
+
+			// the pattern is known once the router has matched the request
+			route := "unmatched"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					route = pattern
+				}
+			}
+
+			code := ww.StatusCode()
+			p.requests.WithLabelValues(route, r.Method, strconv.Itoa(code)).Inc()
+			p.duration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+
+			if code >= http.StatusBadRequest {
+				p.errors.WithLabelValues("http", errorNameByMessage(strings.TrimSpace(ww.ErrorMessage()))).Inc()
+			}
+		})
+	}
+}
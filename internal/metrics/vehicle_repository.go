@@ -0,0 +1,332 @@
+package metrics
+
+import (
+	"app/internal"
+	"context"
+	"time"
+)
+
+// NewVehicleRepository is a function that returns a new instance of VehicleRepository
+func NewVehicleRepository(rp internal.VehicleRepository, p *Prometheus) *VehicleRepository {
+	return &VehicleRepository{rp: rp, p: p}
+}
+
+// VehicleRepository is a struct that implements the VehicleRepository interface timing every operation of another repository
+type VehicleRepository struct {
+	// rp is the instrumented repository
+	rp internal.VehicleRepository
+	// p records the observations
+	p *Prometheus
+}
+
+// FindAll is a method that returns a map of all vehicles
+func (r *VehicleRepository) FindAll() (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindAll", start, err) }(time.Now())
+
+	v, err = r.rp.FindAll()
+	return
+}
+
+// FindAllContext is like FindAll but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindAllContext(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindAll", start, err) }(time.Now())
+
+	v, err = r.rp.FindAllContext(ctx)
+	return
+}
+
+// FindByID is a method that returns a vehicle by its ID
+func (r *VehicleRepository) FindByID(id int) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByID", start, err) }(time.Now())
+
+	v, err = r.rp.FindByID(id)
+	return
+}
+
+// FindByIDContext is like FindByID but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindByIDContext(ctx context.Context, id int) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByID", start, err) }(time.Now())
+
+	v, err = r.rp.FindByIDContext(ctx, id)
+	return
+}
+
+// Create is a method that creates a new vehicle
+func (r *VehicleRepository) Create(v *internal.Vehicle) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("Create", start, err) }(time.Now())
+
+	err = r.rp.Create(v)
+	return
+}
+
+// CreateContext is like Create but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) CreateContext(ctx context.Context, v *internal.Vehicle) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("Create", start, err) }(time.Now())
+
+	err = r.rp.CreateContext(ctx, v)
+	return
+}
+
+// FindByColorAndYear is a method that returns a map of vehicles that match the color and year
+func (r *VehicleRepository) FindByColorAndYear(color string, year int) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByColorAndYear", start, err) }(time.Now())
+
+	v, err = r.rp.FindByColorAndYear(color, year)
+	return
+}
+
+// FindByColorAndYearContext is like FindByColorAndYear but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindByColorAndYearContext(ctx context.Context, color string, year int) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByColorAndYear", start, err) }(time.Now())
+
+	v, err = r.rp.FindByColorAndYearContext(ctx, color, year)
+	return
+}
+
+// FindByBrandAndRangeYear is a method that returns a map of vehicles that match the brand and range year
+func (r *VehicleRepository) FindByBrandAndRangeYear(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByBrandAndRangeYear", start, err) }(time.Now())
+
+	v, err = r.rp.FindByBrandAndRangeYear(brand, startYear, endYear)
+	return
+}
+
+// FindByBrandAndRangeYearContext is like FindByBrandAndRangeYear but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindByBrandAndRangeYearContext(ctx context.Context, brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByBrandAndRangeYear", start, err) }(time.Now())
+
+	v, err = r.rp.FindByBrandAndRangeYearContext(ctx, brand, startYear, endYear)
+	return
+}
+
+// FindAverageSpeedByBrand is a method that returns a map of vehicles that match the average speed and brand
+func (r *VehicleRepository) FindAverageSpeedByBrand(brand string) (avgSpeed float64, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindAverageSpeedByBrand", start, err) }(time.Now())
+
+	avgSpeed, err = r.rp.FindAverageSpeedByBrand(brand)
+	return
+}
+
+// FindAverageSpeedByBrandContext is like FindAverageSpeedByBrand but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindAverageSpeedByBrandContext(ctx context.Context, brand string) (avgSpeed float64, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindAverageSpeedByBrand", start, err) }(time.Now())
+
+	avgSpeed, err = r.rp.FindAverageSpeedByBrandContext(ctx, brand)
+	return
+}
+
+// CreateBatch is a method that creates a batch of vehicles
+func (r *VehicleRepository) CreateBatch(v []internal.Vehicle) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("CreateBatch", start, err) }(time.Now())
+
+	err = r.rp.CreateBatch(v)
+	return
+}
+
+// CreateBatchContext is like CreateBatch but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) CreateBatchContext(ctx context.Context, v []internal.Vehicle) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("CreateBatch", start, err) }(time.Now())
+
+	err = r.rp.CreateBatchContext(ctx, v)
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the max speed of a vehicle
+func (r *VehicleRepository) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("UpdateMaxSpeed", start, err) }(time.Now())
+
+	err = r.rp.UpdateMaxSpeed(id, maxSpeed)
+	return
+}
+
+// UpdateMaxSpeedContext is like UpdateMaxSpeed but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) UpdateMaxSpeedContext(ctx context.Context, id int, maxSpeed float64) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("UpdateMaxSpeed", start, err) }(time.Now())
+
+	err = r.rp.UpdateMaxSpeedContext(ctx, id, maxSpeed)
+	return
+}
+
+// FindByFuelType is a method that returns a map of vehicles that match the fuel type
+func (r *VehicleRepository) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByFuelType", start, err) }(time.Now())
+
+	v, err = r.rp.FindByFuelType(fuelType)
+	return
+}
+
+// FindByFuelTypeContext is like FindByFuelType but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindByFuelTypeContext(ctx context.Context, fuelType string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByFuelType", start, err) }(time.Now())
+
+	v, err = r.rp.FindByFuelTypeContext(ctx, fuelType)
+	return
+}
+
+// Delete is a method that deletes a vehicle
+func (r *VehicleRepository) Delete(id int) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("Delete", start, err) }(time.Now())
+
+	err = r.rp.Delete(id)
+	return
+}
+
+// DeleteContext is like Delete but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) DeleteContext(ctx context.Context, id int) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("Delete", start, err) }(time.Now())
+
+	err = r.rp.DeleteContext(ctx, id)
+	return
+}
+
+// FindByTransmissionType is a method that returns a map of vehicles that match the transmission type
+func (r *VehicleRepository) FindByTransmissionType(transmissionType string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByTransmissionType", start, err) }(time.Now())
+
+	v, err = r.rp.FindByTransmissionType(transmissionType)
+	return
+}
+
+// FindByTransmissionTypeContext is like FindByTransmissionType but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindByTransmissionTypeContext(ctx context.Context, transmissionType string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByTransmissionType", start, err) }(time.Now())
+
+	v, err = r.rp.FindByTransmissionTypeContext(ctx, transmissionType)
+	return
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle
+func (r *VehicleRepository) UpdateFuelType(id int, fuelType string) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("UpdateFuelType", start, err) }(time.Now())
+
+	err = r.rp.UpdateFuelType(id, fuelType)
+	return
+}
+
+// UpdateFuelTypeContext is like UpdateFuelType but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) UpdateFuelTypeContext(ctx context.Context, id int, fuelType string) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("UpdateFuelType", start, err) }(time.Now())
+
+	err = r.rp.UpdateFuelTypeContext(ctx, id, fuelType)
+	return
+}
+
+// FindAverageCapacityByBrand is a method that returns a map of vehicles that match the average person capacity and brand
+func (r *VehicleRepository) FindAverageCapacityByBrand(brand string) (avgCapacity float64, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindAverageCapacityByBrand", start, err) }(time.Now())
+
+	avgCapacity, err = r.rp.FindAverageCapacityByBrand(brand)
+	return
+}
+
+// FindAverageCapacityByBrandContext is like FindAverageCapacityByBrand but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindAverageCapacityByBrandContext(ctx context.Context, brand string) (avgCapacity float64, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindAverageCapacityByBrand", start, err) }(time.Now())
+
+	avgCapacity, err = r.rp.FindAverageCapacityByBrandContext(ctx, brand)
+	return
+}
+
+// FindByDimensions is a method that returns a map of vehicles that match the dimensions
+func (r *VehicleRepository) FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByDimensions", start, err) }(time.Now())
+
+	v, err = r.rp.FindByDimensions(minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// FindByDimensionsContext is like FindByDimensions but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindByDimensionsContext(ctx context.Context, minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByDimensions", start, err) }(time.Now())
+
+	v, err = r.rp.FindByDimensionsContext(ctx, minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// FindByWeightRange is a method that returns a map of vehicles that match the weight range
+func (r *VehicleRepository) FindByWeightRange(minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByWeightRange", start, err) }(time.Now())
+
+	v, err = r.rp.FindByWeightRange(minWeight, maxWeight)
+	return
+}
+
+// FindByWeightRangeContext is like FindByWeightRange but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) FindByWeightRangeContext(ctx context.Context, minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByWeightRange", start, err) }(time.Now())
+
+	v, err = r.rp.FindByWeightRangeContext(ctx, minWeight, maxWeight)
+	return
+}
+
+// ReplaceAll is a method that atomically replaces every vehicle with the given ones
+func (r *VehicleRepository) ReplaceAll(v map[int]internal.Vehicle) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("ReplaceAll", start, err) }(time.Now())
+
+	err = r.rp.ReplaceAll(v)
+	return
+}
+
+// ReplaceAllContext is like ReplaceAll but honors the cancellation and deadline of ctx
+func (r *VehicleRepository) ReplaceAllContext(ctx context.Context, v map[int]internal.Vehicle) (err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("ReplaceAll", start, err) }(time.Now())
+
+	err = r.rp.ReplaceAllContext(ctx, v)
+	return
+}
+
+// SearchContext is a method that returns up to limit vehicles matching the words of a query, the most relevant first
+func (r *VehicleRepository) SearchContext(ctx context.Context, query string, limit int) (hits []internal.VehicleHit, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("Search", start, err) }(time.Now())
+
+	hits, err = r.rp.SearchContext(ctx, query, limit)
+	return
+}
+
+// FindByCatalogContext is a method that returns a map of vehicles linked to catalog entries that match a filter
+func (r *VehicleRepository) FindByCatalogContext(ctx context.Context, filter internal.CatalogFilter) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByCatalog", start, err) }(time.Now())
+
+	v, err = r.rp.FindByCatalogContext(ctx, filter)
+	return
+}
+
+// FindByRegistrationContext is a method that returns a map of vehicles whose registration has the normalized form of a registration
+func (r *VehicleRepository) FindByRegistrationContext(ctx context.Context, registration string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByRegistration", start, err) }(time.Now())
+
+	v, err = r.rp.FindByRegistrationContext(ctx, registration)
+	return
+}
+
+// FindRegistrationDuplicatesContext is a method that returns the registrations shared by several vehicles
+func (r *VehicleRepository) FindRegistrationDuplicatesContext(ctx context.Context) (d []internal.RegistrationDuplicate, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindRegistrationDuplicates", start, err) }(time.Now())
+
+	d, err = r.rp.FindRegistrationDuplicatesContext(ctx)
+	return
+}
+
+// FindByVINContext is a method that returns the vehicle whose VIN has the normalized form of a VIN
+func (r *VehicleRepository) FindByVINContext(ctx context.Context, vin string) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.p.ObserveRepository("FindByVIN", start, err) }(time.Now())
+
+	v, err = r.rp.FindByVINContext(ctx, vin)
+	return
+}
+
+// Ping is a method that checks the instrumented repository when it supports it
+func (r *VehicleRepository) Ping() (err error) {
+	if pn, ok := r.rp.(internal.VehicleRepositoryPinger); ok {
+		err = pn.Ping()
+	}
+	return
+}
+
+// Flush is a method that flushes the instrumented repository when it supports it
+func (r *VehicleRepository) Flush() (err error) {
+	if fl, ok := r.rp.(internal.VehicleRepositoryFlusher); ok {
+		err = fl.Flush()
+	}
+	return
+}
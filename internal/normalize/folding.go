@@ -0,0 +1,98 @@
+package normalize
+
+import (
+	"app/internal"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultSynonyms are the synonyms applied when none are configured, as field:alias=canonical entries
+var DefaultSynonyms = []string{
+	"brand:chevy=chevrolet",
+	"brand:vw=volkswagen",
+	"brand:mercedes=mercedes benz",
+	"fuel_type:petrol=gasoline",
+	"fuel_type:gasolina=gasoline",
+	"fuel_type:lpg=gas",
+	"transmission:auto=automatic",
+	"transmission:semi auto=semi automatic",
+	"transmission:standard=manual",
+}
+
+// ParseSynonyms is a function that groups "field:alias=canonical" entries by field, both sides
+// are compared once normalized so they may be written with any case, accents or separators
+func ParseSynonyms(entries []string) (synonyms map[string]map[string]string, err error) {
+	synonyms = make(map[string]map[string]string)
+	for _, entry := range entries {
+		field, pair, ok := strings.Cut(entry, ":")
+		alias, canonical, okPair := strings.Cut(pair, "=")
+		field, alias, canonical = strings.TrimSpace(field), Fold(alias), Fold(canonical)
+		if !ok || !okPair || alias == "" || canonical == "" {
+			err = fmt.Errorf("%w: %q must be field:alias=canonical", internal.ErrorInvalidSynonym, entry)
+			return
+		}
+		if !slices.Contains(internal.TextFields, field) {
+			err = fmt.Errorf("%w: unknown field %q, must be one of %s", internal.ErrorInvalidSynonym, field, strings.Join(internal.TextFields, ", "))
+			return
+		}
+
+		if synonyms[field] == nil {
+			synonyms[field] = make(map[string]string)
+		}
+		synonyms[field][alias] = canonical
+	}
+	return
+}
+
+// NewFolding is a function that returns a new instance of Folding with synonyms by field, by alias
+// as returned by ParseSynonyms
+func NewFolding(synonyms map[string]map[string]string) *Folding {
+	if synonyms == nil {
+		synonyms = make(map[string]map[string]string)
+	}
+	return &Folding{synonyms: synonyms}
+}
+
+// Folding is a struct that implements the TextNormalizer interface: the keys are the values
+// case folded, without accents, with hyphens, underscores and runs of whitespace as a single space,
+// and then replaced by their canonical value when they are the alias of a synonym of their field
+type Folding struct {
+	// synonyms are the canonical keys by alias key, by field
+	synonyms map[string]map[string]string
+}
+
+// Normalize is a method that returns the key of a value of a text field
+func (f *Folding) Normalize(field string, value string) (key string) {
+	key = Fold(value)
+	if canonical, ok := f.synonyms[field][key]; ok {
+		key = canonical
+	}
+	return
+}
+
+// Fold is a function that case folds a value, strips its accents and normalizes its separators
+func Fold(value string) string {
+	var b strings.Builder
+	b.Grow(len(value))
+	space := false
+	for _, r := range norm.NFD.String(value) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// - combining mark left by the decomposition of an accented letter
+			continue
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
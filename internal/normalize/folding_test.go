@@ -0,0 +1,118 @@
+package normalize_test
+
+import (
+	"app/internal"
+	"app/internal/normalize"
+	"errors"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		key   string
+	}{
+		{name: "case", value: "Mercedes", key: "mercedes"},
+		{name: "accents", value: "Citroën Méhari", key: "citroen mehari"},
+		{name: "hyphens and underscores", value: "semi-auto_matic", key: "semi auto matic"},
+		{name: "runs of whitespace", value: "  Land \t Rover  ", key: "land rover"},
+		{name: "mixed separators", value: "rolls - royce", key: "rolls royce"},
+		{name: "empty", value: "", key: ""},
+		{name: "separators only", value: " -_ ", key: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if key := normalize.Fold(c.value); key != c.key {
+				t.Errorf("Fold(%q) = %q, want %q", c.value, key, c.key)
+			}
+		})
+	}
+}
+
+func TestParseSynonyms(t *testing.T) {
+	cases := []struct {
+		name     string
+		entries  []string
+		synonyms map[string]map[string]string
+		err      error
+	}{
+		{
+			name:     "none",
+			synonyms: map[string]map[string]string{},
+		},
+		{
+			name:    "grouped by field, both sides folded",
+			entries: []string{"brand:VW=Volkswagen", "brand: Chevy = Chevrolet", "transmission:Semi-Auto=semi automatic"},
+			synonyms: map[string]map[string]string{
+				"brand":        {"vw": "volkswagen", "chevy": "chevrolet"},
+				"transmission": {"semi auto": "semi automatic"},
+			},
+		},
+		{name: "missing field", entries: []string{"vw=volkswagen"}, err: internal.ErrorInvalidSynonym},
+		{name: "missing canonical", entries: []string{"brand:vw"}, err: internal.ErrorInvalidSynonym},
+		{name: "empty alias", entries: []string{"brand: =volkswagen"}, err: internal.ErrorInvalidSynonym},
+		{name: "unknown field", entries: []string{"engine:v8=eight"}, err: internal.ErrorInvalidSynonym},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			synonyms, err := normalize.ParseSynonyms(c.entries)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err != nil {
+				return
+			}
+			if len(synonyms) != len(c.synonyms) {
+				t.Fatalf("synonyms = %v, want %v", synonyms, c.synonyms)
+			}
+			for field, aliases := range c.synonyms {
+				if len(synonyms[field]) != len(aliases) {
+					t.Errorf("synonyms of %s = %v, want %v", field, synonyms[field], aliases)
+				}
+				for alias, canonical := range aliases {
+					if synonyms[field][alias] != canonical {
+						t.Errorf("synonym of %s %q = %q, want %q", field, alias, synonyms[field][alias], canonical)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFolding_Normalize(t *testing.T) {
+	synonyms, err := normalize.ParseSynonyms(normalize.DefaultSynonyms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nm := normalize.NewFolding(synonyms)
+
+	cases := []struct {
+		name  string
+		field string
+		value string
+		key   string
+	}{
+		{name: "folded", field: "color", value: "Dark-Blue", key: "dark blue"},
+		{name: "alias of the field", field: "brand", value: "VW", key: "volkswagen"},
+		{name: "alias written with separators and accents", field: "transmission", value: "Sémi_Auto", key: "semi automatic"},
+		{name: "canonical left as it is", field: "fuel_type", value: "Gasoline", key: "gasoline"},
+		{name: "alias of another field", field: "color", value: "vw", key: "vw"},
+		{name: "alias of a multi-word canonical", field: "brand", value: "mercedes", key: "mercedes benz"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if key := nm.Normalize(c.field, c.value); key != c.key {
+				t.Errorf("Normalize(%s, %q) = %q, want %q", c.field, c.value, key, c.key)
+			}
+		})
+	}
+
+	// - without synonyms the values are only folded
+	if key := normalize.NewFolding(nil).Normalize("brand", "VW"); key != "vw" {
+		t.Errorf("Normalize without synonyms = %q, want %q", key, "vw")
+	}
+}
@@ -0,0 +1,243 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Version is the version of the OpenAPI specification the documents follow
+const Version = "3.0.3"
+
+// Document is a struct that represents an OpenAPI 3 document, only the parts this API uses
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Info is a struct that represents the metadata of the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a struct that represents a server of the API
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag is a struct that represents a group of operations
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem is a struct that represents the operations of a path, by method
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Patch  *Operation `json:"patch,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+// Operation is a method that returns the operation of a method, nil if the path does not define it
+func (p *PathItem) Operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return p.Get
+	case "POST":
+		return p.Post
+	case "PUT":
+		return p.Put
+	case "PATCH":
+		return p.Patch
+	case "DELETE":
+		return p.Delete
+	}
+	return nil
+}
+
+// setOperation is a method that sets the operation of a method
+func (p *PathItem) setOperation(method string, op *Operation) {
+	switch strings.ToUpper(method) {
+	case "GET":
+		p.Get = op
+	case "POST":
+		p.Post = op
+	case "PUT":
+		p.Put = op
+	case "PATCH":
+		p.Patch = op
+	case "DELETE":
+		p.Delete = op
+	}
+}
+
+// Operation is a struct that represents an operation of a path
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Parameter is a struct that represents a parameter of an operation
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+	Schema      *Schema `json:"schema"`
+	Example     any     `json:"example,omitempty"`
+}
+
+// RequestBody is a struct that represents the body of a request
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response is a struct that represents a response of an operation
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header is a struct that represents a header of a response
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType is a struct that represents the schema of a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a struct that represents a JSON schema, the OpenAPI 3.0 subset
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Example              any                `json:"example,omitempty"`
+}
+
+// Components is a struct that represents the reusable objects of a document
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a struct that represents a way of authenticating
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// SecurityRequirement is a map of security schemes to their required scopes, one requirement of a list suffices
+type SecurityRequirement map[string][]string
+
+// Ref is a function that returns a schema referencing a component schema
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// SchemaOf is a function that returns the schema of a value, built from its type and JSON tags.
+// Struct fields without omitempty are required, the fields of embedded structs without a JSON name are promoted
+func SchemaOf(v any) *Schema {
+	return schemaOfType(reflect.TypeOf(v))
+}
+
+// schemaOfType is a function that returns the schema of a type
+func schemaOfType(t reflect.Type) (s *Schema) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	s = &Schema{}
+	switch t.Kind() {
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Uint, reflect.Uint8, reflect.Uint16:
+		s.Type = "integer"
+	case reflect.Int32, reflect.Uint32:
+		s.Type, s.Format = "integer", "int32"
+	case reflect.Int64, reflect.Uint64:
+		s.Type, s.Format = "integer", "int64"
+	case reflect.Float32:
+		s.Type, s.Format = "number", "float"
+	case reflect.Float64:
+		s.Type, s.Format = "number", "double"
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Slice, reflect.Array:
+		s.Type, s.Items = "array", schemaOfType(t.Elem())
+	case reflect.Map:
+		s.Type, s.AdditionalProperties = "object", schemaOfType(t.Elem())
+	case reflect.Struct:
+		s.Type = "object"
+		s.Properties = make(map[string]*Schema)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+				embedded := schemaOfType(f.Type)
+				for n, p := range embedded.Properties {
+					s.Properties[n] = p
+				}
+				s.Required = append(s.Required, embedded.Required...)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			s.Properties[name] = schemaOfType(f.Type)
+			if !strings.Contains(opts, "omitempty") {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Errors in the document
+var (
+	ErrorRouteUndocumented = errors.New("Route missing from the OpenAPI document")
+)
+
+// docsPage is the documentation UI, it renders the document served at PathSpec
+//
+//go:embed docs.html
+var docsPage []byte
+
+// Handler is a method that returns a handler serving the document as JSON, encoded once
+func (d *Document) Handler() http.Handler {
+	b, err := json.Marshal(d)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write(b)
+	})
+}
+
+// DocsHandler is a function that returns a handler serving the documentation UI, it needs no network access
+func DocsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'unsafe-inline'; style-src 'unsafe-inline'")
+		w.Write(docsPage)
+	})
+}
+
+// Check is a method that returns ErrorRouteUndocumented naming every route registered on rt
+// that the document does not describe, so a route cannot be added without documenting it
+func (d *Document) Check(rt chi.Routes) (err error) {
+	var missing []string
+	walkErr := chi.Walk(rt, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		path := NormalizePath(route)
+		if !d.Has(method, path) {
+			missing = append(missing, method+" "+path)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		err = walkErr
+		return
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		err = fmt.Errorf("%w: %s", ErrorRouteUndocumented, strings.Join(missing, ", "))
+	}
+	return
+}
+
+// NormalizePath is a function that returns the path of a chi route as written in the document:
+// without the trailing slash chi adds to the root of a subrouter
+func NormalizePath(route string) string {
+	route = strings.ReplaceAll(route, "/*/", "/")
+	if len(route) > 1 {
+		route = strings.TrimSuffix(route, "/")
+	}
+	return route
+}
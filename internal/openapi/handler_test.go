@@ -0,0 +1,73 @@
+package openapi_test
+
+import (
+	"app/internal/openapi"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestDocument_Check(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+
+	cases := []struct {
+		name    string
+		routes  func(rt chi.Router)
+		missing []string
+	}{
+		{
+			name: "every route documented",
+			routes: func(rt chi.Router) {
+				rt.Get("/healthz", noop)
+				rt.Route("/v1/vehicles", func(rt chi.Router) {
+					rt.Get("/", noop)
+					rt.Get("/{id}", noop)
+				})
+			},
+		},
+		{
+			name: "undocumented path",
+			routes: func(rt chi.Router) {
+				rt.Get("/healthz", noop)
+				rt.Get("/undocumented", noop)
+			},
+			missing: []string{"GET /undocumented"},
+		},
+		{
+			name: "undocumented method of a documented path",
+			routes: func(rt chi.Router) {
+				rt.Route("/v1/vehicles", func(rt chi.Router) {
+					rt.Put("/{id}", noop)
+					rt.Delete("/{id}", noop)
+				})
+			},
+			missing: []string{"PUT /v1/vehicles/{id}"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rt := chi.NewRouter()
+			c.routes(rt)
+
+			err := openapi.NewVehicleDocument().Check(rt)
+			if len(c.missing) == 0 {
+				if err != nil {
+					t.Fatalf("error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, openapi.ErrorRouteUndocumented) {
+				t.Fatalf("error = %v, want %v", err, openapi.ErrorRouteUndocumented)
+			}
+			for _, route := range c.missing {
+				if !strings.Contains(err.Error(), route) {
+					t.Errorf("error = %v, want it to name %s", err, route)
+				}
+			}
+		})
+	}
+}
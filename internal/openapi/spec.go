@@ -0,0 +1,911 @@
+package openapi
+
+import (
+	"app/internal"
+	"app/internal/auth"
+	"app/internal/graphql"
+	"app/internal/handler"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Paths of the routes serving the document
+const (
+	PathSpec = "/openapi.json"
+	PathDocs = "/docs"
+)
+
+// Tags of the operations
+const (
+	TagVehicles   = "vehicles"
+	TagVehiclesV2 = "vehicles-v2"
+	TagCatalog    = "catalog"
+	TagAdmin      = "admin"
+	TagKeys       = "keys"
+	TagOps        = "operations"
+)
+
+// names of the component schemas and responses
+const (
+	schemaVehicle        = "Vehicle"
+	schemaVehicleInput   = "VehicleInput"
+	schemaVehicleMap     = "VehicleMap"
+	schemaVehicleV2      = "VehicleV2"
+	schemaVehicleInputV2 = "VehicleInputV2"
+	schemaPage           = "Page"
+	schemaStats          = "Stats"
+	schemaEnums          = "Enums"
+	schemaDuplicate      = "RegistrationDuplicate"
+	schemaBrand          = "CatalogBrand"
+	schemaBrandInput     = "CatalogBrandInput"
+	schemaModel          = "CatalogModel"
+	schemaModelInput     = "CatalogModelInput"
+	schemaError          = "Error"
+	schemaReloadStatus   = "ReloadStatus"
+	schemaClientUsage    = "ClientUsage"
+	schemaAPIKey         = "APIKey"
+	schemaGraphQL        = "GraphQLResponse"
+)
+
+// APIVersion is the version of the API reported in the info of the document
+const APIVersion = "1.0.0"
+
+// NewVehicleDocument is a function that returns the document of every route of the vehicles API
+func NewVehicleDocument() (d *Document) {
+	d = &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Vehicles API",
+			Description: "Fleet of vehicles by tenant. Errors always have the shape of the Error schema.",
+			Version:     APIVersion,
+		},
+		Tags: []Tag{
+			{Name: TagVehicles, Description: "Vehicles of the tenant of the request, version 1 (frozen): lists as objects by ID and filters as path segments"},
+			{Name: TagVehiclesV2, Description: "Vehicles of the tenant of the request, version 2: lists as arrays with pagination metadata and filters as query parameters"},
+			{Name: TagCatalog, Description: "Catalog of the brands and models the vehicles are linked to, shared by the tenants, only registered when the catalog is enabled"},
+			{Name: TagAdmin, Description: "Administration of the datasets, tenants and usage"},
+			{Name: TagKeys, Description: "Administration of the API keys, only registered when API keys are enabled"},
+			{Name: TagOps, Description: "Probes, metrics and documentation"},
+		},
+		Paths: make(map[string]*PathItem),
+		Components: Components{
+			Schemas:         componentSchemas(),
+			Responses:       componentResponses(),
+			SecuritySchemes: componentSecuritySchemes(),
+		},
+	}
+
+	addOperationRoutes(d)
+	addVehicleRoutes(d)
+	addVehicleAliasRoutes(d)
+	addVehicleV2Routes(d)
+	addCatalogRoutes(d)
+	addGraphQLRoutes(d)
+	addAdminRoutes(d)
+	return
+}
+
+// Add is a method that adds the operation of a route, the path uses the chi syntax of parameters
+func (d *Document) Add(method, path string, op *Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+	item.setOperation(method, op)
+}
+
+// Has is a method that reports whether the document describes the operation of a route
+func (d *Document) Has(method, path string) bool {
+	item, ok := d.Paths[path]
+	return ok && item.Operation(method) != nil
+}
+
+// componentSchemas is a function that returns the reusable schemas
+func componentSchemas() map[string]*Schema {
+	descriptions := map[string]string{
+		"id":           "Identifier of the vehicle, unique within its tenant",
+		"year":         "Year of fabrication",
+		"passengers":   "Capacity in passengers",
+		"max_speed":    "Maximum speed in km/h",
+		"weight":       "Weight in kg",
+		"height":       "Height in m",
+		"length":       "Length in m",
+		"width":        "Width in m",
+		"registration": "Registration plate, stored upper case without whitespace, hyphens and dots",
+		"fuel_type":    "One of the fuel types of GET " + handler.PathVehiclesV2 + "/meta/enums",
+		"transmission": "One of the transmissions of GET " + handler.PathVehiclesV2 + "/meta/enums",
+	}
+	vehicle := SchemaOf(handler.VehicleJSON{})
+	describe(vehicle, descriptions)
+
+	// - the input is the vehicle, only the fields checked by the handler are required: the version 1 is frozen,
+	// the fuel type and the transmission are checked against the vocabulary when given
+	input := SchemaOf(handler.VehicleJSON{})
+	input.Required = []string{"brand", "model", "registration", "color", "year"}
+	input.Properties["id"].Minimum = ptr(0.0)
+
+	// - the version 2 adds the VIN to the vehicle and its input
+	vehicleV2 := SchemaOf(handler.VehicleV2JSON{})
+	describe(vehicleV2, descriptions)
+	vehicleV2.Properties["vin"].Description = "Vehicle identification number (ISO 3779), empty if unknown. When given it must have its check digit, " +
+		"a WMI of the brand and the model year of the year of fabrication or the next one, and be unique"
+	inputV2 := SchemaOf(handler.VehicleV2JSON{})
+	inputV2.Required = append(append([]string{}, input.Required...), "fuel_type", "transmission")
+	inputV2.Properties["id"].Minimum = ptr(0.0)
+
+	duplicate := SchemaOf(handler.DuplicateJSON{})
+	describe(duplicate, map[string]string{
+		"registration": "Normalized registration",
+		"ids":          "IDs of the vehicles sharing it, sorted",
+	})
+
+	// - the catalog: the input of a brand lists its models as inputs
+	model := SchemaOf(handler.CatalogModelJSON{})
+	model.Properties["body_type"].Enum = toAny(bodyTypes())
+	describe(model, map[string]string{
+		"aliases":   "Other names the vehicles may give the model",
+		"year_from": "First year of production, 0 if unknown",
+		"year_to":   "Last year of production, 0 if still produced or unknown",
+	})
+	modelInput := SchemaOf(handler.CatalogModelJSON{})
+	modelInput.Properties["body_type"].Enum = model.Properties["body_type"].Enum
+	modelInput.Required = []string{"name", "body_type"}
+	brand := SchemaOf(handler.CatalogBrandJSON{})
+	brand.Properties["models"].Items = Ref(schemaModel)
+	describe(brand, map[string]string{
+		"aliases": "Other names the vehicles may give the brand",
+		"country": "Country of origin",
+	})
+	brandInput := SchemaOf(handler.CatalogBrandJSON{})
+	brandInput.Properties["models"].Items = Ref(schemaModelInput)
+	brandInput.Required = []string{"name", "country"}
+
+	apiKey := SchemaOf(handler.APIKeyJSON{})
+	apiKey.Properties["secret"].Description = "Only returned when the key is issued or rotated"
+
+	return map[string]*Schema{
+		schemaVehicle:        vehicle,
+		schemaVehicleInput:   input,
+		schemaVehicleV2:      vehicleV2,
+		schemaVehicleInputV2: inputV2,
+		schemaVehicleMap: {
+			Type:                 "object",
+			Description:          "Vehicles by their ID",
+			AdditionalProperties: Ref(schemaVehicle),
+		},
+		schemaPage:       SchemaOf(handler.PageJSON{}),
+		schemaStats:      SchemaOf(handler.StatsJSON{}),
+		schemaEnums:      SchemaOf(handler.EnumsJSON{}),
+		schemaDuplicate:  duplicate,
+		schemaBrand:      brand,
+		schemaBrandInput: brandInput,
+		schemaModel:      model,
+		schemaModelInput: modelInput,
+		schemaError: {
+			Type:     "object",
+			Required: []string{"status", "message"},
+			Properties: map[string]*Schema{
+				"status":  {Type: "string", Description: "Text of the status code", Example: "Not Found"},
+				"message": {Type: "string", Description: "Error message, a detail may follow a colon", Example: internal.ErrorVehicleNotFound.Error()},
+				"errors": {
+					Type:        "array",
+					Description: "Every invalid field when the request does not match the schema of its operation",
+					Items:       SchemaOf(FieldError{}),
+				},
+			},
+		},
+		schemaReloadStatus: SchemaOf(handler.ReloadStatusJSON{}),
+		schemaClientUsage:  SchemaOf(handler.ClientUsageJSON{}),
+		schemaAPIKey:       apiKey,
+		schemaGraphQL: object(map[string]*Schema{
+			"data": {Type: "object", Nullable: true, Description: "Result of the operation, null when it could not be executed"},
+			"errors": {
+				Type:        "array",
+				Description: "Errors of the operation, each with its code in the extensions",
+				Items: object(map[string]*Schema{
+					"message":    {Type: "string", Example: internal.ErrorVehicleNotFound.Error()},
+					"path":       {Type: "array", Items: &Schema{}},
+					"locations":  {Type: "array", Items: object(map[string]*Schema{"line": {Type: "integer"}, "column": {Type: "integer"}})},
+					"extensions": object(map[string]*Schema{"code": {Type: "string", Example: graphql.CodeNotFound}}),
+				}, "message"),
+			},
+		}),
+	}
+}
+
+// componentResponses is a function that returns the reusable error responses, by status code
+func componentResponses() map[string]*Response {
+	responses := make(map[string]*Response)
+	for code, description := range errorDescriptions {
+		responses[strconv.Itoa(code)] = &Response{
+			Description: description,
+			Content:     jsonContent(Ref(schemaError)),
+		}
+	}
+	responses[strconv.Itoa(http.StatusTooManyRequests)].Headers = map[string]*Header{
+		"Retry-After": {Description: "Seconds until a token is available", Schema: &Schema{Type: "integer"}},
+	}
+	return responses
+}
+
+// errorDescriptions are the error responses, by status code
+var errorDescriptions = map[int]string{
+	http.StatusBadRequest:            "Invalid parameters, body or tenant, the invalid fields are listed in errors",
+	http.StatusUnauthorized:          "Missing, invalid or expired credentials",
+	http.StatusForbidden:             "The credentials are not allowed to invoke the operation or act on the tenant",
+	http.StatusNotFound:              "Vehicle or tenant not found",
+	http.StatusConflict:              "Vehicle already exists",
+	http.StatusRequestEntityTooLarge: "Request body larger than the limit of the server",
+	http.StatusTooManyRequests:       "Rate limit or daily quota exceeded",
+	http.StatusInternalServerError:   "Internal server error",
+	http.StatusServiceUnavailable:    "Dataset not loaded yet or request canceled",
+	http.StatusGatewayTimeout:        "Request timeout",
+}
+
+// componentSecuritySchemes is a function that returns the ways of authenticating
+func componentSecuritySchemes() map[string]*SecurityScheme {
+	return map[string]*SecurityScheme{
+		"apiKey": {
+			Type:        "apiKey",
+			Description: "API key issued at /admin/keys, also accepted as a bearer token",
+			Name:        auth.HeaderAPIKey,
+			In:          "header",
+		},
+		"bearer": {
+			Type:         "http",
+			Description:  "JWT signed with HS256 or RS256, its roles are mapped by the policy",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+		},
+	}
+}
+
+// security is the requirement of the authenticated routes: an API key or a JWT
+var security = []SecurityRequirement{{"apiKey": {}}, {"bearer": {}}}
+
+// addOperationRoutes is a function that adds the routes of the probes, metrics and documentation
+func addOperationRoutes(d *Document) {
+	d.Add(http.MethodGet, "/healthz", &Operation{
+		OperationID: "HealthDefault.Healthz",
+		Summary:     "Liveness probe",
+		Tags:        []string{TagOps},
+		Responses: map[string]*Response{
+			"200": {Description: "The process is alive", Content: jsonContent(object(map[string]*Schema{"status": {Type: "string", Example: "ok"}}))},
+		},
+	})
+	d.Add(http.MethodGet, "/readyz", &Operation{
+		OperationID: "HealthDefault.Readyz",
+		Summary:     "Readiness probe",
+		Description: "Checks the dataset is loaded, the storage is reachable and the application is not shutting down",
+		Tags:        []string{TagOps},
+		Responses: map[string]*Response{
+			"200": {Description: "Ready", Content: jsonContent(readiness())},
+			"503": {Description: "Not ready, the failed checks hold their error", Content: jsonContent(readiness())},
+		},
+	})
+	d.Add(http.MethodGet, "/version", &Operation{
+		OperationID: "HealthDefault.Version",
+		Summary:     "Build and dataset information",
+		Tags:        []string{TagOps},
+		Responses: map[string]*Response{
+			"200": {Description: "Build and dataset information", Content: jsonContent(object(map[string]*Schema{
+				"build":   {Type: "object", AdditionalProperties: &Schema{}},
+				"dataset": {Type: "object", AdditionalProperties: &Schema{}},
+			}))},
+		},
+	})
+	d.Add(http.MethodGet, "/metrics", &Operation{
+		OperationID: "Prometheus.Handler",
+		Summary:     "Prometheus metrics",
+		Tags:        []string{TagOps},
+		Responses: map[string]*Response{
+			"200": {Description: "Metrics in the Prometheus text format", Content: map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string"}}}},
+		},
+	})
+	d.Add(http.MethodGet, PathSpec, &Operation{
+		OperationID: "OpenAPI.Spec",
+		Summary:     "This OpenAPI document",
+		Tags:        []string{TagOps},
+		Responses: map[string]*Response{
+			"200": {Description: "OpenAPI 3 document", Content: jsonContent(&Schema{Type: "object"})},
+		},
+	})
+	d.Add(http.MethodGet, PathDocs, &Operation{
+		OperationID: "OpenAPI.Docs",
+		Summary:     "Documentation of the API rendered from this document",
+		Tags:        []string{TagOps},
+		Responses: map[string]*Response{
+			"200": {Description: "HTML page", Content: map[string]MediaType{"text/html": {Schema: &Schema{Type: "string"}}}},
+		},
+	})
+}
+
+// addVehicleRoutes is a function that adds the routes of the version 1 of the vehicles
+func addVehicleRoutes(d *Document) {
+	id := pathParam("id", "ID of the vehicle", &Schema{Type: "integer", Minimum: ptr(0.0)})
+	brand := pathParam("brand", "Brand of the vehicles", &Schema{Type: "string"})
+	vehicles := envelope(Ref(schemaVehicleMap), true)
+	fields := fieldsParam(handler.VehicleFieldNames())
+
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles", "VehicleDefault.GetAll", TagVehicles, &Operation{
+		Summary:    "List the vehicles",
+		Parameters: []Parameter{fields},
+		Responses:  map[string]*Response{"200": {Description: "Vehicles by ID", Content: jsonContent(vehicles)}},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/{id}", "VehicleDefault.GetByID", TagVehicles, &Operation{
+		Summary:    "Get a vehicle",
+		Parameters: []Parameter{id, fields},
+		Responses:  map[string]*Response{"200": {Description: "The vehicle", Content: jsonContent(envelope(Ref(schemaVehicle), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodPost, "/v1/vehicles", "VehicleDefault.Create", TagVehicles, &Operation{
+		Summary:     "Create a vehicle",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(Ref(schemaVehicleInput))},
+		Responses:   map[string]*Response{"201": {Description: "The created vehicle", Content: jsonContent(envelope(Ref(schemaVehicle), false))}},
+	}, http.StatusBadRequest, http.StatusConflict)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/brand/{brand}/between/{start_year}/{end_year}", "VehicleDefault.GetByBrandAndRangeYear", TagVehicles, &Operation{
+		Summary: "List the vehicles of a brand fabricated between two years",
+		Parameters: []Parameter{
+			brand,
+			pathParam("start_year", "First year of fabrication, inclusive", &Schema{Type: "integer", Minimum: ptr(0.0)}),
+			pathParam("end_year", "Last year of fabrication, inclusive", &Schema{Type: "integer", Minimum: ptr(0.0)}),
+			fields,
+		},
+		Responses: map[string]*Response{"200": {Description: "Vehicles by ID", Content: jsonContent(vehicles)}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/color/{color}/year/{year}", "VehicleDefault.GetByColorAndYear", TagVehicles, &Operation{
+		Summary: "List the vehicles of a color fabricated in a year",
+		Parameters: []Parameter{
+			pathParam("color", "Color of the vehicles", &Schema{Type: "string"}),
+			pathParam("year", "Year of fabrication", &Schema{Type: "integer", Minimum: ptr(0.0)}),
+			fields,
+		},
+		Responses: map[string]*Response{"200": {Description: "Vehicles by ID", Content: jsonContent(vehicles)}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/average-speed/brand/{brand}", "VehicleDefault.GetAverageSpeedByBrand", TagVehicles, &Operation{
+		Summary:    "Average maximum speed of the vehicles of a brand",
+		Parameters: []Parameter{brand},
+		Responses: map[string]*Response{"200": {Description: "Average speed", Content: jsonContent(envelope(object(map[string]*Schema{
+			"brand":         {Type: "string"},
+			"average_speed": {Type: "number", Format: "double"},
+		}), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodPost, "/v1/vehicles/batch", "VehicleDefault.CreateBatch", TagVehicles, &Operation{
+		Summary:     "Create several vehicles, none is created if any fails",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(object(map[string]*Schema{"vehicles": {Type: "array", MinItems: ptr(1), Items: Ref(schemaVehicleInput)}}, "vehicles"))},
+		Responses:   map[string]*Response{"201": {Description: "Vehicles created", Content: jsonContent(envelope(&Schema{Type: "string"}, false))}},
+	}, http.StatusBadRequest, http.StatusConflict)
+	vehicleRoute(d, http.MethodPatch, "/v1/vehicles/{id}/update-speed", "VehicleDefault.UpdateMaxSpeed", TagVehicles, &Operation{
+		Summary:     "Update the maximum speed of a vehicle",
+		Parameters:  []Parameter{id},
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(object(map[string]*Schema{"max_speed": {Type: "number", Format: "double", Description: "New maximum speed in km/h, not zero"}}, "max_speed"))},
+		Responses:   map[string]*Response{"200": {Description: "Maximum speed updated", Content: jsonContent(detail())}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/fuel-type/{type}", "VehicleDefault.GetByFuelType", TagVehicles, &Operation{
+		Summary:    "List the vehicles of a fuel type",
+		Parameters: []Parameter{pathParam("type", "Fuel type", &Schema{Type: "string"}), fields},
+		Responses:  map[string]*Response{"200": {Description: "Vehicles by ID", Content: jsonContent(vehicles)}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodDelete, "/v1/vehicles/{id}", "VehicleDefault.Delete", TagVehicles, &Operation{
+		Summary:    "Delete a vehicle",
+		Parameters: []Parameter{id},
+		Responses:  map[string]*Response{"204": {Description: "Vehicle deleted"}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/transmission/{type}", "VehicleDefault.GetByTransmissionType", TagVehicles, &Operation{
+		Summary:    "List the vehicles of a transmission type",
+		Parameters: []Parameter{pathParam("type", "Transmission type", &Schema{Type: "string"}), fields},
+		Responses:  map[string]*Response{"200": {Description: "Vehicles by ID", Content: jsonContent(vehicles)}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodPatch, "/v1/vehicles/{id}/update-fuel", "VehicleDefault.UpdateFuelType", TagVehicles, &Operation{
+		Summary:     "Update the fuel type of a vehicle",
+		Parameters:  []Parameter{id},
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(object(map[string]*Schema{"fuel_type": {Type: "string", Description: "New fuel type"}}, "fuel_type"))},
+		Responses:   map[string]*Response{"200": {Description: "Fuel type updated", Content: jsonContent(detail())}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/average-capacity/brand/{brand}", "VehicleDefault.GetAverageCapacityByBrand", TagVehicles, &Operation{
+		Summary:    "Average capacity in passengers of the vehicles of a brand",
+		Parameters: []Parameter{brand},
+		Responses: map[string]*Response{"200": {Description: "Average capacity", Content: jsonContent(envelope(object(map[string]*Schema{
+			"brand":            {Type: "string"},
+			"average_capacity": {Type: "number", Format: "double"},
+		}), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/dimensions", "VehicleDefault.GetByDimensions", TagVehicles, &Operation{
+		Summary: "List the vehicles within ranges of height and width",
+		Parameters: []Parameter{
+			queryParam("height", "Range of height in m as {min}-{max}", true, &Schema{Type: "string", Format: FormatRange}, "1.2-1.8"),
+			queryParam("width", "Range of width in m as {min}-{max}", true, &Schema{Type: "string", Format: FormatRange}, "1.5-2.1"),
+			fields,
+		},
+		Responses: map[string]*Response{"200": {Description: "Vehicles by ID", Content: jsonContent(vehicles)}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, "/v1/vehicles/weight", "VehicleDefault.GetByWeightRange", TagVehicles, &Operation{
+		Summary: "List the vehicles within a range of weight",
+		Parameters: []Parameter{
+			queryParam("min", "Minimum weight in kg", true, &Schema{Type: "number", Format: "double"}, nil),
+			queryParam("max", "Maximum weight in kg", true, &Schema{Type: "number", Format: "double"}, nil),
+			fields,
+		},
+		Responses: map[string]*Response{"200": {Description: "Vehicles by ID", Content: jsonContent(vehicles)}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+}
+
+// addVehicleAliasRoutes is a function that adds the unversioned routes of the vehicles, deprecated copies
+// of the routes of the version 1 they are an alias of
+func addVehicleAliasRoutes(d *Document) {
+	paths := make([]string, 0)
+	for path := range d.Paths {
+		if path == handler.PathVehiclesV1 || strings.HasPrefix(path, handler.PathVehiclesV1+"/") {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		alias := handler.PathVehicles + strings.TrimPrefix(path, handler.PathVehiclesV1)
+		for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			op := d.Paths[path].Operation(method)
+			if op == nil {
+				continue
+			}
+			aliasOp := *op
+			aliasOp.OperationID = op.OperationID + "Unversioned"
+			aliasOp.Deprecated = true
+			aliasOp.Description = strings.TrimSpace("Alias of " + method + " " + path + ".\n\n" + op.Description)
+			d.Add(method, alias, &aliasOp)
+		}
+	}
+}
+
+// addVehicleV2Routes is a function that adds the routes of the version 2 of the vehicles
+func addVehicleV2Routes(d *Document) {
+	path := handler.PathVehiclesV2
+	id := pathParam("id", "ID of the vehicle", &Schema{Type: "integer", Minimum: ptr(0.0)})
+	year := &Schema{Type: "integer", Minimum: ptr(0.0)}
+	fields := fieldsParam(handler.VehicleV2FieldNames())
+	list := envelope(&Schema{Type: "array", Items: Ref(schemaVehicleV2)}, false)
+	list.Properties["meta"] = Ref(schemaPage)
+	list.Required = append(list.Required, "meta")
+
+	vehicleRoute(d, http.MethodGet, path, "VehicleV2.List", TagVehiclesV2, &Operation{
+		Summary: "List the vehicles matching every filter, sorted by ID",
+		Description: "color goes with year, year_from and year_to with brand and height with width. " +
+			"body_type and country match the catalog entries the vehicles are linked to by their brand and model. " +
+			"No vehicle matching is an empty list.",
+		Parameters: []Parameter{
+			queryParam("color", "Color of the vehicles, with year", false, &Schema{Type: "string"}, nil),
+			queryParam("year", "Year of fabrication, with color", false, year, nil),
+			queryParam("brand", "Brand of the vehicles", false, &Schema{Type: "string"}, nil),
+			queryParam("year_from", "First year of fabrication of the vehicles of the brand, inclusive", false, year, nil),
+			queryParam("year_to", "Last year of fabrication of the vehicles of the brand, inclusive", false, year, nil),
+			queryParam("fuel_type", "Fuel type", false, &Schema{Type: "string"}, nil),
+			queryParam("transmission", "Transmission type", false, &Schema{Type: "string"}, nil),
+			queryParam("height", "Range of height in m as {min}-{max}, with width", false, &Schema{Type: "string", Format: FormatRange}, "1.2-1.8"),
+			queryParam("width", "Range of width in m as {min}-{max}, with height", false, &Schema{Type: "string", Format: FormatRange}, "1.5-2.1"),
+			queryParam("weight", "Range of weight in kg as {min}-{max}", false, &Schema{Type: "string", Format: FormatRange}, "100-250"),
+			queryParam("body_type", "Body type of the model in the catalog", false, &Schema{Type: "string", Enum: toAny(bodyTypes())}, "truck"),
+			queryParam("country", "Country of origin of the brand in the catalog", false, &Schema{Type: "string"}, "United States"),
+			queryParam(handler.ParamPage, "Page of the list, from 1", false, &Schema{Type: "integer", Minimum: ptr(1.0)}, nil),
+			queryParam(handler.ParamPerPage, fmt.Sprintf("Vehicles by page, %d by default", handler.DefaultPerPage), false, &Schema{Type: "integer", Minimum: ptr(1.0), Maximum: ptr(float64(handler.MaxPerPage))}, nil),
+			fields,
+		},
+		Responses: map[string]*Response{"200": {Description: "Page of the vehicles", Content: jsonContent(list)}},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+	vehicleRoute(d, http.MethodGet, path+"/search/text", "VehicleV2.Search", TagVehiclesV2, &Operation{
+		Summary: "Search the vehicles by the words of a query, the most relevant first",
+		Description: "Each word matches the brand, model, color or registration of the vehicles exactly, as a prefix " +
+			"or with typos (one from 4 letters, two from 8). Vehicles matching more words and on the brand or model rank first.",
+		Parameters: []Parameter{
+			queryParam("q", "Words to search", true, &Schema{Type: "string", MinLength: ptr(1)}, "chevy camaro"),
+			queryParam("limit", fmt.Sprintf("Maximum number of vehicles, %d by default", internal.SearchDefaultLimit), false,
+				&Schema{Type: "integer", Minimum: ptr(1.0), Maximum: ptr(float64(internal.SearchMaxLimit))}, nil),
+			fields,
+		},
+		Responses: map[string]*Response{"200": {Description: "Vehicles found with their score", Content: jsonContent(object(map[string]*Schema{
+			"message": {Type: "string", Example: "Success"},
+			"data": {Type: "array", Items: object(map[string]*Schema{
+				"score":   {Type: "number", Format: "double", Description: "Relevance, higher is better"},
+				"vehicle": Ref(schemaVehicleV2),
+			}, "score", "vehicle")},
+			"meta": object(map[string]*Schema{"count": {Type: "integer"}}, "count"),
+		}, "message", "data", "meta"))}},
+	}, http.StatusBadRequest)
+	vehicleRoute(d, http.MethodGet, path+"/meta/enums", "VehicleV2.GetEnums", TagVehiclesV2, &Operation{
+		Summary: "List the fuel types and transmissions allowed for the vehicles written",
+		Description: "Values are compared case, accent and separator insensitively and with the synonyms of the configuration, " +
+			"the vehicles created or updated with another fuel type or transmission are rejected.",
+		Responses: map[string]*Response{"200": {Description: "Values allowed", Content: jsonContent(envelope(Ref(schemaEnums), false))}},
+	})
+	vehicleRoute(d, http.MethodGet, path+"/registration/{plate}", "VehicleV2.GetByRegistration", TagVehiclesV2, &Operation{
+		Summary: "Find the vehicles of a registration, sorted by ID",
+		Description: "The plate is compared by its normalized form, upper case without whitespace, hyphens and dots. " +
+			"Several vehicles are only found when the loaded dataset shares the registration.",
+		Parameters: []Parameter{pathParam("plate", "Registration plate", &Schema{Type: "string", MinLength: ptr(1)}), fields},
+		Responses: map[string]*Response{"200": {Description: "Vehicles of the registration", Content: jsonContent(object(map[string]*Schema{
+			"message": {Type: "string", Example: "Success"},
+			"data":    {Type: "array", Items: Ref(schemaVehicleV2)},
+			"meta":    object(map[string]*Schema{"count": {Type: "integer"}}, "count"),
+		}, "message", "data", "meta"))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, path+"/vin/{vin}", "VehicleV2.GetByVIN", TagVehiclesV2, &Operation{
+		Summary:     "Find the vehicle of a VIN",
+		Description: "The VIN is compared by its normalized form, upper case without whitespace and hyphens.",
+		Parameters:  []Parameter{pathParam("vin", "Vehicle identification number", &Schema{Type: "string", MinLength: ptr(1)}), fields},
+		Responses:   map[string]*Response{"200": {Description: "The vehicle", Content: jsonContent(envelope(Ref(schemaVehicleV2), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, path+"/registrations/duplicates", "VehicleV2.GetRegistrationDuplicates", TagVehiclesV2, &Operation{
+		Summary:     "Report the registrations shared by several vehicles, sorted",
+		Description: "Only the loaded dataset may share a registration, the vehicles created must have a registration of their own.",
+		Responses: map[string]*Response{"200": {Description: "Registrations shared", Content: jsonContent(object(map[string]*Schema{
+			"message": {Type: "string", Example: "Success"},
+			"data":    {Type: "array", Items: Ref(schemaDuplicate)},
+			"meta": object(map[string]*Schema{
+				"count":    {Type: "integer", Description: "Registrations shared"},
+				"vehicles": {Type: "integer", Description: "Vehicles sharing them"},
+			}, "count", "vehicles"),
+		}, "message", "data", "meta"))}},
+	})
+	vehicleRoute(d, http.MethodGet, path+"/stats", "VehicleV2.GetStats", TagVehiclesV2, &Operation{
+		Summary:    "Average maximum speed and capacity of the vehicles of a brand",
+		Parameters: []Parameter{queryParam("brand", "Brand of the vehicles", true, &Schema{Type: "string", MinLength: ptr(1)}, nil)},
+		Responses:  map[string]*Response{"200": {Description: "Averages of the brand", Content: jsonContent(envelope(Ref(schemaStats), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodGet, path+"/{id}", "VehicleV2.Get", TagVehiclesV2, &Operation{
+		Summary:    "Get a vehicle",
+		Parameters: []Parameter{id, fields},
+		Responses:  map[string]*Response{"200": {Description: "The vehicle", Content: jsonContent(envelope(Ref(schemaVehicleV2), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodPost, path, "VehicleV2.Create", TagVehiclesV2, &Operation{
+		Summary:     "Create a vehicle",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(Ref(schemaVehicleInputV2))},
+		Responses: map[string]*Response{"201": {
+			Description: "The created vehicle",
+			Headers:     map[string]*Header{"Location": {Description: "Path of the vehicle", Schema: &Schema{Type: "string"}}},
+			Content:     jsonContent(envelope(Ref(schemaVehicleV2), false)),
+		}},
+	}, http.StatusBadRequest, http.StatusConflict)
+	vehicleRoute(d, http.MethodPost, path+"/batch", "VehicleV2.CreateBatch", TagVehiclesV2, &Operation{
+		Summary:     "Create several vehicles, none is created if any fails",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(object(map[string]*Schema{"vehicles": {Type: "array", MinItems: ptr(1), Items: Ref(schemaVehicleInputV2)}}, "vehicles"))},
+		Responses: map[string]*Response{"201": {Description: "The created vehicles", Content: jsonContent(object(map[string]*Schema{
+			"message": {Type: "string", Example: "Success"},
+			"data":    {Type: "array", Items: Ref(schemaVehicleV2)},
+			"meta":    object(map[string]*Schema{"count": {Type: "integer"}}, "count"),
+		}, "message", "data", "meta"))}},
+	}, http.StatusBadRequest, http.StatusConflict)
+	vehicleRoute(d, http.MethodPatch, path+"/{id}", "VehicleV2.Update", TagVehiclesV2, &Operation{
+		Summary:    "Update the maximum speed and fuel type of a vehicle",
+		Parameters: []Parameter{id},
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(object(map[string]*Schema{
+			"max_speed": {Type: "number", Format: "double", Description: "New maximum speed in km/h"},
+			"fuel_type": {Type: "string", Description: "New fuel type"},
+		}))},
+		Responses: map[string]*Response{"200": {Description: "The updated vehicle", Content: jsonContent(envelope(Ref(schemaVehicleV2), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+	vehicleRoute(d, http.MethodDelete, path+"/{id}", "VehicleV2.Delete", TagVehiclesV2, &Operation{
+		Summary:    "Delete a vehicle",
+		Parameters: []Parameter{id},
+		Responses:  map[string]*Response{"204": {Description: "Vehicle deleted, without body"}},
+	}, http.StatusBadRequest, http.StatusNotFound)
+}
+
+// addCatalogRoutes is a function that adds the routes of the catalog of brands and models
+func addCatalogRoutes(d *Document) {
+	path := handler.PathCatalogBrands
+	brand := pathParam("brand", "Name or alias of the brand", &Schema{Type: "string"})
+	model := pathParam("model", "Name or alias of the model", &Schema{Type: "string"})
+	written := func(description string) *Response {
+		return &Response{
+			Description: description,
+			Headers:     map[string]*Header{"Location": {Description: "Path of the brand", Schema: &Schema{Type: "string"}}},
+			Content:     jsonContent(envelope(Ref(schemaBrand), false)),
+		}
+	}
+
+	catalogRoute(d, http.MethodGet, path, "CatalogDefault.GetBrands", &Operation{
+		Summary: "List the brands of the catalog with their models, sorted by name",
+		Responses: map[string]*Response{"200": {Description: "Brands", Content: jsonContent(object(map[string]*Schema{
+			"message": {Type: "string", Example: "Success"},
+			"data":    {Type: "array", Items: Ref(schemaBrand)},
+			"meta":    object(map[string]*Schema{"count": {Type: "integer"}}, "count"),
+		}, "message", "data", "meta"))}},
+	})
+	catalogRoute(d, http.MethodGet, path+"/{brand}", "CatalogDefault.GetBrand", &Operation{
+		Summary:    "Get a brand of the catalog with its models",
+		Parameters: []Parameter{brand},
+		Responses:  map[string]*Response{"200": {Description: "The brand", Content: jsonContent(envelope(Ref(schemaBrand), false))}},
+	}, http.StatusNotFound)
+	catalogRoute(d, http.MethodPost, path, "CatalogDefault.CreateBrand", &Operation{
+		Summary:     "Add a brand to the catalog with its models",
+		Description: "The names and aliases of the brands, and of the models of a brand, are compared as the vehicle filters do and must be unique.",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(Ref(schemaBrandInput))},
+		Responses:   map[string]*Response{"201": written("The created brand")},
+	}, http.StatusConflict)
+	catalogRoute(d, http.MethodPut, path+"/{brand}", "CatalogDefault.UpdateBrand", &Operation{
+		Summary:     "Replace a brand of the catalog, its models included",
+		Parameters:  []Parameter{brand},
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(Ref(schemaBrandInput))},
+		Responses:   map[string]*Response{"200": written("The updated brand")},
+	}, http.StatusNotFound, http.StatusConflict)
+	catalogRoute(d, http.MethodDelete, path+"/{brand}", "CatalogDefault.DeleteBrand", &Operation{
+		Summary:     "Remove a brand from the catalog with its models",
+		Description: "Its vehicles are kept but no longer linked to the catalog, and new ones are rejected.",
+		Parameters:  []Parameter{brand},
+		Responses:   map[string]*Response{"204": {Description: "Brand removed, without body"}},
+	}, http.StatusNotFound)
+	catalogRoute(d, http.MethodPost, path+"/{brand}/models", "CatalogDefault.CreateModel", &Operation{
+		Summary:     "Add a model to a brand of the catalog",
+		Parameters:  []Parameter{brand},
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(Ref(schemaModelInput))},
+		Responses:   map[string]*Response{"201": written("The brand with the created model")},
+	}, http.StatusNotFound, http.StatusConflict)
+	catalogRoute(d, http.MethodPut, path+"/{brand}/models/{model}", "CatalogDefault.UpdateModel", &Operation{
+		Summary:     "Replace a model of a brand of the catalog",
+		Parameters:  []Parameter{brand, model},
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(Ref(schemaModelInput))},
+		Responses:   map[string]*Response{"200": written("The brand with the updated model")},
+	}, http.StatusNotFound, http.StatusConflict)
+	catalogRoute(d, http.MethodDelete, path+"/{brand}/models/{model}", "CatalogDefault.DeleteModel", &Operation{
+		Summary:    "Remove a model from a brand of the catalog",
+		Parameters: []Parameter{brand, model},
+		Responses:  map[string]*Response{"204": {Description: "Model removed, without body"}},
+	}, http.StatusNotFound)
+}
+
+// addGraphQLRoutes is a function that adds the route of the GraphQL API
+func addGraphQLRoutes(d *Document) {
+	description := "GraphQL API of the vehicles of the tenant of the request, the schema is available by introspection. " +
+		fmt.Sprintf("Operations deeper than the configured depth (graphql.max_depth) or more complex (graphql.max_complexity) are rejected, "+
+			"each field costs 1 and the fields under a list %d times more. The introspection fields are measured apart, "+
+			"within a depth of %d and a complexity of %d.", graphql.ListComplexity, graphql.DefaultMaxIntrospectionDepth, graphql.DefaultMaxIntrospectionComplexity)
+	for _, handlerName := range []string{"GraphQLDefault.Query", "GraphQLDefault.Mutate", "GraphQLDefault.Delete"} {
+		rule := auth.DefaultPolicy[handlerName]
+		description += fmt.Sprintf("\n\n`%s` requires the scope `%s` for API keys or one of the roles `%s` for tokens.", handlerName, rule.Scope, strings.Join(rule.Roles, "`, `"))
+	}
+	result := jsonContent(Ref(schemaGraphQL))
+
+	vehicleRoute(d, http.MethodPost, "/graphql", "GraphQLDefault.Execute", TagVehicles, &Operation{
+		Summary:     "Execute a GraphQL operation",
+		Description: description,
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(object(map[string]*Schema{
+			"query":         {Type: "string", MinLength: ptr(1), Example: "{ vehicles(filter: {fuelType: \"gas\"}) { id brand dimensions { height } } }"},
+			"operationName": {Type: "string", Nullable: true},
+			"variables":     {Type: "object", Nullable: true},
+		}, "query"))},
+		Responses: map[string]*Response{
+			"200": {Description: "Result of the operation, the errors of its fields are listed in errors", Content: result},
+			"400": {Description: "Operation that can not be parsed, invalid or over the limits", Content: result},
+			"403": {Description: "A root field of the operation is not allowed to the credentials", Content: result},
+		},
+	})
+}
+
+// addAdminRoutes is a function that adds the routes of the administration
+func addAdminRoutes(d *Document) {
+	keyID := pathParam("id", "ID of the API key", &Schema{Type: "string"})
+	duration := &Schema{Type: "string", Description: "Go duration, as 1h30m", Example: "1h"}
+
+	adminRoute(d, http.MethodPost, "/admin/reload", "AdminDefault.Reload", TagAdmin, &Operation{
+		Summary: "Reload the datasets from their sources",
+		Responses: map[string]*Response{
+			"200": {Description: "Reloaded", Content: jsonContent(envelope(Ref(schemaReloadStatus), false))},
+			"500": {Description: "Reload failed, the previous dataset is kept", Content: jsonContent(envelope(Ref(schemaReloadStatus), false))},
+		},
+	})
+	adminRoute(d, http.MethodGet, "/admin/reload", "AdminDefault.GetReloadStatus", TagAdmin, &Operation{
+		Summary:     "Status of the reloads",
+		Description: "When several sources are merged, the status reports the vehicles kept from each file and the collisions resolved by the merge policy.",
+		Responses:   map[string]*Response{"200": {Description: "Status of the reloads", Content: jsonContent(envelope(Ref(schemaReloadStatus), false))}},
+	})
+	adminRoute(d, http.MethodGet, "/admin/tenants", "TenantDefault.GetTenants", TagAdmin, &Operation{
+		Summary:     "List the tenants and their number of vehicles",
+		Description: "Credentials bound to a tenant only see their tenant.",
+		Responses: map[string]*Response{"200": {Description: "Tenants", Content: jsonContent(envelope(&Schema{Type: "array", Items: object(map[string]*Schema{
+			"tenant":   {Type: "string"},
+			"vehicles": {Type: "integer"},
+		}, "tenant", "vehicles")}, false))}},
+	})
+	adminRoute(d, http.MethodGet, "/admin/vehicles", "TenantDefault.GetAllVehicles", TagAdmin, &Operation{
+		Summary:     "List the vehicles of every tenant",
+		Description: "Credentials bound to a tenant only see their tenant, requesting another tenant is answered 403.",
+		Parameters:  []Parameter{queryParam("tenant", "Comma separated tenants to restrict the listing to", false, &Schema{Type: "string"}, "fleet-a,fleet-b")},
+		Responses: map[string]*Response{"200": {Description: "Vehicles by tenant", Content: jsonContent(envelope(&Schema{
+			Type:                 "object",
+			AdditionalProperties: &Schema{Type: "array", Items: Ref(schemaVehicle)},
+		}, false))}},
+	}, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusGatewayTimeout)
+	adminRoute(d, http.MethodGet, "/admin/usage", "UsageDefault.GetUsage", TagAdmin, &Operation{
+		Summary:   "Usage of the clients of the API today",
+		Responses: map[string]*Response{"200": {Description: "Usage by client", Content: jsonContent(envelope(&Schema{Type: "array", Items: Ref(schemaClientUsage)}, false))}},
+	})
+
+	adminRoute(d, http.MethodGet, "/admin/keys", "APIKeyDefault.GetAll", TagKeys, &Operation{
+		Summary:     "List the API keys, their secrets are never returned",
+		Description: "Credentials bound to a tenant only see the keys of their tenant.",
+		Responses:   map[string]*Response{"200": {Description: "API keys", Content: jsonContent(envelope(&Schema{Type: "array", Items: Ref(schemaAPIKey)}, false))}},
+	}, http.StatusInternalServerError)
+	adminRoute(d, http.MethodPost, "/admin/keys", "APIKeyDefault.Issue", TagKeys, &Operation{
+		Summary:     "Issue an API key",
+		Description: "Credentials bound to a tenant only issue keys bound to their tenant, requesting another tenant is answered 403.",
+		RequestBody: &RequestBody{Required: true, Content: jsonContent(object(map[string]*Schema{
+			"name":       {Type: "string"},
+			"tenant":     {Type: "string", Description: "Tenant the key is bound to, empty for every tenant or the tenant of bound credentials"},
+			"scopes":     {Type: "array", Items: &Schema{Type: "string", Enum: toAny(internal.Scopes)}},
+			"expires_in": duration,
+		}, "name", "scopes"))},
+		Responses: map[string]*Response{"201": {Description: "API key issued with its secret", Content: jsonContent(envelope(Ref(schemaAPIKey), false))}},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+	adminRoute(d, http.MethodPost, "/admin/keys/{id}/rotate", "APIKeyDefault.Rotate", TagKeys, &Operation{
+		Summary:     "Rotate the secret of an API key, the previous one is accepted during a grace period",
+		Description: "Credentials bound to a tenant only rotate the keys of their tenant.",
+		Parameters:  []Parameter{keyID},
+		RequestBody: &RequestBody{Content: jsonContent(object(map[string]*Schema{"grace": duration}))},
+		Responses:   map[string]*Response{"200": {Description: "API key rotated with its new secret", Content: jsonContent(envelope(Ref(schemaAPIKey), false))}},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	adminRoute(d, http.MethodDelete, "/admin/keys/{id}", "APIKeyDefault.Revoke", TagKeys, &Operation{
+		Summary:     "Revoke an API key",
+		Description: "Credentials bound to a tenant only revoke the keys of their tenant.",
+		Parameters:  []Parameter{keyID},
+		Responses:   map[string]*Response{"200": {Description: "API key revoked", Content: jsonContent(detail())}},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+}
+
+// vehicleRoute is a function that adds a route of the vehicles: it is authenticated, scoped to the tenant
+// of the request, rate limited and bounded by a timeout besides the errors of the handler itself
+func vehicleRoute(d *Document, method, path, handlerName, tag string, op *Operation, errors ...int) {
+	op.Parameters = append(op.Parameters, Parameter{
+		Name:        handler.HeaderTenant,
+		In:          "header",
+		Description: "Tenant of the request, ignored for credentials bound to a tenant",
+		Schema:      &Schema{Type: "string", Pattern: internal.TenantPattern, Example: internal.DefaultTenant},
+	})
+	limitRoute(op)
+	errors = append(errors, http.StatusBadRequest, http.StatusNotFound, http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout)
+	adminRoute(d, method, path, handlerName, tag, op, errors...)
+}
+
+// catalogRoute is a function that adds a route of the catalog, rate limited and timed out as the vehicle routes
+// but not scoped to a tenant
+func catalogRoute(d *Document, method, path, handlerName string, op *Operation, errors ...int) {
+	limitRoute(op)
+	errors = append(errors, http.StatusBadRequest, http.StatusTooManyRequests, http.StatusGatewayTimeout)
+	adminRoute(d, method, path, handlerName, TagCatalog, op, errors...)
+}
+
+// limitRoute is a function that adds the rate limit headers to the responses with a body of an operation
+func limitRoute(op *Operation) {
+	for _, res := range op.Responses {
+		if res.Content == nil {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(map[string]*Header)
+		}
+		for name, h := range rateLimitHeaders() {
+			res.Headers[name] = h
+		}
+	}
+}
+
+// adminRoute is a function that adds an authenticated route allowed by the policy of its handler
+func adminRoute(d *Document, method, path, handlerName, tag string, op *Operation, errors ...int) {
+	op.OperationID = handlerName
+	op.Tags = []string{tag}
+	op.Security = security
+	if rule, ok := auth.DefaultPolicy[handlerName]; ok {
+		op.Description = strings.TrimSpace(op.Description + fmt.Sprintf("\n\nRequires the scope `%s` for API keys or one of the roles `%s` for tokens.", rule.Scope, strings.Join(rule.Roles, "`, `")))
+	}
+
+	errors = append(errors, http.StatusUnauthorized, http.StatusForbidden)
+	if op.RequestBody != nil {
+		errors = append(errors, http.StatusRequestEntityTooLarge)
+	}
+	sort.Ints(errors)
+	for _, code := range errors {
+		key := strconv.Itoa(code)
+		if _, ok := op.Responses[key]; !ok {
+			op.Responses[key] = &Response{Ref: "#/components/responses/" + key}
+		}
+	}
+	d.Add(method, path, op)
+}
+
+// rateLimitHeaders is a function that returns the headers of the rate limited responses
+func rateLimitHeaders() map[string]*Header {
+	return map[string]*Header{
+		"RateLimit-Limit":     {Description: "Tokens of the bucket of the client", Schema: &Schema{Type: "integer"}},
+		"RateLimit-Remaining": {Description: "Tokens left", Schema: &Schema{Type: "integer"}},
+		"RateLimit-Reset":     {Description: "Seconds until the bucket is full", Schema: &Schema{Type: "integer"}},
+	}
+}
+
+// readiness is a function that returns the schema of the readiness probe
+func readiness() *Schema {
+	return object(map[string]*Schema{
+		"status": {Type: "string", Enum: []any{"ready", "not ready"}},
+		"checks": {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+	}, "status", "checks")
+}
+
+// envelope is a function that returns the schema of a successful response holding data
+func envelope(data *Schema, count bool) *Schema {
+	s := object(map[string]*Schema{
+		"message": {Type: "string", Example: "Success"},
+		"data":    data,
+	}, "message", "data")
+	if count {
+		s.Properties["count"] = &Schema{Type: "integer"}
+		s.Required = append(s.Required, "count")
+	}
+	return s
+}
+
+// detail is a function that returns the schema of a successful response describing what was done
+func detail() *Schema {
+	return object(map[string]*Schema{
+		"message": {Type: "string", Example: "Success"},
+		"detail":  {Type: "string"},
+	}, "message", "detail")
+}
+
+// object is a function that returns the schema of an object
+func object(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// jsonContent is a function that returns a JSON content of a schema
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// pathParam is a function that returns a required path parameter
+func pathParam(name, description string, s *Schema) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: s}
+}
+
+// queryParam is a function that returns a query parameter
+func queryParam(name, description string, required bool, s *Schema, example any) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Required: required, Schema: s, Example: example}
+}
+
+// fieldsParam is a function that returns the query parameter selecting the fields of the vehicles of a response
+// among names, written as a comma separated list
+func fieldsParam(names []string) (p Parameter) {
+	p = queryParam(handler.ParamFields, "Comma separated fields of the vehicles to return, every field when missing. "+
+		"dimensions selects height, length and width, dimensions.{name} one of them", false,
+		&Schema{Type: "array", MinItems: ptr(1), Items: &Schema{Type: "string", Enum: toAny(names)}},
+		"id,brand,model,max_speed")
+	p.Style, p.Explode = "form", ptr(false)
+	return
+}
+
+// describe is a function that sets the descriptions of the properties of a schema
+func describe(s *Schema, descriptions map[string]string) {
+	for name, description := range descriptions {
+		if p, ok := s.Properties[name]; ok {
+			p.Description = description
+		}
+	}
+}
+
+// bodyTypes is a function that returns the body types of the models of the catalog
+func bodyTypes() (names []string) {
+	for _, t := range internal.BodyTypes {
+		names = append(names, string(t))
+	}
+	return
+}
+
+// toAny is a function that returns the values of a slice as a slice of any
+func toAny(values []string) (a []any) {
+	for _, v := range values {
+		a = append(a, v)
+	}
+	return
+}
+
+// ptr is a function that returns a pointer to a value
+func ptr[T any](v T) *T { return &v }
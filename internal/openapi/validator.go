@@ -0,0 +1,438 @@
+package openapi
+
+import (
+	"app/internal"
+	"app/internal/tools"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// FormatRange is the format of the string parameters holding a range of numbers as {min}-{max},
+// they are converted to a tools.Range
+const FormatRange = "range"
+
+// FieldError is a struct that represents why a field of a request does not match its schema
+type FieldError struct {
+	// In is where the field is: path, query or body
+	In string `json:"in"`
+	// Field is the name of the parameter, or the JSON path of a body field ("body" for the body itself)
+	Field string `json:"field"`
+	// Message is why the field is invalid
+	Message string `json:"message"`
+}
+
+// NewValidator is a function that returns a new instance of Validator, rt is the root router
+// the requests are matched against to find their operation and maxBodyBytes the largest body read
+func NewValidator(doc *Document, rt chi.Routes, maxBodyBytes int64) *Validator {
+	return &Validator{doc: doc, rt: rt, maxBodyBytes: maxBodyBytes, patterns: make(map[string]*regexp.Regexp)}
+}
+
+// Validator is a struct that validates the requests against the operations of a document
+type Validator struct {
+	// doc is the document describing the operations
+	doc *Document
+	// rt is the root router
+	rt chi.Routes
+	// maxBodyBytes is the largest body read, a larger one is answered 413
+	maxBodyBytes int64
+	// patterns are the compiled patterns of the schemas, by pattern
+	patterns map[string]*regexp.Regexp
+}
+
+// Middleware is a method that returns a middleware answering 400 with every invalid field when the path,
+// query or body of a request does not match the schema of its operation, 413 when the body is larger than
+// maxBodyBytes. The parameters are added to the request context converted to their type (tools.ParamsFromContext),
+// so the handlers need not parse them. The headers are left to the middlewares owning them (credentials, tenant),
+// which must run before it so the callers they refuse are not read nor told the schema
+func (v *Validator) Middleware() func(http.Handler) http.Handler {
+	// - compile the patterns upfront, the middleware only reads the map
+	v.compile()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.NewRouteContext()
+			if !v.rt.Match(rctx, r.Method, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			item, ok := v.doc.Paths[NormalizePath(rctx.RoutePattern())]
+			if !ok || item.Operation(r.Method) == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			op := item.Operation(r.Method)
+
+			// - parameters
+			params := make(tools.Params)
+			var errs []FieldError
+			query := r.URL.Query()
+			for _, p := range op.Parameters {
+				var raw string
+				var present bool
+				switch p.In {
+				case "path":
+					raw = rctx.URLParam(p.Name)
+					present = raw != ""
+				case "query":
+					present = query.Has(p.Name)
+					raw = query.Get(p.Name)
+				default:
+					continue
+				}
+				if !present {
+					if p.Required {
+						errs = append(errs, FieldError{In: p.In, Field: p.Name, Message: "is required"})
+					}
+					continue
+				}
+				value, msg := v.parseParam(raw, p.Schema)
+				if msg != "" {
+					errs = append(errs, FieldError{In: p.In, Field: p.Name, Message: msg})
+					continue
+				}
+				params[p.Name] = value
+			}
+
+			// - body, restored for the handler
+			if op.RequestBody != nil && len(errs) == 0 {
+				b, err := io.ReadAll(http.MaxBytesReader(w, r.Body, v.maxBodyBytes))
+				if err != nil {
+					var tooLarge *http.MaxBytesError
+					switch {
+					case errors.As(err, &tooLarge):
+						slog.WarnContext(r.Context(), "request body too large", slog.String("operation", op.OperationID), slog.Int64("max_bytes", v.maxBodyBytes))
+						response.Error(w, http.StatusRequestEntityTooLarge, internal.ErrorRequestTooLarge.Error())
+					default:
+						response.Error(w, http.StatusBadRequest, internal.ErrorInvalidBodyRequest.Error())
+					}
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(b))
+				errs = append(errs, v.validateBody(b, op.RequestBody)...)
+			}
+
+			if len(errs) > 0 {
+				slog.WarnContext(r.Context(), "invalid request", slog.String("operation", op.OperationID), slog.Any("errors", errs))
+				response.JSON(w, http.StatusBadRequest, map[string]any{
+					"status":  http.StatusText(http.StatusBadRequest),
+					"message": internal.ErrorInvalidRequest.Error(),
+					"errors":  errs,
+				})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(tools.WithParams(r.Context(), params)))
+		})
+	}
+}
+
+// parseParam is a method that converts a parameter to the type of its schema,
+// msg is why it does not match the schema, empty if it does
+func (v *Validator) parseParam(raw string, s *Schema) (value any, msg string) {
+	s = v.resolve(s)
+	switch s.Type {
+	case "integer":
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			msg = "must be an integer"
+			return
+		}
+		value, msg = n, v.checkNumber(float64(n), s)
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			msg = "must be a number"
+			return
+		}
+		value, msg = n, v.checkNumber(n, s)
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			msg = "must be a boolean"
+			return
+		}
+		value = b
+	case "array":
+		value, msg = v.parseArray(raw, s)
+	default:
+		if msg = v.checkString(raw, s); msg != "" {
+			return
+		}
+		value = raw
+		if s.Format == FormatRange {
+			value, msg = parseRange(raw)
+		}
+	}
+	return
+}
+
+// parseArray is a method that converts a comma separated parameter (style form, not exploded) to a slice,
+// []string for items of type string and []any otherwise
+func (v *Validator) parseArray(raw string, s *Schema) (value any, msg string) {
+	items := strings.Split(raw, ",")
+	switch {
+	case s.MinItems != nil && len(items) < *s.MinItems:
+		msg = fmt.Sprintf("must have at least %d items", *s.MinItems)
+		return
+	case s.MaxItems != nil && len(items) > *s.MaxItems:
+		msg = fmt.Sprintf("must have at most %d items", *s.MaxItems)
+		return
+	}
+
+	strs := make([]string, 0, len(items))
+	values := make([]any, 0, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		iv, imsg := v.parseParam(item, s.Items)
+		if imsg != "" {
+			msg = fmt.Sprintf("%q %s", item, imsg)
+			return
+		}
+		strs = append(strs, item)
+		values = append(values, iv)
+	}
+	if v.resolve(s.Items).Type == "string" {
+		value = strs
+		return
+	}
+	value = values
+	return
+}
+
+// parseRange is a function that parses a range written {min}-{max}
+func parseRange(raw string) (r tools.Range, msg string) {
+	lo, hi, ok := strings.Cut(raw, "-")
+	var errLo, errHi error
+	if ok {
+		r.Min, errLo = strconv.ParseFloat(lo, 64)
+		r.Max, errHi = strconv.ParseFloat(hi, 64)
+	}
+	switch {
+	case !ok || errLo != nil || errHi != nil:
+		msg = "must be a range as {min}-{max}"
+	case r.Min > r.Max:
+		msg = "minimum must not be greater than maximum"
+	}
+	return
+}
+
+// validateBody is a method that validates a JSON body against the schema of the request body
+func (v *Validator) validateBody(b []byte, rb *RequestBody) (errs []FieldError) {
+	if len(bytes.TrimSpace(b)) == 0 {
+		if rb.Required {
+			errs = append(errs, FieldError{In: "body", Field: "body", Message: "is required"})
+		}
+		return
+	}
+	mt, ok := rb.Content["application/json"]
+	if !ok {
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var body any
+	if err := dec.Decode(&body); err != nil {
+		errs = append(errs, FieldError{In: "body", Field: "body", Message: "must be valid JSON"})
+		return
+	}
+	v.validateValue(body, mt.Schema, "", &errs)
+	return
+}
+
+// validateValue is a method that appends to errs every mismatch between a decoded JSON value and a schema
+func (v *Validator) validateValue(value any, s *Schema, field string, errs *[]FieldError) {
+	s = v.resolve(s)
+	fail := func(msg string) {
+		if field == "" {
+			field = "body"
+		}
+		*errs = append(*errs, FieldError{In: "body", Field: field, Message: msg})
+	}
+
+	if value == nil {
+		if !s.Nullable && s.Type != "" {
+			fail("must not be null")
+		}
+		return
+	}
+	switch s.Type {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				*errs = append(*errs, FieldError{In: "body", Field: join(field, name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if p, ok := s.Properties[name]; ok {
+				v.validateValue(obj[name], p, join(field, name), errs)
+			} else if s.AdditionalProperties != nil {
+				v.validateValue(obj[name], s.AdditionalProperties, join(field, name), errs)
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			fail(fmt.Sprintf("must have at least %d items", *s.MinItems))
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			fail(fmt.Sprintf("must have at most %d items", *s.MaxItems))
+		}
+		for i, item := range arr {
+			v.validateValue(item, s.Items, fmt.Sprintf("%s[%d]", field, i), errs)
+		}
+	case "integer", "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			if s.Type == "integer" {
+				fail("must be an integer")
+			} else {
+				fail("must be a number")
+			}
+			return
+		}
+		if s.Type == "integer" {
+			if _, err := n.Int64(); err != nil {
+				fail("must be an integer")
+				return
+			}
+		}
+		f, _ := n.Float64()
+		if msg := v.checkNumber(f, s); msg != "" {
+			fail(msg)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be a boolean")
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		if msg := v.checkString(str, s); msg != "" {
+			fail(msg)
+		}
+	}
+}
+
+// checkNumber is a method that returns why a number does not match the bounds of a schema, empty if it does
+func (v *Validator) checkNumber(n float64, s *Schema) string {
+	switch {
+	case s.Minimum != nil && n < *s.Minimum:
+		return "must be at least " + strconv.FormatFloat(*s.Minimum, 'f', -1, 64)
+	case s.Maximum != nil && n > *s.Maximum:
+		return "must be at most " + strconv.FormatFloat(*s.Maximum, 'f', -1, 64)
+	}
+	return ""
+}
+
+// checkString is a method that returns why a string does not match the constraints of a schema, empty if it does
+func (v *Validator) checkString(str string, s *Schema) string {
+	switch {
+	case s.MinLength != nil && len(str) < *s.MinLength:
+		return fmt.Sprintf("must have at least %d characters", *s.MinLength)
+	case s.MaxLength != nil && len(str) > *s.MaxLength:
+		return fmt.Sprintf("must have at most %d characters", *s.MaxLength)
+	case s.Pattern != "" && v.patterns[s.Pattern] != nil && !v.patterns[s.Pattern].MatchString(str):
+		return "must match " + s.Pattern
+	case len(s.Enum) > 0 && !containsAny(s.Enum, str):
+		return fmt.Sprintf("must be one of %v", s.Enum)
+	}
+	return ""
+}
+
+// resolve is a method that follows the reference of a schema to the component schema
+func (v *Validator) resolve(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = v.doc.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	if s == nil {
+		return &Schema{}
+	}
+	return s
+}
+
+// compile is a method that compiles the patterns of every schema of the document
+func (v *Validator) compile() {
+	var visit func(s *Schema)
+	visit = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Pattern != "" {
+			if _, ok := v.patterns[s.Pattern]; !ok {
+				v.patterns[s.Pattern] = regexp.MustCompile(s.Pattern)
+			}
+		}
+		for _, p := range s.Properties {
+			visit(p)
+		}
+		visit(s.Items)
+		visit(s.AdditionalProperties)
+	}
+
+	for _, s := range v.doc.Components.Schemas {
+		visit(s)
+	}
+	for _, item := range v.doc.Paths {
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if op == nil {
+				continue
+			}
+			for _, p := range op.Parameters {
+				visit(p.Schema)
+			}
+			if op.RequestBody != nil {
+				for _, mt := range op.RequestBody.Content {
+					visit(mt.Schema)
+				}
+			}
+		}
+	}
+}
+
+// join is a function that returns the JSON path of a property of field, the body itself is the empty path
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+// containsAny is a function that reports whether values holds s
+func containsAny(values []any, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"context"
+	"errors"
+)
+
+// Roles granted by the tokens of the gateway
+const (
+	// RoleViewer may only read the fleet
+	RoleViewer = "viewer"
+	// RoleOperator may read the fleet and create or update vehicles
+	RoleOperator = "operator"
+	// RoleFleetAdmin may do everything, deletes and administration included
+	RoleFleetAdmin = "fleet-admin"
+)
+
+// Roles are all the roles a token can be mapped to
+var Roles = []string{RoleViewer, RoleOperator, RoleFleetAdmin}
+
+// Authentication methods of a principal
+const (
+	// AuthMethodAPIKey is set on principals authenticated by an API key
+	AuthMethodAPIKey = "api_key"
+	// AuthMethodJWT is set on principals authenticated by a JWT
+	AuthMethodJWT = "jwt"
+)
+
+// Principal is a struct that represents the authenticated caller of a request
+type Principal struct {
+	// Subject is the identity of the caller: the key ID of an API key, the sub claim of a JWT
+	Subject string
+	// Name is a readable description of the caller, if any
+	Name string
+	// Method is how the caller was authenticated
+	Method string
+	// Scopes are the scopes of an API key
+	Scopes []string
+	// Roles are the roles of a JWT
+	Roles []string
+	// Tenant is the tenant the credentials are bound to, empty if they may act on any tenant
+	Tenant string
+}
+
+// HasScope is a method that reports whether the principal was granted a scope
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAnyRole is a method that reports whether the principal has one of roles
+func (p Principal) HasAnyRole(roles ...string) bool {
+	for _, r := range p.Roles {
+		for _, role := range roles {
+			if r == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// principalKey is the key of the principal in a context
+type principalKey struct{}
+
+// WithPrincipal is a function that returns a copy of ctx carrying the authenticated caller
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext is a function that returns the authenticated caller carried by ctx
+func PrincipalFromContext(ctx context.Context) (p Principal, ok bool) {
+	p, ok = ctx.Value(principalKey{}).(Principal)
+	return
+}
+
+// Errors in authentication and authorization
+var (
+	ErrorTokenInvalid  = errors.New("Invalid token")
+	ErrorTokenExpired  = errors.New("Token expired")
+	ErrorJWTKeys       = errors.New("JWT verification keys could not be loaded")
+	ErrorForbidden     = errors.New("Caller is not allowed to invoke this handler")
+	ErrorPolicyMissing = errors.New("No policy for handler")
+)
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+// Classes of routes limited separately
+const (
+	// RateClassRead are the routes that read vehicles
+	RateClassRead = "read"
+	// RateClassWrite are the routes that create, update or delete a vehicle
+	RateClassWrite = "write"
+	// RateClassBatch are the routes that create vehicles in bulk
+	RateClassBatch = "batch"
+)
+
+// RateClasses are all the classes of routes
+var RateClasses = []string{RateClassRead, RateClassWrite, RateClassBatch}
+
+// RateDecision is a struct that represents the outcome of taking a token for a request
+type RateDecision struct {
+	// Allowed reports whether the request may proceed
+	Allowed bool
+	// Limit is the size of the bucket
+	Limit int
+	// Remaining is the number of tokens left in the bucket
+	Remaining int
+	// Reset is the time until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is the time until the request may be retried, set when not allowed
+	RetryAfter time.Duration
+	// Err is ErrorRateLimited or ErrorQuotaExceeded when not allowed
+	Err error
+}
+
+// ClientUsage is a struct that represents the requests of a client during a day
+type ClientUsage struct {
+	// Client is the key the client is limited by: an API key, a token subject or an IP
+	Client string
+	// Day is the UTC day of the usage, formatted as 2006-01-02
+	Day string
+	// Requests are the allowed requests by class
+	Requests map[string]int
+	// Throttled are the requests rejected by the rate limits by class
+	Throttled map[string]int
+	// QuotaRejected are the requests rejected by the daily quotas by class
+	QuotaRejected map[string]int
+	// QuotaRemaining are the requests left today by class, only for the classes with a quota
+	QuotaRemaining map[string]int
+}
+
+// RateLimiter is an interface that represents a limiter of the requests of each client
+type RateLimiter interface {
+	// Take is a method that takes a token of a class for a client
+	Take(client string, class string) (d RateDecision)
+
+	// Usage is a method that returns the usage of today of every client
+	Usage() (u []ClientUsage)
+}
+
+// Errors in rate limiters
+var (
+	ErrorRateLimited   = errors.New("Rate limit exceeded")
+	ErrorQuotaExceeded = errors.New("Daily quota exceeded")
+)
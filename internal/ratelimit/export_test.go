@@ -0,0 +1,15 @@
+package ratelimit
+
+import "time"
+
+// SetNow is a function that replaces the clock of a token bucket, for the tests to control the time
+func SetNow(l *TokenBucket, now func() time.Time) {
+	l.now = now
+}
+
+// Buckets is a function that returns the number of buckets a token bucket holds
+func Buckets(l *TokenBucket) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
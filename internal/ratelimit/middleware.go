@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"app/internal"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// Middleware is a function that returns a middleware taking a token of class for the client of each request,
+// answering 429 with Retry-After when the client is over its rate limit or daily quota.
+// It must run after the authentication so the clients are keyed by their credentials rather than their IP
+func Middleware(rl internal.RateLimiter, class string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d := rl.Take(ClientKey(r), class)
+
+			// - headers of draft-ietf-httpapi-ratelimit-headers
+			if d.Limit > 0 {
+				w.Header().Set("RateLimit-Limit", strconv.Itoa(d.Limit))
+				w.Header().Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+				w.Header().Set("RateLimit-Reset", seconds(d.Reset))
+			}
+			if !d.Allowed {
+				slog.WarnContext(r.Context(), "request rejected by rate limiter",
+					slog.String("client", ClientKey(r)),
+					slog.String("class", class),
+					slog.String("error", d.Err.Error()),
+				)
+				w.Header().Set("Retry-After", seconds(d.RetryAfter))
+				response.Error(w, http.StatusTooManyRequests, d.Err.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientKey is a function that returns the key a request is limited by:
+// the authenticated caller if any, otherwise the IP of the connection
+func ClientKey(r *http.Request) string {
+	if p, ok := internal.PrincipalFromContext(r.Context()); ok {
+		return p.Method + ":" + p.Subject
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// seconds is a function that formats a duration as whole seconds, rounded up
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,112 @@
+package ratelimit_test
+
+import (
+	"app/internal"
+	"app/internal/ratelimit"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// limiter is a struct that implements the RateLimiter interface returning a decision
+type limiter struct {
+	d internal.RateDecision
+}
+
+// Take is a method that returns the decision of the limiter
+func (l *limiter) Take(client string, class string) internal.RateDecision {
+	return l.d
+}
+
+// Usage is a method that returns no usage
+func (l *limiter) Usage() []internal.ClientUsage { return nil }
+
+func TestMiddleware(t *testing.T) {
+	cases := []struct {
+		name    string
+		d       internal.RateDecision
+		status  int
+		headers map[string]string
+		body    string
+	}{
+		{
+			name:    "allowed, the durations rounded up",
+			d:       internal.RateDecision{Allowed: true, Limit: 10, Remaining: 9, Reset: 1500 * time.Millisecond},
+			status:  http.StatusOK,
+			headers: map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "9", "RateLimit-Reset": "2", "Retry-After": ""},
+		},
+		{
+			name:    "allowed without limit",
+			d:       internal.RateDecision{Allowed: true},
+			status:  http.StatusOK,
+			headers: map[string]string{"RateLimit-Limit": "", "RateLimit-Remaining": "", "RateLimit-Reset": "", "Retry-After": ""},
+		},
+		{
+			name:    "throttled",
+			d:       internal.RateDecision{Limit: 10, Reset: 6 * time.Second, RetryAfter: 100 * time.Millisecond, Err: internal.ErrorRateLimited},
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "0", "RateLimit-Reset": "6", "Retry-After": "1"},
+			body:    internal.ErrorRateLimited.Error(),
+		},
+		{
+			name:    "over the daily quota",
+			d:       internal.RateDecision{Limit: 10, RetryAfter: 3 * time.Hour, Err: internal.ErrorQuotaExceeded},
+			status:  http.StatusTooManyRequests,
+			headers: map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "0", "RateLimit-Reset": "0", "Retry-After": "10800"},
+			body:    internal.ErrorQuotaExceeded.Error(),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rl := &limiter{d: c.d}
+			hd := ratelimit.Middleware(rl, "read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			res := httptest.NewRecorder()
+			hd.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if res.Code != c.status {
+				t.Fatalf("status = %d, want %d", res.Code, c.status)
+			}
+			for name, value := range c.headers {
+				if got := res.Header().Get(name); got != value {
+					t.Errorf("header %s = %q, want %q", name, got, value)
+				}
+			}
+			if !strings.Contains(res.Body.String(), c.body) {
+				t.Errorf("body = %s, want it to contain %q", res.Body, c.body)
+			}
+		})
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		p      *internal.Principal
+		remote string
+		key    string
+	}{
+		{name: "API key", p: &internal.Principal{Subject: "k1", Method: internal.AuthMethodAPIKey}, remote: "10.0.0.1:1234", key: "api_key:k1"},
+		{name: "token", p: &internal.Principal{Subject: "u1", Method: internal.AuthMethodJWT}, remote: "10.0.0.1:1234", key: "jwt:u1"},
+		{name: "IP without its port", remote: "10.0.0.1:1234", key: "ip:10.0.0.1"},
+		{name: "IPv6", remote: "[::1]:1234", key: "ip:::1"},
+		{name: "address without port", remote: "10.0.0.1", key: "ip:10.0.0.1"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = c.remote
+			if c.p != nil {
+				req = req.WithContext(internal.WithPrincipal(req.Context(), *c.p))
+			}
+			if key := ratelimit.ClientKey(req); key != c.key {
+				t.Errorf("key = %q, want %q", key, c.key)
+			}
+		})
+	}
+}
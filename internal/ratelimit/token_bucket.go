@@ -0,0 +1,218 @@
+package ratelimit
+
+import (
+	"app/internal"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Limit is a struct that represents the limits of a class of routes
+type Limit struct {
+	// PerMinute is the rate the bucket refills at, zero disables the rate limit
+	PerMinute int
+	// Burst is the size of the bucket, defaults to PerMinute
+	Burst int
+	// DailyQuota is the number of requests allowed per UTC day, zero disables the quota
+	DailyQuota int
+}
+
+// NewTokenBucket is a function that returns a new instance of TokenBucket
+func NewTokenBucket(limits map[string]Limit) *TokenBucket {
+	// default values
+	l := make(map[string]Limit, len(limits))
+	for class, limit := range limits {
+		if limit.Burst <= 0 {
+			limit.Burst = limit.PerMinute
+		}
+		l[class] = limit
+	}
+
+	return &TokenBucket{
+		limits:  l,
+		buckets: make(map[bucketKey]*bucket),
+		usage:   make(map[string]*internal.ClientUsage),
+		now:     time.Now,
+	}
+}
+
+// TokenBucket is a struct that implements the RateLimiter interface with a token bucket per client and class
+type TokenBucket struct {
+	// limits are the limits by class
+	limits map[string]Limit
+	// mu guards the fields below
+	mu sync.Mutex
+	// buckets are the buckets by client and class
+	buckets map[bucketKey]*bucket
+	// usage is the usage of the day by client
+	usage map[string]*internal.ClientUsage
+	// day is the UTC day the usage belongs to
+	day string
+	// lastSweep is the last time the idle buckets were removed
+	lastSweep time.Time
+	// now returns the current time
+	now func() time.Time
+}
+
+// bucketKey is the key of a bucket
+type bucketKey struct {
+	client string
+	class  string
+}
+
+// bucket is a struct that represents the tokens of a client for a class
+type bucket struct {
+	// tokens are the tokens available at updated
+	tokens float64
+	// updated is the last time tokens was refilled
+	updated time.Time
+}
+
+// sweepInterval is the time between removals of the idle buckets
+const sweepInterval = 10 * time.Minute
+
+// Take is a method that takes a token of a class for a client, counting it against the daily quota
+func (l *TokenBucket) Take(client string, class string) (d internal.RateDecision) {
+	limit, ok := l.limits[class]
+	if !ok {
+		d.Allowed = true
+		return
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rollover(now)
+	l.sweep(now)
+	u := l.clientUsage(client)
+
+	// - daily quota, checked first so a client over quota does not drain its bucket
+	if limit.DailyQuota > 0 && u.Requests[class] >= limit.DailyQuota {
+		u.QuotaRejected[class]++
+		d.Err = internal.ErrorQuotaExceeded
+		d.RetryAfter = nextDay(now).Sub(now)
+		d.Limit = limit.Burst
+		return
+	}
+
+	// - rate limit
+	if limit.PerMinute > 0 {
+		rate := float64(limit.PerMinute) / float64(time.Minute)
+		b := l.bucket(bucketKey{client: client, class: class}, limit, now)
+		d.Limit = limit.Burst
+		if b.tokens < 1 {
+			u.Throttled[class]++
+			d.Err = internal.ErrorRateLimited
+			d.RetryAfter = time.Duration(math.Ceil((1 - b.tokens) / rate))
+			d.Reset = time.Duration(math.Ceil((float64(limit.Burst) - b.tokens) / rate))
+			return
+		}
+		b.tokens--
+		d.Remaining = int(b.tokens)
+		d.Reset = time.Duration(math.Ceil((float64(limit.Burst) - b.tokens) / rate))
+	}
+
+	u.Requests[class]++
+	d.Allowed = true
+	return
+}
+
+// Usage is a method that returns the usage of today of every client sorted by client
+func (l *TokenBucket) Usage() (u []internal.ClientUsage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rollover(l.now())
+	u = make([]internal.ClientUsage, 0, len(l.usage))
+	for _, cu := range l.usage {
+		c := internal.ClientUsage{
+			Client:         cu.Client,
+			Day:            cu.Day,
+			Requests:       copyCounts(cu.Requests),
+			Throttled:      copyCounts(cu.Throttled),
+			QuotaRejected:  copyCounts(cu.QuotaRejected),
+			QuotaRemaining: make(map[string]int),
+		}
+		for class, limit := range l.limits {
+			if limit.DailyQuota > 0 {
+				c.QuotaRemaining[class] = max(limit.DailyQuota-cu.Requests[class], 0)
+			}
+		}
+		u = append(u, c)
+	}
+	sort.Slice(u, func(i, j int) bool { return u[i].Client < u[j].Client })
+	return
+}
+
+// bucket is a method that returns the bucket of a key refilled up to now, the caller must hold the lock
+func (l *TokenBucket) bucket(key bucketKey, limit Limit, now time.Time) (b *bucket) {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+		return
+	}
+
+	rate := float64(limit.PerMinute) / float64(time.Minute)
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+float64(now.Sub(b.updated))*rate)
+	b.updated = now
+	return
+}
+
+// clientUsage is a method that returns the usage of a client, the caller must hold the lock
+func (l *TokenBucket) clientUsage(client string) (u *internal.ClientUsage) {
+	u, ok := l.usage[client]
+	if !ok {
+		u = &internal.ClientUsage{
+			Client:        client,
+			Day:           l.day,
+			Requests:      make(map[string]int),
+			Throttled:     make(map[string]int),
+			QuotaRejected: make(map[string]int),
+		}
+		l.usage[client] = u
+	}
+	return
+}
+
+// rollover is a method that resets the usage when the UTC day changes, the caller must hold the lock
+func (l *TokenBucket) rollover(now time.Time) {
+	day := now.UTC().Format(time.DateOnly)
+	if day != l.day {
+		l.day = day
+		l.usage = make(map[string]*internal.ClientUsage)
+	}
+}
+
+// sweep is a method that removes the buckets that are full again, the caller must hold the lock
+func (l *TokenBucket) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		limit := l.limits[key.class]
+		rate := float64(limit.PerMinute) / float64(time.Minute)
+		if b.tokens+float64(now.Sub(b.updated))*rate >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// nextDay is a function that returns the start of the next UTC day
+func nextDay(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+}
+
+// copyCounts is a function that returns a copy of counts by class
+func copyCounts(counts map[string]int) (c map[string]int) {
+	c = make(map[string]int, len(counts))
+	for class, n := range counts {
+		c[class] = n
+	}
+	return
+}
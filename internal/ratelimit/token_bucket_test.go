@@ -0,0 +1,209 @@
+package ratelimit_test
+
+import (
+	"app/internal"
+	"app/internal/ratelimit"
+	"errors"
+	"testing"
+	"time"
+)
+
+// clock is a struct that represents a fake clock, moved forward by the tests
+type clock struct {
+	t time.Time
+}
+
+// now is a method that returns the time of the clock
+func (c *clock) now() time.Time { return c.t }
+
+// noon is the time the clocks of the tests start at, half a day before the UTC rollover
+var noon = time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+// newTokenBucket is a function that returns a token bucket of limits on a fake clock set at t
+func newTokenBucket(limits map[string]ratelimit.Limit, t time.Time) (l *ratelimit.TokenBucket, c *clock) {
+	c = &clock{t: t}
+	l = ratelimit.NewTokenBucket(limits)
+	ratelimit.SetNow(l, c.now)
+	return
+}
+
+func TestTokenBucket_Take(t *testing.T) {
+	// step is a take after the clock moved forward by advance
+	type step struct {
+		advance    time.Duration
+		allowed    bool
+		remaining  int
+		reset      time.Duration
+		retryAfter time.Duration
+		err        error
+	}
+	cases := []struct {
+		name     string
+		limit    ratelimit.Limit
+		size     int
+		steps    []step
+		requests int
+		throttle int
+		rejected int
+	}{
+		{
+			name:  "burst, then refill up to the burst",
+			limit: ratelimit.Limit{PerMinute: 60, Burst: 3},
+			size:  3,
+			steps: []step{
+				{allowed: true, remaining: 2, reset: time.Second},
+				{allowed: true, remaining: 1, reset: 2 * time.Second},
+				{allowed: true, remaining: 0, reset: 3 * time.Second},
+				{retryAfter: time.Second, reset: 3 * time.Second, err: internal.ErrorRateLimited},
+				{advance: 500 * time.Millisecond, retryAfter: 500 * time.Millisecond, reset: 2500 * time.Millisecond, err: internal.ErrorRateLimited},
+				{advance: 500 * time.Millisecond, allowed: true, remaining: 0, reset: 3 * time.Second},
+				{advance: time.Hour, allowed: true, remaining: 2, reset: time.Second},
+			},
+			requests: 5,
+			throttle: 2,
+		},
+		{
+			name:  "burst defaults to the rate",
+			limit: ratelimit.Limit{PerMinute: 2},
+			size:  2,
+			steps: []step{
+				{allowed: true, remaining: 1, reset: 30 * time.Second},
+				{allowed: true, remaining: 0, reset: time.Minute},
+				{retryAfter: 30 * time.Second, reset: time.Minute, err: internal.ErrorRateLimited},
+				{advance: 15 * time.Second, retryAfter: 15 * time.Second, reset: 45 * time.Second, err: internal.ErrorRateLimited},
+			},
+			requests: 2,
+			throttle: 2,
+		},
+		{
+			name:  "daily quota checked before the bucket",
+			limit: ratelimit.Limit{PerMinute: 60, Burst: 1, DailyQuota: 1},
+			size:  1,
+			steps: []step{
+				{allowed: true, remaining: 0, reset: time.Second},
+				{retryAfter: 12 * time.Hour, err: internal.ErrorQuotaExceeded},
+				{advance: time.Hour, retryAfter: 11 * time.Hour, err: internal.ErrorQuotaExceeded},
+			},
+			requests: 1,
+			rejected: 2,
+		},
+		{
+			name:  "daily quota without rate limit",
+			limit: ratelimit.Limit{DailyQuota: 2},
+			steps: []step{
+				{allowed: true},
+				{allowed: true},
+				{retryAfter: 12 * time.Hour, err: internal.ErrorQuotaExceeded},
+			},
+			requests: 2,
+			rejected: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			l, clk := newTokenBucket(map[string]ratelimit.Limit{"read": c.limit}, noon)
+			for i, s := range c.steps {
+				clk.t = clk.t.Add(s.advance)
+				d := l.Take("k1", "read")
+				if d.Allowed != s.allowed || !errors.Is(d.Err, s.err) || d.Limit != c.size || d.Remaining != s.remaining {
+					t.Errorf("step %d: decision = %+v, want allowed %t, error %v, limit %d, remaining %d", i+1, d, s.allowed, s.err, c.size, s.remaining)
+				}
+				// - the durations are computed in floating point, compared to the millisecond
+				if d.Reset.Round(time.Millisecond) != s.reset || d.RetryAfter.Round(time.Millisecond) != s.retryAfter {
+					t.Errorf("step %d: reset and retry after = %s %s, want %s %s", i+1, d.Reset, d.RetryAfter, s.reset, s.retryAfter)
+				}
+			}
+
+			u := l.Usage()
+			if len(u) != 1 || u[0].Requests["read"] != c.requests || u[0].Throttled["read"] != c.throttle || u[0].QuotaRejected["read"] != c.rejected {
+				t.Errorf("usage = %+v, want %d requests, %d throttled and %d rejected", u, c.requests, c.throttle, c.rejected)
+			}
+		})
+	}
+}
+
+func TestTokenBucket_Take_Classes(t *testing.T) {
+	l, _ := newTokenBucket(map[string]ratelimit.Limit{"write": {PerMinute: 1}}, noon)
+
+	// - a class without limit is always allowed and has no headers
+	for i := 0; i < 3; i++ {
+		if d := l.Take("k1", "read"); !d.Allowed || d.Limit != 0 {
+			t.Errorf("decision = %+v, want allowed without limit", d)
+		}
+	}
+	// - the buckets are per client and class
+	for _, client := range []string{"k1", "k2"} {
+		if d := l.Take(client, "write"); !d.Allowed {
+			t.Errorf("decision of %s = %+v, want allowed", client, d)
+		}
+	}
+	if d := l.Take("k1", "write"); d.Allowed {
+		t.Errorf("decision = %+v, want throttled", d)
+	}
+}
+
+func TestTokenBucket_Rollover(t *testing.T) {
+	// - the clock is a minute before the UTC midnight, read in a zone where the day is already over
+	start := time.Date(2024, time.March, 10, 23, 59, 0, 0, time.UTC).In(time.FixedZone("UTC+2", 2*60*60))
+	l, clk := newTokenBucket(map[string]ratelimit.Limit{"read": {DailyQuota: 1}}, start)
+
+	if d := l.Take("k1", "read"); !d.Allowed {
+		t.Fatalf("decision = %+v, want allowed", d)
+	}
+	d := l.Take("k1", "read")
+	if !errors.Is(d.Err, internal.ErrorQuotaExceeded) || d.RetryAfter != time.Minute {
+		t.Fatalf("decision = %+v, want the quota exceeded until the UTC midnight", d)
+	}
+	u := l.Usage()
+	if len(u) != 1 || u[0].Day != "2024-03-10" || u[0].QuotaRemaining["read"] != 0 {
+		t.Fatalf("usage = %+v, want the one of 2024-03-10 without quota left", u)
+	}
+
+	// - at the UTC midnight the usage is reset
+	clk.t = clk.t.Add(time.Minute)
+	if u := l.Usage(); len(u) != 0 {
+		t.Errorf("usage = %+v, want none", u)
+	}
+	if d := l.Take("k1", "read"); !d.Allowed {
+		t.Errorf("decision = %+v, want allowed", d)
+	}
+	u = l.Usage()
+	if len(u) != 1 || u[0].Day != "2024-03-11" || u[0].Requests["read"] != 1 || u[0].QuotaRemaining["read"] != 0 {
+		t.Errorf("usage = %+v, want the one of 2024-03-11 of one request", u)
+	}
+}
+
+func TestTokenBucket_Sweep(t *testing.T) {
+	l, clk := newTokenBucket(map[string]ratelimit.Limit{
+		"fast": {PerMinute: 60, Burst: 2},
+		"slow": {PerMinute: 1, Burst: 20},
+	}, noon)
+
+	// - the buckets of the slow class need 20 minutes to be full again, the fast ones 2 seconds
+	for i := 0; i < 20; i++ {
+		l.Take("k1", "slow")
+	}
+	l.Take("k1", "fast")
+	l.Take("k2", "fast")
+	if n := ratelimit.Buckets(l); n != 3 {
+		t.Fatalf("buckets = %d, want 3", n)
+	}
+
+	// - the buckets are not swept before the interval
+	clk.t = clk.t.Add(time.Minute)
+	l.Take("k3", "fast")
+	if n := ratelimit.Buckets(l); n != 4 {
+		t.Fatalf("buckets = %d, want 4 before the sweep", n)
+	}
+
+	// - the full buckets are swept, the slow one is still refilling
+	clk.t = clk.t.Add(10 * time.Minute)
+	l.Take("k4", "fast")
+	if n := ratelimit.Buckets(l); n != 2 {
+		t.Fatalf("buckets = %d, want the slow one and the new one", n)
+	}
+	if d := l.Take("k1", "slow"); !d.Allowed || d.Remaining != 10 {
+		t.Errorf("decision = %+v, want the slow bucket kept with 11 tokens", d)
+	}
+}
@@ -0,0 +1,36 @@
+package internal
+
+import "errors"
+
+// RegistrationFormat is an interface that represents the format of the registrations (license plates) of a country
+type RegistrationFormat interface {
+	// Country is a method that returns the code of the country of the format
+	Country() (country string)
+
+	// Match is a method that reports whether a normalized registration has the format
+	Match(registration string) (ok bool)
+}
+
+// Registrations is an interface that represents the registrations allowed for the vehicles written, a registration
+// is stored normalized and is allowed when its normalized form has one of the formats
+type Registrations interface {
+	// Normalize is a method that returns the normalized form of a registration, the key of the uniqueness index
+	Normalize(registration string) (normalized string)
+
+	// CheckRegistration is a method that returns an error when a registration is empty or has none of the formats
+	CheckRegistration(registration string) (err error)
+}
+
+// RegistrationDuplicate is a struct that represents a normalized registration shared by several vehicles
+type RegistrationDuplicate struct {
+	// Registration is the normalized registration
+	Registration string
+	// IDs are the IDs of the vehicles sharing it, sorted
+	IDs []int
+}
+
+// Errors in registrations
+var (
+	ErrorInvalidRegistration        = errors.New("Registration is invalid")
+	ErrorInvalidRegistrationFormats = errors.New("Invalid registration formats")
+)
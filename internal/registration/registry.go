@@ -0,0 +1,174 @@
+package registration
+
+import (
+	"app/internal"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// CountryAny is the code of the format accepting any registration of letters and digits
+const CountryAny = "any"
+
+// DefaultCountries are the countries of the formats allowed when none are configured
+var DefaultCountries = []string{CountryAny}
+
+// Formats are the built-in formats by country code, of the registrations as normalized by a Registry
+var Formats = map[string]internal.RegistrationFormat{
+	CountryAny: mustPattern(CountryAny, `[A-Z0-9]{1,10}`),
+	"ar":       mustPattern("ar", `[A-Z]{3}[0-9]{3}|[A-Z]{2}[0-9]{3}[A-Z]{2}`),
+	"br":       mustPattern("br", `[A-Z]{3}[0-9][A-Z0-9][0-9]{2}`),
+	"co":       mustPattern("co", `[A-Z]{3}[0-9]{3}`),
+	"de":       mustPattern("de", `[A-Z]{1,3}[A-Z]{1,2}[0-9]{1,4}[EH]?`),
+	"es":       mustPattern("es", `[0-9]{4}[B-DF-HJ-NP-TV-Z]{3}`),
+	"fr":       mustPattern("fr", `[A-Z]{2}[0-9]{3}[A-Z]{2}`),
+	"it":       mustPattern("it", `[A-Z]{2}[0-9]{3}[A-Z]{2}`),
+	"mx":       mustPattern("mx", `[A-Z]{3}[0-9]{3,4}|[A-Z]{3}[0-9]{3}[A-Z]`),
+	"uk":       mustPattern("uk", `[A-Z]{2}[0-9]{2}[A-Z]{3}`),
+	"us":       mustPattern("us", `[A-Z0-9]{1,8}`),
+}
+
+// NewPattern is a function that returns a new instance of Pattern, matching the whole registration
+func NewPattern(country string, expr string) (p *Pattern, err error) {
+	country = strings.ToLower(strings.TrimSpace(country))
+	if country == "" {
+		err = fmt.Errorf("%w: %q has no country", internal.ErrorInvalidRegistrationFormats, expr)
+		return
+	}
+	re, err := regexp.Compile(`^(?:` + expr + `)$`)
+	if err != nil {
+		err = fmt.Errorf("%w: %s: %s", internal.ErrorInvalidRegistrationFormats, country, err)
+		return
+	}
+	p = &Pattern{country: country, re: re}
+	return
+}
+
+// mustPattern is a function that returns a new instance of Pattern, panicking on an invalid expression
+func mustPattern(country string, expr string) *Pattern {
+	p, err := NewPattern(country, expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Pattern is a struct that implements the RegistrationFormat interface with a regular expression
+type Pattern struct {
+	// country is the code of the country
+	country string
+	// re is the expression the whole registration must match
+	re *regexp.Regexp
+}
+
+// Country is a method that returns the code of the country of the format
+func (p *Pattern) Country() (country string) {
+	return p.country
+}
+
+// Match is a method that reports whether a normalized registration has the format
+func (p *Pattern) Match(registration string) (ok bool) {
+	return p.re.MatchString(registration)
+}
+
+// ParseFormats is a function that returns the formats of country=regexp entries, in the order given
+func ParseFormats(entries []string) (formats []internal.RegistrationFormat, err error) {
+	for _, entry := range entries {
+		country, expr, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(expr) == "" {
+			err = fmt.Errorf("%w: %q must be country=regexp", internal.ErrorInvalidRegistrationFormats, entry)
+			return
+		}
+
+		var p *Pattern
+		if p, err = NewPattern(country, strings.TrimSpace(expr)); err != nil {
+			return
+		}
+		formats = append(formats, p)
+	}
+	return
+}
+
+// NewRegistry is a function that returns a new instance of Registry allowing the formats of the countries given,
+// looked up in custom before the built-in Formats. There must be a country and no country twice
+func NewRegistry(countries []string, custom []internal.RegistrationFormat) (r *Registry, err error) {
+	if len(countries) == 0 {
+		err = fmt.Errorf("%w: there must be a country", internal.ErrorInvalidRegistrationFormats)
+		return
+	}
+
+	available := make(map[string]internal.RegistrationFormat, len(Formats)+len(custom))
+	for country, f := range Formats {
+		available[country] = f
+	}
+	for _, f := range custom {
+		available[f.Country()] = f
+	}
+
+	r = &Registry{}
+	seen := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		country = strings.ToLower(strings.TrimSpace(country))
+		f, ok := available[country]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%w: unknown country %q, must be one of %s", internal.ErrorInvalidRegistrationFormats, country, countriesOf(available))
+		case seen[country]:
+			return nil, fmt.Errorf("%w: %q is given twice", internal.ErrorInvalidRegistrationFormats, country)
+		}
+		seen[country] = true
+		r.formats = append(r.formats, f)
+	}
+	return
+}
+
+// Registry is a struct that implements the Registrations interface with formats fixed when it is built.
+// A registration is normalized upper case without whitespace, hyphens and dots
+type Registry struct {
+	// formats are the formats allowed, in the order given
+	formats []internal.RegistrationFormat
+}
+
+// Normalize is a method that returns the normalized form of a registration
+func (r *Registry) Normalize(registration string) (normalized string) {
+	return strings.Map(func(c rune) rune {
+		if unicode.IsSpace(c) || c == '-' || c == '.' {
+			return -1
+		}
+		return unicode.ToUpper(c)
+	}, registration)
+}
+
+// CheckRegistration is a method that returns an error when a registration is empty or has none of the formats
+func (r *Registry) CheckRegistration(registration string) (err error) {
+	normalized := r.Normalize(registration)
+	if normalized == "" {
+		err = fmt.Errorf("%w: it is required", internal.ErrorInvalidRegistration)
+		return
+	}
+
+	for _, f := range r.formats {
+		if f.Match(normalized) {
+			return
+		}
+	}
+
+	countries := make([]string, 0, len(r.formats))
+	for _, f := range r.formats {
+		countries = append(countries, f.Country())
+	}
+	err = fmt.Errorf("%w: %s does not have the format of %s", internal.ErrorInvalidRegistration, normalized, strings.Join(countries, ", "))
+	return
+}
+
+// countriesOf is a function that returns the countries of formats, sorted, as a comma separated list
+func countriesOf(formats map[string]internal.RegistrationFormat) string {
+	countries := make([]string, 0, len(formats))
+	for country := range formats {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	return strings.Join(countries, ", ")
+}
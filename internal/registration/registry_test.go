@@ -0,0 +1,146 @@
+package registration_test
+
+import (
+	"app/internal"
+	"app/internal/registration"
+	"errors"
+	"testing"
+)
+
+func TestParseFormats(t *testing.T) {
+	cases := []struct {
+		name      string
+		entries   []string
+		countries []string
+		err       error
+	}{
+		{name: "none"},
+		{name: "in the order given, countries lower cased", entries: []string{"NL=[A-Z]{2}[0-9]{2}[A-Z]{2}", " pt = [0-9]{2}[A-Z]{2}[0-9]{2}"}, countries: []string{"nl", "pt"}},
+		{name: "missing expression", entries: []string{"nl"}, err: internal.ErrorInvalidRegistrationFormats},
+		{name: "empty expression", entries: []string{"nl= "}, err: internal.ErrorInvalidRegistrationFormats},
+		{name: "missing country", entries: []string{" =[A-Z]+"}, err: internal.ErrorInvalidRegistrationFormats},
+		{name: "invalid expression", entries: []string{"nl=[A-Z"}, err: internal.ErrorInvalidRegistrationFormats},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			formats, err := registration.ParseFormats(c.entries)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if len(formats) != len(c.countries) {
+				t.Fatalf("formats = %v, want the ones of %v", formats, c.countries)
+			}
+			for i, f := range formats {
+				if f.Country() != c.countries[i] {
+					t.Errorf("country %d = %q, want %q", i, f.Country(), c.countries[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	custom, err := registration.ParseFormats([]string{"nl=[A-Z]{2}[0-9]{2}[A-Z]{2}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		countries []string
+		custom    []internal.RegistrationFormat
+		err       error
+	}{
+		{name: "default countries", countries: registration.DefaultCountries},
+		{name: "built-in countries, whatever their case", countries: []string{"ES", " fr "}},
+		{name: "custom country", countries: []string{"nl", "uk"}, custom: custom},
+		{name: "no country", err: internal.ErrorInvalidRegistrationFormats},
+		{name: "unknown country", countries: []string{"nl"}, err: internal.ErrorInvalidRegistrationFormats},
+		{name: "country given twice", countries: []string{"es", "ES"}, err: internal.ErrorInvalidRegistrationFormats},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := registration.NewRegistry(c.countries, c.custom)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if (r == nil) != (c.err != nil) {
+				t.Errorf("registry = %v, want one only without error", r)
+			}
+		})
+	}
+}
+
+func TestRegistry_CheckRegistration(t *testing.T) {
+	custom, err := registration.ParseFormats([]string{"es=[A-Z]{2}[0-9]{4}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name         string
+		countries    []string
+		custom       []internal.RegistrationFormat
+		registration string
+		err          error
+	}{
+		{name: "any: letters and digits", countries: registration.DefaultCountries, registration: "abc123"},
+		{name: "any: too long", countries: registration.DefaultCountries, registration: "ABCDEFGHIJK", err: internal.ErrorInvalidRegistration},
+		{name: "required", countries: registration.DefaultCountries, registration: " - ", err: internal.ErrorInvalidRegistration},
+		{name: "ar: old format", countries: []string{"ar"}, registration: "ABC123"},
+		{name: "ar: mercosur format", countries: []string{"ar"}, registration: "AB 123 CD"},
+		{name: "br: mercosur format", countries: []string{"br"}, registration: "ABC1D23"},
+		{name: "br: too short", countries: []string{"br"}, registration: "ABC123", err: internal.ErrorInvalidRegistration},
+		{name: "de: district and suffix", countries: []string{"de"}, registration: "M-AB 1234E"},
+		{name: "es: without vowels", countries: []string{"es"}, registration: "1234-BCD"},
+		{name: "es: vowels rejected", countries: []string{"es"}, registration: "1234ABC", err: internal.ErrorInvalidRegistration},
+		{name: "fr: hyphens removed", countries: []string{"fr"}, registration: "ab-123-cd"},
+		{name: "uk: spaces removed", countries: []string{"uk"}, registration: "AB12 CDE"},
+		{name: "uk: digits misplaced", countries: []string{"uk"}, registration: "AB123CD", err: internal.ErrorInvalidRegistration},
+		{name: "mx: trailing letter", countries: []string{"mx"}, registration: "ABC123D"},
+		{name: "us: dots removed", countries: []string{"us"}, registration: "7.ABC.123"},
+		{name: "any of several countries", countries: []string{"es", "fr"}, registration: "AB123CD"},
+		{name: "none of several countries", countries: []string{"es", "fr"}, registration: "ABC123", err: internal.ErrorInvalidRegistration},
+		{name: "custom format over the built-in one", countries: []string{"es"}, custom: custom, registration: "MA1234"},
+		{name: "built-in format replaced", countries: []string{"es"}, custom: custom, registration: "1234BCD", err: internal.ErrorInvalidRegistration},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := registration.NewRegistry(c.countries, c.custom)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := r.CheckRegistration(c.registration); !errors.Is(err, c.err) {
+				t.Errorf("CheckRegistration(%q) = %v, want %v", c.registration, err, c.err)
+			}
+		})
+	}
+}
+
+func TestRegistry_Normalize(t *testing.T) {
+	r, err := registration.NewRegistry(registration.DefaultCountries, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		registration string
+		normalized   string
+	}{
+		{registration: "ab-123-cd", normalized: "AB123CD"},
+		{registration: " 7.abc 123 ", normalized: "7ABC123"},
+		{registration: "AB\t12\nCDE", normalized: "AB12CDE"},
+		{registration: "", normalized: ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.registration, func(t *testing.T) {
+			if normalized := r.Normalize(c.registration); normalized != c.normalized {
+				t.Errorf("Normalize(%q) = %q, want %q", c.registration, normalized, c.normalized)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package reloader
+
+import (
+	"app/internal"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// NewVehicleGroup is a function that returns a new instance of VehicleGroup
+func NewVehicleGroup(reloaders ...*VehiclePolling) *VehicleGroup {
+	return &VehicleGroup{reloaders: reloaders}
+}
+
+// VehicleGroup is a struct that implements the VehicleReloader interface reloading the dataset of several tenants
+type VehicleGroup struct {
+	// reloaders are the reloaders of each tenant
+	reloaders []*VehiclePolling
+}
+
+// Reload is a method that reloads every dataset, a failed one keeps its previous contents
+// while the others are swapped
+func (g *VehicleGroup) Reload() (s internal.ReloadStatus, err error) {
+	var errs []error
+	for _, rl := range g.reloaders {
+		if _, rlErr := rl.Reload(); rlErr != nil {
+			errs = append(errs, rlErr)
+		}
+	}
+
+	s = g.Status()
+	err = errors.Join(errs...)
+	return
+}
+
+// Status is a method that returns the state of the reloads of every dataset combined,
+// the group has only succeeded once every dataset has. The merges of the datasets are combined as well
+func (g *VehicleGroup) Status() (s internal.ReloadStatus) {
+	var sources, lastErrors []string
+	for i, rl := range g.reloaders {
+		st := rl.Status()
+
+		sources = append(sources, st.Source)
+		if st.LastError != "" {
+			lastErrors = append(lastErrors, st.LastError)
+		}
+		s.DatasetSize += st.DatasetSize
+		s.Reloads += st.Reloads
+		s.Failures += st.Failures
+		if st.LastAttempt.After(s.LastAttempt) {
+			s.LastAttempt = st.LastAttempt
+		}
+		if st.LastDuration > s.LastDuration {
+			s.LastDuration = st.LastDuration
+		}
+		if i == 0 || st.LastSuccess.IsZero() || (!s.LastSuccess.IsZero() && st.LastSuccess.Before(s.LastSuccess)) {
+			s.LastSuccess = st.LastSuccess
+		}
+		if st.Merge != nil {
+			if s.Merge == nil {
+				s.Merge = &internal.VehicleMergeReport{Vehicles: make(map[string]int)}
+			}
+			s.Merge.Files = append(s.Merge.Files, st.Merge.Files...)
+			for file, n := range st.Merge.Vehicles {
+				s.Merge.Vehicles[file] += n
+			}
+			s.Merge.Conflicts = append(s.Merge.Conflicts, st.Merge.Conflicts...)
+		}
+	}
+	s.Source = strings.Join(sources, ";")
+	s.LastError = strings.Join(lastErrors, "; ")
+	return
+}
+
+// Watch is a method that watches the files of every dataset until ctx is done
+func (g *VehicleGroup) Watch(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, rl := range g.reloaders {
+		wg.Add(1)
+		go func(rl *VehiclePolling) {
+			defer wg.Done()
+			rl.Watch(ctx)
+		}(rl)
+	}
+	wg.Wait()
+}
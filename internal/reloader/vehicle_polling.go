@@ -0,0 +1,273 @@
+package reloader
+
+import (
+	"app/internal"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfigVehiclePolling is a struct that represents the configuration for VehiclePolling
+type ConfigVehiclePolling struct {
+	// Loader is the loader used to read the dataset
+	Loader internal.VehicleLoader
+	// Repository is the repository whose contents are swapped on each reload
+	Repository internal.VehicleRepository
+	// Tenant is the tenant whose vehicles are swapped, empty for the default tenant
+	Tenant string
+	// Paths are the files, directories or glob patterns watched for changes
+	Paths []string
+	// Interval is the time between two checks of the watched paths
+	Interval time.Duration
+	// Reloaded is called with the context of the tenant once its vehicles are swapped, nil if nothing is told
+	Reloaded func(ctx context.Context)
+}
+
+// NewVehiclePolling is a function that returns a new instance of VehiclePolling
+func NewVehiclePolling(cfg ConfigVehiclePolling) *VehiclePolling {
+	// default values
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+
+	source := strings.Join(cfg.Paths, ",")
+	if cfg.Tenant != "" {
+		source = cfg.Tenant + "=" + source
+	}
+
+	return &VehiclePolling{
+		ld:       cfg.Loader,
+		rp:       cfg.Repository,
+		tenant:   cfg.Tenant,
+		paths:    cfg.Paths,
+		interval: cfg.Interval,
+		reloaded: cfg.Reloaded,
+		status:   internal.ReloadStatus{Source: source},
+	}
+}
+
+// VehiclePolling is a struct that implements the VehicleReloader interface polling the dataset files
+type VehiclePolling struct {
+	// ld is the loader used to read the dataset
+	ld internal.VehicleLoader
+	// rp is the repository whose contents are swapped
+	rp internal.VehicleRepository
+	// tenant is the tenant whose vehicles are swapped
+	tenant string
+	// paths are the watched paths
+	paths []string
+	// interval is the time between two checks of the watched paths
+	interval time.Duration
+	// reloaded is called once the vehicles are swapped, nil if nothing is told
+	reloaded func(ctx context.Context)
+	// reloading serializes reloads
+	reloading sync.Mutex
+	// mu guards status and loaded
+	mu sync.RWMutex
+	// status is the state of the reloads
+	status internal.ReloadStatus
+	// loaded is the fingerprint of the watched paths taken before the last load
+	loaded string
+}
+
+// Reload is a method that loads the dataset, validates it and swaps the repository contents
+func (p *VehiclePolling) Reload() (s internal.ReloadStatus, err error) {
+	if !p.reloading.TryLock() {
+		err = internal.ErrorReloadInProgress
+		s = p.Status()
+		return
+	}
+	defer p.reloading.Unlock()
+
+	// - the fingerprint is taken before loading, a change during the load is seen by Watch
+	loaded := fingerprint(p.paths)
+	start := time.Now()
+	size, merge, err := p.reload()
+
+	// update status
+	p.mu.Lock()
+	p.loaded = loaded
+	p.status.LastAttempt = start
+	p.status.LastDuration = time.Since(start)
+	if err != nil {
+		p.status.Failures++
+		p.status.LastError = err.Error()
+	} else {
+		p.status.Reloads++
+		p.status.LastError = ""
+		p.status.LastSuccess = start
+		p.status.DatasetSize = size
+		p.status.Merge = merge
+	}
+	s = p.status
+	p.mu.Unlock()
+
+	if err != nil {
+		slog.Error("dataset reload failed", slog.String("source", s.Source), slog.Any("error", err))
+	} else {
+		slog.Info("dataset reloaded", slog.String("source", s.Source), slog.Int("vehicles", size), slog.Duration("duration", s.LastDuration))
+	}
+
+	return
+}
+
+// Status is a method that returns the state of the reloads
+func (p *VehiclePolling) Status() (s internal.ReloadStatus) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	s = p.status
+	return
+}
+
+// Watch is a method that polls the watched paths and reloads when they changed since the fingerprint
+// of the last reload, until ctx is done. Without a previous reload the first poll reloads
+func (p *VehiclePolling) Watch(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.mu.RLock()
+	last := p.loaded
+	p.mu.RUnlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := fingerprint(p.paths)
+			if current == last {
+				continue
+			}
+
+			// a failed reload is retried on the next change only, the status keeps the error
+			if _, err := p.Reload(); errors.Is(err, internal.ErrorReloadInProgress) {
+				continue
+			}
+			last = current
+		}
+	}
+}
+
+// reload is a method that loads and validates the dataset and swaps the repository contents,
+// merge is how the sources were merged when the loader merges several ones
+func (p *VehiclePolling) reload() (size int, merge *internal.VehicleMergeReport, err error) {
+	db, err := p.ld.Load()
+	if err != nil {
+		return
+	}
+
+	// - the collisions resolved by the merge policy are told, the vehicles discarded would be lost silently otherwise
+	if ml, ok := p.ld.(internal.VehicleMergeLoader); ok {
+		report := ml.MergeReport()
+		merge = &report
+		for _, c := range report.Conflicts {
+			slog.Warn("dataset merge conflict", slog.String("source", p.status.Source), slog.Int("id", c.Id), slog.String("registration", c.Registration),
+				slog.String("file", c.Source), slog.Any("conflicting_ids", c.ConflictingIds), slog.String("resolution", c.Resolution))
+		}
+	}
+
+	err = validate(db)
+	if err != nil {
+		return
+	}
+
+	ctx := context.Background()
+	if p.tenant != "" {
+		ctx = internal.WithTenant(ctx, p.tenant)
+	}
+	err = p.rp.ReplaceAllContext(ctx, db)
+	if err != nil {
+		return
+	}
+	if p.reloaded != nil {
+		p.reloaded(ctx)
+	}
+
+	size = len(db)
+	return
+}
+
+// validate is a function that checks every vehicle of a dataset, joining all the errors found by vehicle ID
+// so the message is the same on each reload. The VINs, unlike the registrations of the datasets, must be unique
+func validate(db map[int]internal.Vehicle) (err error) {
+	if len(db) == 0 {
+		err = internal.ErrorReloadEmptyDataset
+		return
+	}
+
+	var errs []error
+	ids := make([]int, 0, len(db))
+	for key := range db {
+		ids = append(ids, key)
+	}
+	sort.Ints(ids)
+	vins := make(map[string]int)
+	for _, key := range ids {
+		value := db[key]
+		if vin := strings.ToUpper(strings.TrimSpace(value.VIN)); vin != "" {
+			if owner, ok := vins[vin]; ok {
+				errs = append(errs, fmt.Errorf("%w: vehicle %d has the VIN %s of vehicle %d", internal.ErrorReloadInvalidVehicle, key, vin, owner))
+			} else {
+				vins[vin] = key
+			}
+		}
+
+		var missing []string
+		if key <= 0 || value.Id != key {
+			missing = append(missing, "id")
+		}
+		if value.Brand == "" {
+			missing = append(missing, "brand")
+		}
+		if value.Model == "" {
+			missing = append(missing, "model")
+		}
+		if value.Registration == "" {
+			missing = append(missing, "registration")
+		}
+		if value.Color == "" {
+			missing = append(missing, "color")
+		}
+		if value.FabricationYear <= 0 {
+			missing = append(missing, "year")
+		}
+
+		if len(missing) > 0 {
+			errs = append(errs, fmt.Errorf("%w: vehicle %d has invalid %s", internal.ErrorReloadInvalidVehicle, key, strings.Join(missing, ", ")))
+		}
+	}
+
+	err = errors.Join(errs...)
+	return
+}
+
+// fingerprint is a function that summarizes the name, size and modification time of the watched files
+func fingerprint(paths []string) (f string) {
+	var entries []string
+	for _, path := range paths {
+		pattern := path
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			pattern = filepath.Join(path, "*.json")
+		}
+
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				continue
+			}
+			entries = append(entries, fmt.Sprintf("%s:%d:%d", match, info.Size(), info.ModTime().UnixNano()))
+		}
+	}
+
+	sort.Strings(entries)
+	f = strings.Join(entries, "|")
+	return
+}
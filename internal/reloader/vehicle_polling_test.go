@@ -0,0 +1,337 @@
+package reloader_test
+
+import (
+	"app/internal"
+	"app/internal/loader"
+	"app/internal/reloader"
+	"app/internal/repository"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// loaderStub is a struct that implements the VehicleLoader interface with a fixed dataset
+type loaderStub struct {
+	// db is the dataset loaded
+	db map[int]internal.Vehicle
+	// err is the error of the load
+	err error
+}
+
+// Load is a method that returns the fixed dataset
+func (l *loaderStub) Load() (v map[int]internal.Vehicle, err error) {
+	return l.db, l.err
+}
+
+// loaderFunc is a function type that implements the VehicleLoader interface
+type loaderFunc func() (map[int]internal.Vehicle, error)
+
+// Load is a method that calls the function
+func (f loaderFunc) Load() (v map[int]internal.Vehicle, err error) {
+	return f()
+}
+
+// vehicle is a function that returns a valid vehicle with an ID and a VIN
+func vehicle(id int, vin string) internal.Vehicle {
+	return internal.Vehicle{Id: id, VehicleAttributes: internal.VehicleAttributes{
+		Brand: "Acura", Model: "MDX", Registration: "R" + string(rune('A'+id)), Color: "Red", FabricationYear: 2010, VIN: vin,
+	}}
+}
+
+func TestVehiclePolling_Reload(t *testing.T) {
+	errLoad := errors.New("load failed")
+	missing := vehicle(2, "")
+	missing.Brand = ""
+
+	cases := []struct {
+		name string
+		db   map[int]internal.Vehicle
+		err  error
+	}{
+		{
+			name: "valid dataset swapped",
+			db:   map[int]internal.Vehicle{1: vehicle(1, "V1"), 2: vehicle(2, "V2")},
+		},
+		{
+			name: "loader error",
+			err:  errLoad,
+		},
+		{
+			name: "empty dataset",
+			db:   map[int]internal.Vehicle{},
+			err:  internal.ErrorReloadEmptyDataset,
+		},
+		{
+			name: "missing field",
+			db:   map[int]internal.Vehicle{1: vehicle(1, ""), 2: missing},
+			err:  internal.ErrorReloadInvalidVehicle,
+		},
+		{
+			name: "ID different from its key",
+			db:   map[int]internal.Vehicle{1: vehicle(1, ""), 3: vehicle(2, "")},
+			err:  internal.ErrorReloadInvalidVehicle,
+		},
+		{
+			name: "VIN shared by two vehicles, whatever its case",
+			db:   map[int]internal.Vehicle{1: vehicle(1, "v1"), 2: vehicle(2, "V1 ")},
+			err:  internal.ErrorReloadInvalidVehicle,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rp := repository.NewVehicleTenant(nil, nil, nil, nil, nil)
+			previous := map[int]internal.Vehicle{7: vehicle(7, "")}
+			if err := rp.ReplaceAllContext(internal.WithTenant(context.Background(), "acme"), previous); err != nil {
+				t.Fatal(err)
+			}
+			var reloaded []string
+			ld := &loaderStub{db: c.db}
+			if c.err == errLoad {
+				ld.err = errLoad
+			}
+			rl := reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+				Loader:     ld,
+				Repository: rp,
+				Tenant:     "acme",
+				Paths:      []string{"vehicles.json"},
+				Reloaded: func(ctx context.Context) {
+					reloaded = append(reloaded, internal.TenantFromContext(ctx))
+				},
+			})
+
+			s, err := rl.Reload()
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if s.Source != "acme=vehicles.json" || s != rl.Status() {
+				t.Errorf("status = %+v, want the one of the reloader of acme=vehicles.json", s)
+			}
+
+			all, _ := rp.FindAllContext(internal.WithTenant(context.Background(), "acme"))
+			if c.err != nil {
+				// - a failed reload keeps the previous contents and tells nothing
+				if s.Failures != 1 || s.LastError == "" || !s.LastSuccess.IsZero() {
+					t.Errorf("status = %+v, want one failure", s)
+				}
+				if len(all) != 1 || len(reloaded) != 0 {
+					t.Errorf("vehicles = %v and reloads told %v, want the previous vehicles and none", all, reloaded)
+				}
+				return
+			}
+			if s.Reloads != 1 || s.DatasetSize != len(c.db) || s.LastSuccess.IsZero() || s.LastError != "" {
+				t.Errorf("status = %+v, want one reload of %d vehicles", s, len(c.db))
+			}
+			if len(all) != len(c.db) || len(reloaded) != 1 || reloaded[0] != "acme" {
+				t.Errorf("vehicles = %v and reloads told %v, want the dataset and the reload of acme", all, reloaded)
+			}
+		})
+	}
+}
+
+func TestVehiclePolling_Reload_Errors(t *testing.T) {
+	db := make(map[int]internal.Vehicle)
+	for id := 1; id <= 8; id++ {
+		db[id] = vehicle(id, "")
+	}
+	for _, id := range []int{2, 5, 7} {
+		v := db[id]
+		v.Color, v.FabricationYear = "", 0
+		db[id] = v
+	}
+	v := db[6]
+	v.Id, v.VIN = 60, "v1"
+	db[6] = v
+	v = db[3]
+	v.VIN = "V1"
+	db[3] = v
+	want := "Invalid vehicle in dataset: vehicle 2 has invalid color, year\n" +
+		"Invalid vehicle in dataset: vehicle 5 has invalid color, year\n" +
+		"Invalid vehicle in dataset: vehicle 6 has the VIN V1 of vehicle 3\n" +
+		"Invalid vehicle in dataset: vehicle 6 has invalid id\n" +
+		"Invalid vehicle in dataset: vehicle 7 has invalid color, year"
+
+	// - the errors are sorted by vehicle, whatever the order the dataset is walked in
+	rl := reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+		Loader:     &loaderStub{db: db},
+		Repository: repository.NewVehicleTenant(nil, nil, nil, nil, nil),
+		Paths:      []string{"vehicles.json"},
+	})
+	for i := 0; i < 20; i++ {
+		_, err := rl.Reload()
+		if !errors.Is(err, internal.ErrorReloadInvalidVehicle) || err.Error() != want {
+			t.Fatalf("error = %v, want:\n%s", err, want)
+		}
+	}
+}
+
+func TestVehiclePolling_Reload_Merge(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.json")
+	b := filepath.Join(dir, "b.json")
+	for path, content := range map[string]string{
+		a: `[{"id":1,"brand":"Acura","model":"MDX","registration":"A1","color":"Red","year":2010}]`,
+		b: `[{"id":1,"brand":"Audi","model":"A4","registration":"B1","color":"Blue","year":2015}]`,
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// - a single source is not merged
+	rl := reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+		Loader:     loader.NewVehicleJSONFile(a),
+		Repository: repository.NewVehicleTenant(nil, nil, nil, nil, nil),
+		Paths:      []string{a},
+	})
+	if s, err := rl.Reload(); err != nil || s.Merge != nil {
+		t.Fatalf("status = %+v and error %v, want no merge", s, err)
+	}
+
+	// - the merge of several sources is kept in the status, with the collisions resolved
+	rl = reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+		Loader:     loader.NewVehicleMulti([]string{a, b}, loader.MergePolicyFirstWins),
+		Repository: repository.NewVehicleTenant(nil, nil, nil, nil, nil),
+		Paths:      []string{a, b},
+	})
+	s, err := rl.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Merge == nil || len(s.Merge.Files) != 2 || len(s.Merge.Conflicts) != 1 || s.Merge.Conflicts[0].Source != b {
+		t.Fatalf("merge = %+v, want the vehicle of %s reported", s.Merge, b)
+	}
+	if g := reloader.NewVehicleGroup(rl).Status(); g.Merge == nil || len(g.Merge.Conflicts) != 1 || g.Merge.Vehicles[a] != 1 {
+		t.Errorf("merge of the group = %+v, want the one of its reloader", g.Merge)
+	}
+}
+
+func TestVehiclePolling_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vehicles.json")
+	// - the file is replaced at once, a poll never reads it half written
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path+".tmp", []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`[{"id":1,"brand":"Acura","model":"MDX","registration":"A1","color":"Red","year":2010}]`)
+
+	rp := repository.NewVehicleTenant(nil, nil, nil, nil, nil)
+	rl := reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+		Loader:     loader.NewVehicleJSONFile(path),
+		Repository: rp,
+		Paths:      []string{path},
+		Interval:   10 * time.Millisecond,
+	})
+	if _, err := rl.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rl.Watch(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// - a change of the file is reloaded by a next poll, the file is written until the watcher has seen it once
+	deadline := time.Now().Add(5 * time.Second)
+	for rl.Status().DatasetSize != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("status = %+v, want the change reloaded", rl.Status())
+		}
+		write(`[{"id":1,"brand":"Acura","model":"MDX","registration":"A1","color":"Red","year":2010},` +
+			`{"id":2,"brand":"Audi","model":"A4","registration":"A2","color":"Blue","year":2015}]`)
+		time.Sleep(50 * time.Millisecond)
+	}
+	if s := rl.Status(); s.Reloads < 2 || s.Failures != 0 {
+		t.Errorf("status = %+v, want the change reloaded without failure", s)
+	}
+}
+
+func TestVehiclePolling_Watch_ChangeDuringLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vehicles.json")
+	one := `[{"id":1,"brand":"Acura","model":"MDX","registration":"A1","color":"Red","year":2010}]`
+	two := `[{"id":1,"brand":"Acura","model":"MDX","registration":"A1","color":"Red","year":2010},` +
+		`{"id":2,"brand":"Audi","model":"A4","registration":"A2","color":"Blue","year":2015}]`
+	if err := os.WriteFile(path, []byte(one), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// - the file changes while the first load reads it, the load keeps the former contents
+	loads := 0
+	ld := loaderFunc(func() (map[int]internal.Vehicle, error) {
+		loads++
+		if loads == 1 {
+			db, err := loader.NewVehicleJSONFile(path).Load()
+			if err == nil {
+				err = os.WriteFile(path, []byte(two), 0o600)
+			}
+			return db, err
+		}
+		return loader.NewVehicleJSONFile(path).Load()
+	})
+	rl := reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+		Loader:     ld,
+		Repository: repository.NewVehicleTenant(nil, nil, nil, nil, nil),
+		Paths:      []string{path},
+		Interval:   10 * time.Millisecond,
+	})
+	if s, err := rl.Reload(); err != nil || s.DatasetSize != 1 {
+		t.Fatalf("status = %+v and error %v, want the former contents loaded", s, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rl.Watch(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// - the watcher compares with the fingerprint taken before the load, so it reloads the change
+	deadline := time.Now().Add(5 * time.Second)
+	for rl.Status().DatasetSize != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("status = %+v, want the change during the load reloaded", rl.Status())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestVehicleGroup_Status(t *testing.T) {
+	rp := repository.NewVehicleTenant(nil, nil, nil, nil, nil)
+	ok := reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+		Loader: &loaderStub{db: map[int]internal.Vehicle{1: vehicle(1, "")}}, Repository: rp, Tenant: "a", Paths: []string{"a.json"},
+	})
+	failing := reloader.NewVehiclePolling(reloader.ConfigVehiclePolling{
+		Loader: &loaderStub{db: map[int]internal.Vehicle{}}, Repository: rp, Tenant: "b", Paths: []string{"b.json"},
+	})
+	g := reloader.NewVehicleGroup(ok, failing)
+
+	// - the group has not succeeded until every dataset has, the others are swapped anyway
+	s, err := g.Reload()
+	if !errors.Is(err, internal.ErrorReloadEmptyDataset) {
+		t.Fatalf("error = %v, want %v", err, internal.ErrorReloadEmptyDataset)
+	}
+	if s.Source != "a=a.json;b=b.json" || s.DatasetSize != 1 || s.Reloads != 1 || s.Failures != 1 || !s.LastSuccess.IsZero() {
+		t.Errorf("status = %+v, want one reload and one failure, without success", s)
+	}
+	if !ok.Status().LastSuccess.Equal(ok.Status().LastAttempt) {
+		t.Errorf("status of a = %+v, want it reloaded", ok.Status())
+	}
+}
@@ -0,0 +1,393 @@
+package repository
+
+import (
+	"app/internal"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NewCatalogMap is a function that returns a new instance of CatalogMap, empty,
+// the names and aliases are compared by the keys of nm or as they are if nm is nil
+func NewCatalogMap(nm internal.TextNormalizer) *CatalogMap {
+	return &CatalogMap{
+		nm:     nm,
+		brands: make(map[string]internal.CatalogBrand),
+		names:  make(map[string]string),
+	}
+}
+
+// CatalogMap is a struct that implements the CatalogRepository interface in memory
+type CatalogMap struct {
+	// mu guards brands and names
+	mu sync.RWMutex
+	// nm normalizes the names and aliases, nil compares them as they are
+	nm internal.TextNormalizer
+	// brands are the brands by the key of their name
+	brands map[string]internal.CatalogBrand
+	// names are the keys of the names of the brands by the keys of their names and aliases
+	names map[string]string
+}
+
+// key is a method that returns the key of a name or alias of a field
+func (r *CatalogMap) key(field string, value string) string {
+	if r.nm == nil {
+		return strings.TrimSpace(value)
+	}
+	return r.nm.Normalize(field, value)
+}
+
+// brand is a method that returns the key of the name of a brand given by its name or an alias,
+// the caller must hold the lock
+func (r *CatalogMap) brand(brand string) (key string, err error) {
+	key, ok := r.names[r.key(internal.TextFieldBrand, brand)]
+	if !ok {
+		err = fmt.Errorf("%w: %s", internal.ErrorCatalogBrandNotFound, brand)
+	}
+	return
+}
+
+// model is a method that returns the index of a model of a brand given by its name or an alias, -1 if it has none
+func (r *CatalogMap) model(b internal.CatalogBrand, model string) int {
+	return slices.IndexFunc(b.Models, func(m internal.CatalogModel) bool { return r.named(m, model) })
+}
+
+// named is a method that reports whether a model is given by a name or an alias
+func (r *CatalogMap) named(m internal.CatalogModel, model string) bool {
+	key := r.key(internal.TextFieldModel, model)
+	return r.key(internal.TextFieldModel, m.Name) == key || slices.ContainsFunc(m.Aliases, func(alias string) bool {
+		return r.key(internal.TextFieldModel, alias) == key
+	})
+}
+
+// Resolve is a method that returns the entries of a brand and a model given by their names or aliases
+func (r *CatalogMap) Resolve(brand string, model string) (link internal.CatalogLink, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, err = r.resolve(brand, model)
+	return
+}
+
+// resolve is a method that returns the entries of a brand and a model given by their names or aliases,
+// the caller must hold the lock
+func (r *CatalogMap) resolve(brand string, model string) (link internal.CatalogLink, err error) {
+	key, err := r.brand(brand)
+	if err != nil {
+		return
+	}
+	link.Brand = r.brands[key]
+
+	i := r.model(link.Brand, model)
+	if i < 0 {
+		err = fmt.Errorf("%w: %s %s", internal.ErrorCatalogModelNotFound, link.Brand.Name, model)
+		return
+	}
+	link.Model = link.Brand.Models[i]
+
+	return
+}
+
+// Match is a method that reports whether the entries of a brand and a model match a filter,
+// false if they are not in the catalog
+func (r *CatalogMap) Match(brand string, model string, filter internal.CatalogFilter) (ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, err := r.resolve(brand, model)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case filter.Brand != "" && r.names[r.key(internal.TextFieldBrand, filter.Brand)] != r.key(internal.TextFieldBrand, link.Brand.Name):
+		return
+	case filter.Model != "" && !r.named(link.Model, filter.Model):
+		return
+	case filter.BodyType != "" && filter.BodyType != link.Model.BodyType:
+		return
+	case filter.Country != "" && !strings.EqualFold(filter.Country, link.Brand.Country):
+		return
+	}
+	ok = true
+	return
+}
+
+// FindAllContext is a method that returns all the brands sorted by name
+func (r *CatalogMap) FindAllContext(ctx context.Context) (b []internal.CatalogBrand, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	b = make([]internal.CatalogBrand, 0, len(r.brands))
+	for _, value := range r.brands {
+		b = append(b, value)
+	}
+	sort.Slice(b, func(i, j int) bool { return b[i].Name < b[j].Name })
+
+	return
+}
+
+// FindBrandContext is a method that returns a brand given by its name or an alias
+func (r *CatalogMap) FindBrandContext(ctx context.Context, brand string) (b internal.CatalogBrand, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	key, err := r.brand(brand)
+	if err != nil {
+		return
+	}
+	b = r.brands[key]
+
+	return
+}
+
+// CreateBrandContext is a method that adds a brand with its models
+func (r *CatalogMap) CreateBrandContext(ctx context.Context, b internal.CatalogBrand) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	err = r.put(b, "")
+	return
+}
+
+// UpdateBrandContext is a method that replaces a brand given by its name or an alias, its models included
+func (r *CatalogMap) UpdateBrandContext(ctx context.Context, brand string, b internal.CatalogBrand) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	key, err := r.brand(brand)
+	if err != nil {
+		return
+	}
+
+	err = r.put(b, key)
+	return
+}
+
+// DeleteBrandContext is a method that removes a brand given by its name or an alias with its models
+func (r *CatalogMap) DeleteBrandContext(ctx context.Context, brand string) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	key, err := r.brand(brand)
+	if err != nil {
+		return
+	}
+
+	r.remove(key)
+	return
+}
+
+// CreateModelContext is a method that adds a model to a brand given by its name or an alias
+func (r *CatalogMap) CreateModelContext(ctx context.Context, brand string, m internal.CatalogModel) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	key, err := r.brand(brand)
+	if err != nil {
+		return
+	}
+
+	b := r.brands[key]
+	b.Models = append(slices.Clip(b.Models), m)
+	err = r.put(b, key)
+	return
+}
+
+// UpdateModelContext is a method that replaces a model given by its name or an alias of a brand given by its name or an alias
+func (r *CatalogMap) UpdateModelContext(ctx context.Context, brand string, model string, m internal.CatalogModel) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	key, err := r.brand(brand)
+	if err != nil {
+		return
+	}
+
+	b := r.brands[key]
+	i := r.model(b, model)
+	if i < 0 {
+		err = fmt.Errorf("%w: %s %s", internal.ErrorCatalogModelNotFound, b.Name, model)
+		return
+	}
+	b.Models = slices.Clone(b.Models)
+	b.Models[i] = m
+	err = r.put(b, key)
+	return
+}
+
+// DeleteModelContext is a method that removes a model given by its name or an alias of a brand given by its name or an alias
+func (r *CatalogMap) DeleteModelContext(ctx context.Context, brand string, model string) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	key, err := r.brand(brand)
+	if err != nil {
+		return
+	}
+
+	b := r.brands[key]
+	i := r.model(b, model)
+	if i < 0 {
+		err = fmt.Errorf("%w: %s %s", internal.ErrorCatalogModelNotFound, b.Name, model)
+		return
+	}
+	b.Models = slices.Delete(slices.Clone(b.Models), i, i+1)
+	r.brands[key] = b
+	return
+}
+
+// ReplaceAllContext is a method that replaces every brand, nothing is replaced if any of them is invalid
+func (r *CatalogMap) ReplaceAllContext(ctx context.Context, b []internal.CatalogBrand) (err error) {
+	replacement := NewCatalogMap(r.nm)
+	for _, value := range b {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if err = replacement.put(value, ""); err != nil {
+			return
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.brands, r.names = replacement.brands, replacement.names
+	return
+}
+
+// put is a method that validates a brand and stores it in place of the brand of the key replaced, empty to add it,
+// the caller must hold the write lock
+func (r *CatalogMap) put(b internal.CatalogBrand, replaced string) (err error) {
+	if err = r.validate(b); err != nil {
+		return
+	}
+
+	// - names and aliases of the brand, unique across the catalog but for the brand replaced.
+	// An alias may have the key of the name, as a synonym of the normalizer does
+	key := r.key(internal.TextFieldBrand, b.Name)
+	keys := []string{key}
+	for _, alias := range b.Aliases {
+		if k := r.key(internal.TextFieldBrand, alias); !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range keys {
+		if owner, ok := r.names[k]; ok && owner != replaced {
+			err = fmt.Errorf("%w: brand %s", internal.ErrorCatalogConflict, k)
+			return
+		}
+	}
+
+	if replaced != "" {
+		r.remove(replaced)
+	}
+	r.brands[key] = b
+	for _, k := range keys {
+		r.names[k] = key
+	}
+	return
+}
+
+// remove is a method that removes the brand of a key with its names and aliases, the caller must hold the write lock
+func (r *CatalogMap) remove(key string) {
+	delete(r.brands, key)
+	for k, owner := range r.names {
+		if owner == key {
+			delete(r.names, k)
+		}
+	}
+}
+
+// validate is a method that returns an error when a brand or one of its models is incomplete
+// or two of its models share a name or alias
+func (r *CatalogMap) validate(b internal.CatalogBrand) (err error) {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", internal.ErrorCatalogInvalid, fmt.Sprintf(format, args...))
+	}
+
+	if r.key(internal.TextFieldBrand, b.Name) == "" {
+		return invalid("brand name is required")
+	}
+	if strings.TrimSpace(b.Country) == "" {
+		return invalid("country of %s is required", b.Name)
+	}
+	for _, alias := range b.Aliases {
+		if r.key(internal.TextFieldBrand, alias) == "" {
+			return invalid("aliases of %s must not be empty", b.Name)
+		}
+	}
+
+	models := make(map[string]bool)
+	for _, m := range b.Models {
+		if r.key(internal.TextFieldModel, m.Name) == "" {
+			return invalid("model name of %s is required", b.Name)
+		}
+		if !slices.Contains(internal.BodyTypes, m.BodyType) {
+			return invalid("body type of %s %s must be one of %s", b.Name, m.Name, bodyTypes())
+		}
+		if m.YearFrom < 0 || m.YearTo < 0 || (m.YearTo != 0 && m.YearFrom > m.YearTo) {
+			return invalid("production years of %s %s must be positive and in order", b.Name, m.Name)
+		}
+		keys := make(map[string]bool)
+		for _, name := range append([]string{m.Name}, m.Aliases...) {
+			k := r.key(internal.TextFieldModel, name)
+			if k == "" {
+				return invalid("aliases of %s %s must not be empty", b.Name, m.Name)
+			}
+			if models[k] {
+				return fmt.Errorf("%w: model %s of %s", internal.ErrorCatalogConflict, k, b.Name)
+			}
+			keys[k] = true
+		}
+		for k := range keys {
+			models[k] = true
+		}
+	}
+	return
+}
+
+// bodyTypes is a function that returns the body types as a comma separated list
+func bodyTypes() string {
+	names := make([]string, 0, len(internal.BodyTypes))
+	for _, t := range internal.BodyTypes {
+		names = append(names, string(t))
+	}
+	return strings.Join(names, ", ")
+}
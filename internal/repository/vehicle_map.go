@@ -2,31 +2,234 @@ package repository
 
 import (
 	"app/internal"
+	"app/internal/search"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 )
 
-// NewVehicleMap is a function that returns a new instance of VehicleMap
-func NewVehicleMap(db map[int]internal.Vehicle) *VehicleMap {
+// NewVehicleMap is a function that returns a new instance of VehicleMap,
+// the text fields are compared by the keys of nm or as they are if nm is nil and the vehicles written
+// must have the fuel type and transmission of vc, the brand and model of ct, a registration of rg and a VIN decoded
+// by vn, if any, unless they are nil. The registrations and VINs are unique by the normalized forms of rg and vn,
+// or as they are trimmed if they are nil
+func NewVehicleMap(db map[int]internal.Vehicle, nm internal.TextNormalizer, vc internal.Vocabulary, ct internal.Catalog, rg internal.Registrations, vn internal.VINDecoder) *VehicleMap {
 	// default db
 	defaultDb := make(map[int]internal.Vehicle)
 	if db != nil {
 		defaultDb = db
 	}
-	return &VehicleMap{db: defaultDb}
+	r := &VehicleMap{db: defaultDb, nm: nm, vc: vc, ct: ct, rg: rg, vn: vn}
+	r.keys = r.index(defaultDb)
+	r.plates = r.indexPlates(defaultDb)
+	r.vins = r.indexVINs(defaultDb)
+	r.ix = newSearchIndex(defaultDb)
+	return r
 }
 
 // VehicleMap is a struct that represents a vehicle repository
 type VehicleMap struct {
+	// mu guards db, keys, plates, vins and ix, they are replaced as a whole on reloads
+	mu sync.RWMutex
 	// db is a map of vehicles
 	db map[int]internal.Vehicle
+	// nm normalizes the text fields, nil compares them as they are
+	nm internal.TextNormalizer
+	// vc is the vocabulary of the vehicles written, nil writes any value
+	vc internal.Vocabulary
+	// ct is the catalog the vehicles are linked to, nil links none and writes any brand and model
+	ct internal.Catalog
+	// rg are the registrations of the vehicles written, nil writes any registration
+	rg internal.Registrations
+	// vn decodes the VINs of the vehicles written, nil writes any VIN
+	vn internal.VINDecoder
+	// keys are the normalized text fields of the vehicles of db by ID, written with them
+	keys map[int]vehicleKeys
+	// plates are the IDs of the vehicles of db by normalized registration, sorted, written with them.
+	// The vehicles written have a registration of their own but the loaded ones may share it
+	plates map[string][]int
+	// vins are the IDs of the vehicles of db with a VIN by normalized VIN, written with them
+	vins map[string]int
+	// ix is the text index of the vehicles of db, written with them
+	ix *search.Index
+}
+
+// vehicleKeys is a struct that represents the normalized text fields of a vehicle, the vehicle keeps the values to display
+type vehicleKeys struct {
+	brand        string
+	color        string
+	fuelType     string
+	transmission string
+}
+
+// key is a method that returns the key of a value of a text field
+func (r *VehicleMap) key(field string, value string) string {
+	if r.nm == nil {
+		return value
+	}
+	return r.nm.Normalize(field, value)
+}
+
+// keysOf is a method that returns the keys of the text fields of a vehicle
+func (r *VehicleMap) keysOf(v internal.Vehicle) vehicleKeys {
+	return vehicleKeys{
+		brand:        r.key(internal.TextFieldBrand, v.Brand),
+		color:        r.key(internal.TextFieldColor, v.Color),
+		fuelType:     r.key(internal.TextFieldFuelType, string(v.FuelType)),
+		transmission: r.key(internal.TextFieldTransmission, string(v.Transmission)),
+	}
+}
+
+// plate is a method that returns the normalized form of a registration
+func (r *VehicleMap) plate(registration string) string {
+	if r.rg == nil {
+		return strings.TrimSpace(registration)
+	}
+	return r.rg.Normalize(registration)
+}
+
+// vin is a method that returns the normalized form of a VIN
+func (r *VehicleMap) vin(vin string) string {
+	if r.vn == nil {
+		return strings.TrimSpace(vin)
+	}
+	return r.vn.Normalize(vin)
+}
+
+// check is a method that returns an error when the registration of a vehicle has none of the formats,
+// its VIN is invalid or does not match it, its fuel type or transmission are given and not in the vocabulary
+// or its brand and model are not in the catalog, produced in its fabrication year
+func (r *VehicleMap) check(v internal.Vehicle) (err error) {
+	if r.rg != nil {
+		if err = r.rg.CheckRegistration(v.Registration); err != nil {
+			return
+		}
+	}
+	if r.vn != nil && r.vin(v.VIN) != "" {
+		if err = r.checkVIN(v); err != nil {
+			return
+		}
+	}
+	// - the version 1 of the API, frozen, creates vehicles without them
+	if r.vc != nil && strings.TrimSpace(string(v.FuelType)) != "" {
+		if err = r.vc.CheckFuelType(v.FuelType); err != nil {
+			return
+		}
+	}
+	if r.vc != nil && strings.TrimSpace(string(v.Transmission)) != "" {
+		if err = r.vc.CheckTransmission(v.Transmission); err != nil {
+			return
+		}
+	}
+	if r.ct != nil {
+		link, errLink := r.ct.Resolve(v.Brand, v.Model)
+		if errLink != nil {
+			err = fmt.Errorf("%w: %w", internal.ErrorVehicleNotInCatalog, errLink)
+			return
+		}
+		if !link.Model.Produced(v.FabricationYear) {
+			err = fmt.Errorf("%w: %s %s was not produced in %d", internal.ErrorVehicleNotInCatalog, link.Brand.Name, link.Model.Name, v.FabricationYear)
+			return
+		}
+	}
+	return
+}
+
+// checkVIN is a method that returns an error when the VIN of a vehicle is invalid, its WMI is assigned to other brands
+// or its model year is neither the fabrication year of the vehicle nor the next one, as the models of a year
+// are built from the year before
+func (r *VehicleMap) checkVIN(v internal.Vehicle) (err error) {
+	info, err := r.vn.Decode(v.VIN)
+	if err != nil {
+		return
+	}
+
+	brand := r.key(internal.TextFieldBrand, v.Brand)
+	if len(info.Manufacturers) > 0 && !slices.ContainsFunc(info.Manufacturers, func(m string) bool {
+		return r.key(internal.TextFieldBrand, m) == brand
+	}) {
+		err = fmt.Errorf("%w: its WMI %s is assigned to %s, not %s", internal.ErrorVINMismatch, info.WMI, strings.Join(info.Manufacturers, ", "), v.Brand)
+		return
+	}
+	if !slices.Contains(info.ModelYears, v.FabricationYear) && !slices.Contains(info.ModelYears, v.FabricationYear+1) {
+		err = fmt.Errorf("%w: its model year %c is not %d nor %d", internal.ErrorVINMismatch, info.VIN[9], v.FabricationYear, v.FabricationYear+1)
+		return
+	}
+	return
+}
+
+// index is a method that returns the keys of the text fields of the vehicles by ID
+func (r *VehicleMap) index(db map[int]internal.Vehicle) map[int]vehicleKeys {
+	keys := make(map[int]vehicleKeys, len(db))
+	for id, v := range db {
+		keys[id] = r.keysOf(v)
+	}
+	return keys
+}
+
+// indexPlates is a method that returns the IDs of the vehicles by normalized registration, sorted
+func (r *VehicleMap) indexPlates(db map[int]internal.Vehicle) map[string][]int {
+	plates := make(map[string][]int, len(db))
+	for id, v := range db {
+		key := r.plate(v.Registration)
+		plates[key] = append(plates[key], id)
+	}
+	for _, ids := range plates {
+		slices.Sort(ids)
+	}
+	return plates
+}
+
+// indexVINs is a method that returns the IDs of the vehicles with a VIN by normalized VIN,
+// the lowest ID if several share it
+func (r *VehicleMap) indexVINs(db map[int]internal.Vehicle) map[string]int {
+	vins := make(map[string]int)
+	for id, v := range db {
+		key := r.vin(v.VIN)
+		if key == "" {
+			continue
+		}
+		if owner, ok := vins[key]; !ok || id < owner {
+			vins[key] = id
+		}
+	}
+	return vins
+}
+
+// removePlate is a method that removes the ID of a vehicle from the index of registrations,
+// the caller must hold the write lock
+func (r *VehicleMap) removePlate(id int, registration string) {
+	key := r.plate(registration)
+	ids := slices.DeleteFunc(r.plates[key], func(value int) bool { return value == id })
+	if len(ids) == 0 {
+		delete(r.plates, key)
+		return
+	}
+	r.plates[key] = ids
 }
 
 // FindAll is a method that returns a map of all vehicles
 func (r *VehicleMap) FindAll() (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindAllContext(context.Background())
+	return
+}
+
+// FindAllContext is a method that returns a map of all vehicles, honoring the cancellation of ctx
+func (r *VehicleMap) FindAllContext(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
 
 	// copy db
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
 		v[key] = value
 	}
 
@@ -35,6 +238,15 @@ func (r *VehicleMap) FindAll() (v map[int]internal.Vehicle, err error) {
 
 // FindByID is a method that returns a vehicle by ID
 func (r *VehicleMap) FindByID(id int) (v internal.Vehicle, err error) {
+	v, err = r.FindByIDContext(context.Background(), id)
+	return
+}
+
+// FindByIDContext is a method that returns a vehicle by ID, honoring the cancellation of ctx
+func (r *VehicleMap) FindByIDContext(ctx context.Context, id int) (v internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	if _, ok := r.db[id]; !ok {
 		err = internal.ErrorVehicleNotFound
 		return
@@ -47,31 +259,101 @@ func (r *VehicleMap) FindByID(id int) (v internal.Vehicle, err error) {
 
 // Create is a method that creates a new vehicle
 func (r *VehicleMap) Create(v *internal.Vehicle) (err error) {
-	// check if vehicle already exists
-	if v.Id == 0 {
-		// generate new ID
-		v.Id = len(r.db) + 1
+	err = r.CreateContext(context.Background(), v)
+	return
+}
+
+// CreateContext is a method that creates a new vehicle, honoring the cancellation of ctx
+func (r *VehicleMap) CreateContext(ctx context.Context, v *internal.Vehicle) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err = r.create(ctx, v)
+	return
+}
+
+// create is a method that creates a new vehicle, the caller must hold the write lock
+func (r *VehicleMap) create(ctx context.Context, v *internal.Vehicle) (err error) {
+	// check the vocabulary
+	if err = r.check(*v); err != nil {
+		return
 	}
 
-	for _, value := range r.db {
-		if value.Id == v.Id || value.Registration == v.Registration {
-			err = internal.ErrorVehicleAlreadyExists
-			return
-		}
+	if err = ctx.Err(); err != nil {
+		return
 	}
 
-	// add vehicle to db
-	r.db[v.Id] = *v
+	// check if vehicle already exists, by ID and by normalized registration
+	r.prepare(v, len(r.db))
+	if err = r.taken(*v); err != nil {
+		return
+	}
+
+	r.insert(*v)
+	return
+}
+
+// prepare is a method that gives a new vehicle the ID following size vehicles when it has none
+// and normalizes its registration and VIN
+func (r *VehicleMap) prepare(v *internal.Vehicle, size int) {
+	if v.Id == 0 {
+		// generate new ID
+		v.Id = size + 1
+	}
+	v.Registration = r.plate(v.Registration)
+	v.VIN = r.vin(v.VIN)
+}
 
+// taken is a method that returns ErrorVehicleAlreadyExists when the ID, registration or VIN of a prepared vehicle
+// belongs to a stored vehicle, the caller must hold the lock
+func (r *VehicleMap) taken(v internal.Vehicle) (err error) {
+	if _, ok := r.db[v.Id]; ok {
+		err = internal.ErrorVehicleAlreadyExists
+		return
+	}
+	if ids := r.plates[v.Registration]; len(ids) > 0 {
+		err = fmt.Errorf("%w: registration %s is taken by vehicle %d", internal.ErrorVehicleAlreadyExists, v.Registration, ids[0])
+		return
+	}
+	if id, ok := r.vins[v.VIN]; ok && v.VIN != "" {
+		err = fmt.Errorf("%w: VIN %s is taken by vehicle %d", internal.ErrorVehicleAlreadyExists, v.VIN, id)
+		return
+	}
 	return
 }
 
+// insert is a method that adds a prepared vehicle to db and its indexes without checking it,
+// the caller must hold the write lock
+func (r *VehicleMap) insert(v internal.Vehicle) {
+	r.db[v.Id] = v
+	r.keys[v.Id] = r.keysOf(v)
+	r.plates[v.Registration] = []int{v.Id}
+	if v.VIN != "" {
+		r.vins[v.VIN] = v.Id
+	}
+	r.ix.Add(v.Id, searchFields(v)...)
+}
+
 // FindByColorAndYear is a method that returns a map of vehicles that match the color and year
 func (r *VehicleMap) FindByColorAndYear(color string, year int) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByColorAndYearContext(context.Background(), color, year)
+	return
+}
+
+// FindByColorAndYearContext is a method that returns a map of vehicles that match the color and year, honoring the cancellation of ctx
+func (r *VehicleMap) FindByColorAndYearContext(ctx context.Context, color string, year int) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
+	color = r.key(internal.TextFieldColor, color)
 
 	for key, value := range r.db {
-		if value.Color == color && value.FabricationYear == year {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if r.keys[key].color == color && value.FabricationYear == year {
 			v[key] = value
 		}
 	}
@@ -85,10 +367,24 @@ func (r *VehicleMap) FindByColorAndYear(color string, year int) (v map[int]inter
 
 // FindByBrandAndRangeYear is a method that returns a map of vehicles that match the brand and range year
 func (r *VehicleMap) FindByBrandAndRangeYear(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByBrandAndRangeYearContext(context.Background(), brand, startYear, endYear)
+	return
+}
+
+// FindByBrandAndRangeYearContext is a method that returns a map of vehicles that match the brand and range year, honoring the cancellation of ctx
+func (r *VehicleMap) FindByBrandAndRangeYearContext(ctx context.Context, brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
+	brand = r.key(internal.TextFieldBrand, brand)
 
 	for key, value := range r.db {
-		if value.Brand == brand && value.FabricationYear >= startYear && value.FabricationYear <= endYear {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if r.keys[key].brand == brand && value.FabricationYear >= startYear && value.FabricationYear <= endYear {
 			v[key] = value
 		}
 	}
@@ -102,11 +398,26 @@ func (r *VehicleMap) FindByBrandAndRangeYear(brand string, startYear int, endYea
 
 // FindAverageSpeedByBrand is a method that returns a value of average speed by brand
 func (r *VehicleMap) FindAverageSpeedByBrand(brand string) (avgSpeed float64, err error) {
+	avgSpeed, err = r.FindAverageSpeedByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageSpeedByBrandContext is a method that returns a value of average speed by brand, honoring the cancellation of ctx
+func (r *VehicleMap) FindAverageSpeedByBrandContext(ctx context.Context, brand string) (avgSpeed float64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var totalSpeed float64
 	var brandCount int
 
-	for _, value := range r.db {
-		if value.Brand == brand {
+	brand = r.key(internal.TextFieldBrand, brand)
+
+	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if r.keys[key].brand == brand {
 			totalSpeed += value.MaxSpeed
 			brandCount++
 		}
@@ -124,24 +435,88 @@ func (r *VehicleMap) FindAverageSpeedByBrand(brand string) (avgSpeed float64, er
 
 // CreateBatch is a method that creates a batch of vehicles
 func (r *VehicleMap) CreateBatch(v []internal.Vehicle) (err error) {
-	for _, value := range v {
-		err = r.Create(&value)
-		if err != nil {
+	err = r.CreateBatchContext(context.Background(), v)
+	return
+}
+
+// CreateBatchContext is a method that creates a batch of vehicles, honoring the cancellation of ctx.
+// Every vehicle is checked, against the stored ones and the others of the batch, before any is created:
+// either all of them are created, in place with their IDs and normalized registrations and VINs, or none
+func (r *VehicleMap) CreateBatchContext(ctx context.Context, v []internal.Vehicle) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// - the vehicles are prepared on a copy, left untouched when the batch fails
+	batch := make([]internal.Vehicle, len(v))
+	copy(batch, v)
+	ids := make(map[int]int, len(batch))
+	plates := make(map[string]int, len(batch))
+	vins := make(map[string]int, len(batch))
+	for i := range batch {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		vh := &batch[i]
+		if err = r.check(*vh); err != nil {
+			err = fmt.Errorf("vehicle %d of the batch: %w", i+1, err)
 			return
 		}
+		// - the IDs are generated as if the vehicles before were created
+		r.prepare(vh, len(r.db)+i)
+		if err = r.taken(*vh); err != nil {
+			err = fmt.Errorf("vehicle %d of the batch: %w", i+1, err)
+			return
+		}
+
+		// - and must not be shared within the batch either
+		if j, ok := ids[vh.Id]; ok {
+			err = fmt.Errorf("vehicle %d of the batch: %w: ID %d is the ID of vehicle %d of the batch", i+1, internal.ErrorVehicleAlreadyExists, vh.Id, j)
+			return
+		}
+		if j, ok := plates[vh.Registration]; ok {
+			err = fmt.Errorf("vehicle %d of the batch: %w: registration %s is the registration of vehicle %d of the batch", i+1, internal.ErrorVehicleAlreadyExists, vh.Registration, j)
+			return
+		}
+		if j, ok := vins[vh.VIN]; ok && vh.VIN != "" {
+			err = fmt.Errorf("vehicle %d of the batch: %w: VIN %s is the VIN of vehicle %d of the batch", i+1, internal.ErrorVehicleAlreadyExists, vh.VIN, j)
+			return
+		}
+		ids[vh.Id] = i + 1
+		plates[vh.Registration] = i + 1
+		vins[vh.VIN] = i + 1
 	}
 
+	// - nothing can fail anymore
+	for _, vh := range batch {
+		r.insert(vh)
+	}
+	copy(v, batch)
+
 	return
 }
 
 // UpdateMaxSpeed is a method that updates the max speed of a vehicle
 func (r *VehicleMap) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	err = r.UpdateMaxSpeedContext(context.Background(), id, maxSpeed)
+	return
+}
+
+// UpdateMaxSpeedContext is a method that updates the max speed of a vehicle, honoring the cancellation of ctx
+func (r *VehicleMap) UpdateMaxSpeedContext(ctx context.Context, id int, maxSpeed float64) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if maxSpeed < 0 || maxSpeed > 500 {
 		err = internal.ErrorInvalidMaxSpeedRange
 		return
 	}
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
 		if value.Id == id {
 			value.MaxSpeed = maxSpeed
 			r.db[key] = value
@@ -155,10 +530,24 @@ func (r *VehicleMap) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
 
 // FindByFuelType is a method that returns a map of vehicles that match the fuel type
 func (r *VehicleMap) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByFuelTypeContext(context.Background(), fuelType)
+	return
+}
+
+// FindByFuelTypeContext is a method that returns a map of vehicles that match the fuel type, honoring the cancellation of ctx
+func (r *VehicleMap) FindByFuelTypeContext(ctx context.Context, fuelType string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
+	fuelType = r.key(internal.TextFieldFuelType, fuelType)
 
 	for key, value := range r.db {
-		if value.FuelType == fuelType {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if r.keys[key].fuelType == fuelType {
 			v[key] = value
 		}
 	}
@@ -172,9 +561,28 @@ func (r *VehicleMap) FindByFuelType(fuelType string) (v map[int]internal.Vehicle
 
 // Delete is a method that deletes a vehicle
 func (r *VehicleMap) Delete(id int) (err error) {
+	err = r.DeleteContext(context.Background(), id)
+	return
+}
+
+// DeleteContext is a method that deletes a vehicle, honoring the cancellation of ctx
+func (r *VehicleMap) DeleteContext(ctx context.Context, id int) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
 		if value.Id == id {
 			delete(r.db, key)
+			delete(r.keys, key)
+			r.removePlate(key, value.Registration)
+			if r.vins[r.vin(value.VIN)] == key {
+				delete(r.vins, r.vin(value.VIN))
+			}
+			r.ix.Remove(key)
 			return
 		}
 	}
@@ -185,10 +593,24 @@ func (r *VehicleMap) Delete(id int) (err error) {
 
 // FindByTransmissionType is a method that returns a map of vehicles that match the transmission type
 func (r *VehicleMap) FindByTransmissionType(transmissionType string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByTransmissionTypeContext(context.Background(), transmissionType)
+	return
+}
+
+// FindByTransmissionTypeContext is a method that returns a map of vehicles that match the transmission type, honoring the cancellation of ctx
+func (r *VehicleMap) FindByTransmissionTypeContext(ctx context.Context, transmissionType string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
+	transmissionType = r.key(internal.TextFieldTransmission, transmissionType)
 
 	for key, value := range r.db {
-		if value.Transmission == transmissionType {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if r.keys[key].transmission == transmissionType {
 			v[key] = value
 		}
 	}
@@ -202,16 +624,30 @@ func (r *VehicleMap) FindByTransmissionType(transmissionType string) (v map[int]
 
 // UpdateFuelType is a method that updates the fuel type of a vehicle
 func (r *VehicleMap) UpdateFuelType(id int, fuelType string) (err error) {
+	err = r.UpdateFuelTypeContext(context.Background(), id, fuelType)
+	return
+}
+
+// UpdateFuelTypeContext is a method that updates the fuel type of a vehicle, honoring the cancellation of ctx
+func (r *VehicleMap) UpdateFuelTypeContext(ctx context.Context, id int, fuelType string) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.vc != nil {
+		if err = r.vc.CheckFuelType(internal.FuelType(fuelType)); err != nil {
+			return
+		}
+	}
+
 	for key, value := range r.db {
-		if value.Id == id {
-			switch strings.ToLower(fuelType) {
-			case "gasoline", "diesel", "biodiesel", "gas":
-				value.FuelType = fuelType
-				r.db[key] = value
-			default:
-				err = internal.ErrorInvalidFuelTypeUpdate
-			}
+		if err = ctx.Err(); err != nil {
+			return
+		}
 
+		if value.Id == id {
+			value.FuelType = internal.FuelType(fuelType)
+			r.db[key] = value
+			r.keys[key] = r.keysOf(value)
 			return
 		}
 	}
@@ -223,11 +659,26 @@ func (r *VehicleMap) UpdateFuelType(id int, fuelType string) (err error) {
 
 // FindAverageCapacityByBrand is a method that returns a value of average person capacity by brand
 func (r *VehicleMap) FindAverageCapacityByBrand(brand string) (avgCapacity float64, err error) {
+	avgCapacity, err = r.FindAverageCapacityByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageCapacityByBrandContext is a method that returns a value of average person capacity by brand, honoring the cancellation of ctx
+func (r *VehicleMap) FindAverageCapacityByBrandContext(ctx context.Context, brand string) (avgCapacity float64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var totalCapacity float64
 	var brandCount int
 
-	for _, value := range r.db {
-		if value.Brand == brand {
+	brand = r.key(internal.TextFieldBrand, brand)
+
+	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if r.keys[key].brand == brand {
 			totalCapacity += float64(value.Capacity)
 			brandCount++
 		}
@@ -245,9 +696,22 @@ func (r *VehicleMap) FindAverageCapacityByBrand(brand string) (avgCapacity float
 
 // FindByDimensions is a method that returns a map of vehicles that match the dimensions
 func (r *VehicleMap) FindByDimensions(minHeight float64, maxHeight float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByDimensionsContext(context.Background(), minHeight, maxHeight, minWidth, maxWidth)
+	return
+}
+
+// FindByDimensionsContext is a method that returns a map of vehicles that match the dimensions, honoring the cancellation of ctx
+func (r *VehicleMap) FindByDimensionsContext(ctx context.Context, minHeight float64, maxHeight float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
 		if value.Height >= minHeight && value.Height <= maxHeight && value.Width >= minWidth && value.Width <= maxWidth {
 			v[key] = value
 		}
@@ -262,9 +726,22 @@ func (r *VehicleMap) FindByDimensions(minHeight float64, maxHeight float64, minW
 
 // FindByWeightRange is a method that returns a map of vehicles that match the weight range
 func (r *VehicleMap) FindByWeightRange(minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByWeightRangeContext(context.Background(), minWeight, maxWeight)
+	return
+}
+
+// FindByWeightRangeContext is a method that returns a map of vehicles that match the weight range, honoring the cancellation of ctx
+func (r *VehicleMap) FindByWeightRangeContext(ctx context.Context, minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
 		if value.Weight >= minWeight && value.Weight <= maxWeight {
 			v[key] = value
 		}
@@ -276,3 +753,190 @@ func (r *VehicleMap) FindByWeightRange(minWeight float64, maxWeight float64) (v
 
 	return
 }
+
+// ReplaceAll is a method that atomically replaces every vehicle with the given ones
+func (r *VehicleMap) ReplaceAll(v map[int]internal.Vehicle) (err error) {
+	err = r.ReplaceAllContext(context.Background(), v)
+	return
+}
+
+// ReplaceAllContext is a method that atomically replaces every vehicle with the given ones, honoring the cancellation of ctx
+func (r *VehicleMap) ReplaceAllContext(ctx context.Context, v map[int]internal.Vehicle) (err error) {
+	db := make(map[int]internal.Vehicle, len(v))
+	for key, value := range v {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		db[key] = value
+	}
+
+	keys := r.index(db)
+	plates := r.indexPlates(db)
+	vins := r.indexVINs(db)
+	ix := newSearchIndex(db)
+
+	r.mu.Lock()
+	r.db = db
+	r.keys = keys
+	r.plates = plates
+	r.vins = vins
+	r.ix = ix
+	r.mu.Unlock()
+
+	return
+}
+
+// SearchContext is a method that returns up to limit vehicles matching the words of a query by brand, model,
+// color and registration, the most relevant first, honoring the cancellation of ctx.
+// A word that is a synonym of a brand also matches the brand
+func (r *VehicleMap) SearchContext(ctx context.Context, query string, limit int) (hits []internal.VehicleHit, err error) {
+	var terms []string
+	for _, term := range search.Tokenize(query) {
+		if brand := r.key(internal.TextFieldBrand, term); brand != term {
+			terms = append(terms, search.Tokenize(brand)...)
+			continue
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 0 {
+		err = internal.ErrorInvalidSearchQuery
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	matches := r.ix.Search(terms, limit)
+	hits = make([]internal.VehicleHit, 0, len(matches))
+	for _, m := range matches {
+		hits = append(hits, internal.VehicleHit{Vehicle: r.db[m.ID], Score: m.Score})
+	}
+
+	return
+}
+
+// FindByCatalogContext is a method that returns a map of vehicles linked by their brand and model to catalog
+// entries that match a filter, honoring the cancellation of ctx. No vehicle is linked without a catalog
+func (r *VehicleMap) FindByCatalogContext(ctx context.Context, filter internal.CatalogFilter) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	v = make(map[int]internal.Vehicle)
+	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if r.ct != nil && r.ct.Match(value.Brand, value.Model, filter) {
+			v[key] = value
+		}
+	}
+
+	if len(v) == 0 {
+		err = internal.ErrorVehicleNotFound
+	}
+
+	return
+}
+
+// FindByRegistrationContext is a method that returns a map of vehicles whose registration has the normalized
+// form of a registration, honoring the cancellation of ctx
+func (r *VehicleMap) FindByRegistrationContext(ctx context.Context, registration string) (v map[int]internal.Vehicle, err error) {
+	key := r.plate(registration)
+	if key == "" {
+		err = fmt.Errorf("%w: it is required", internal.ErrorInvalidRegistration)
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	ids := r.plates[key]
+	if len(ids) == 0 {
+		err = internal.ErrorVehicleNotFound
+		return
+	}
+
+	v = make(map[int]internal.Vehicle, len(ids))
+	for _, id := range ids {
+		v[id] = r.db[id]
+	}
+
+	return
+}
+
+// FindRegistrationDuplicatesContext is a method that returns the registrations shared by several vehicles,
+// sorted, honoring the cancellation of ctx
+func (r *VehicleMap) FindRegistrationDuplicatesContext(ctx context.Context) (d []internal.RegistrationDuplicate, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d = []internal.RegistrationDuplicate{}
+	for key, ids := range r.plates {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		if len(ids) > 1 {
+			d = append(d, internal.RegistrationDuplicate{Registration: key, IDs: slices.Clone(ids)})
+		}
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i].Registration < d[j].Registration })
+
+	return
+}
+
+// FindByVINContext is a method that returns the vehicle whose VIN has the normalized form of a VIN,
+// honoring the cancellation of ctx
+func (r *VehicleMap) FindByVINContext(ctx context.Context, vin string) (v internal.Vehicle, err error) {
+	key := r.vin(vin)
+	if key == "" {
+		err = fmt.Errorf("%w: it is required", internal.ErrorInvalidVIN)
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	id, ok := r.vins[key]
+	if !ok {
+		err = internal.ErrorVehicleNotFound
+		return
+	}
+	v = r.db[id]
+
+	return
+}
+
+// searchFields is a function that returns the fields of a vehicle in the text index, the brand
+// and model weigh the most as they are what is usually searched
+func searchFields(v internal.Vehicle) []search.Field {
+	return []search.Field{
+		{Text: v.Brand, Weight: 3},
+		{Text: v.Model, Weight: 3},
+		{Text: v.Registration, Weight: 2},
+		{Text: v.Color, Weight: 1},
+	}
+}
+
+// newSearchIndex is a function that returns the text index of the vehicles
+func newSearchIndex(db map[int]internal.Vehicle) *search.Index {
+	ix := search.NewIndex()
+	for id, v := range db {
+		ix.Add(id, searchFields(v)...)
+	}
+	return ix
+}
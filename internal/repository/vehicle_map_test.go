@@ -0,0 +1,142 @@
+package repository_test
+
+import (
+	"app/internal"
+	"app/internal/normalize"
+	"app/internal/registration"
+	"app/internal/repository"
+	"app/internal/vocabulary"
+	"context"
+	"errors"
+	"testing"
+)
+
+// vehicle is a function that returns a vehicle with an ID, a registration and a VIN
+func vehicle(id int, plate string, vin string) internal.Vehicle {
+	return internal.Vehicle{Id: id, VehicleAttributes: internal.VehicleAttributes{Brand: "Acura", Registration: plate, VIN: vin}}
+}
+
+func TestVehicleMap_CreateBatchContext(t *testing.T) {
+	cases := []struct {
+		name  string
+		batch []internal.Vehicle
+		ids   []int
+		err   error
+	}{
+		{
+			name:  "every vehicle created, the missing IDs generated",
+			batch: []internal.Vehicle{vehicle(0, "b-1", ""), vehicle(10, "B2", ""), vehicle(0, "B3", "")},
+			ids:   []int{2, 10, 4},
+		},
+		{
+			name:  "ID of a stored vehicle",
+			batch: []internal.Vehicle{vehicle(0, "B1", ""), vehicle(1, "B2", "")},
+			err:   internal.ErrorVehicleAlreadyExists,
+		},
+		{
+			name:  "registration of a stored vehicle, by its normalized form",
+			batch: []internal.Vehicle{vehicle(0, "B1", ""), vehicle(0, "a-1", "")},
+			err:   internal.ErrorVehicleAlreadyExists,
+		},
+		{
+			name:  "ID shared within the batch",
+			batch: []internal.Vehicle{vehicle(5, "B1", ""), vehicle(5, "B2", "")},
+			err:   internal.ErrorVehicleAlreadyExists,
+		},
+		{
+			name:  "generated ID taken by a later vehicle of the batch",
+			batch: []internal.Vehicle{vehicle(0, "B1", ""), vehicle(2, "B2", "")},
+			err:   internal.ErrorVehicleAlreadyExists,
+		},
+		{
+			name:  "registration shared within the batch",
+			batch: []internal.Vehicle{vehicle(0, "B1", ""), vehicle(0, "b 1", "")},
+			err:   internal.ErrorVehicleAlreadyExists,
+		},
+		{
+			name:  "VIN shared within the batch",
+			batch: []internal.Vehicle{vehicle(0, "B1", "V1"), vehicle(0, "B2", "V1")},
+			err:   internal.ErrorVehicleAlreadyExists,
+		},
+		{
+			name:  "invalid registration",
+			batch: []internal.Vehicle{vehicle(0, "B1", ""), vehicle(0, "", "")},
+			err:   internal.ErrorInvalidRegistration,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rg, err := registration.NewRegistry(registration.DefaultCountries, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rp := repository.NewVehicleMap(map[int]internal.Vehicle{1: vehicle(1, "A1", "")}, nil, nil, nil, rg, nil)
+			batch := append([]internal.Vehicle(nil), c.batch...)
+
+			err = rp.CreateBatchContext(context.Background(), batch)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+
+			all, _ := rp.FindAllContext(context.Background())
+			if c.err != nil {
+				// - a failed batch creates nothing and leaves its vehicles untouched
+				if len(all) != 1 {
+					t.Errorf("stored %d vehicles, want only the first one: %v", len(all), all)
+				}
+				for i := range batch {
+					if batch[i] != c.batch[i] {
+						t.Errorf("vehicle %d of the batch = %+v, want it untouched %+v", i+1, batch[i], c.batch[i])
+					}
+				}
+				return
+			}
+
+			if len(all) != 1+len(c.batch) {
+				t.Fatalf("stored %d vehicles, want %d", len(all), 1+len(c.batch))
+			}
+			for i, id := range c.ids {
+				if batch[i].Id != id {
+					t.Errorf("ID of vehicle %d of the batch = %d, want %d", i+1, batch[i].Id, id)
+				}
+				if all[id] != batch[i] {
+					t.Errorf("stored vehicle %d = %+v, want %+v", id, all[id], batch[i])
+				}
+			}
+			if batch[0].Registration != "B1" {
+				t.Errorf("registration = %s, want it normalized to B1", batch[0].Registration)
+			}
+		})
+	}
+}
+
+func TestVehicleMap_CreateContext_Vocabulary(t *testing.T) {
+	cases := []struct {
+		name         string
+		fuelType     internal.FuelType
+		transmission internal.Transmission
+		err          error
+	}{
+		{name: "neither given, as in the version 1"},
+		{name: "both known", fuelType: "diesel", transmission: "manual"},
+		{name: "unknown fuel type", fuelType: "steam", err: internal.ErrorInvalidFuelTypeUpdate},
+		{name: "unknown transmission", transmission: "pedals", err: internal.ErrorInvalidTransmissionValue},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			vc, err := vocabulary.NewRegistry(normalize.NewFolding(nil), vocabulary.DefaultFuelTypes, vocabulary.DefaultTransmissions)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rp := repository.NewVehicleMap(nil, nil, vc, nil, nil, nil)
+			v := vehicle(0, "A1", "")
+			v.FuelType, v.Transmission = c.fuelType, c.transmission
+
+			if err := rp.CreateContext(context.Background(), &v); !errors.Is(err, c.err) {
+				t.Errorf("error = %v, want %v", err, c.err)
+			}
+		})
+	}
+}
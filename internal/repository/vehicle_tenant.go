@@ -0,0 +1,429 @@
+package repository
+
+import (
+	"app/internal"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// NewVehicleTenant is a function that returns a new instance of VehicleTenant,
+// the namespaces compare the text fields by the keys of nm or as they are if nm is nil, check the
+// vehicles written against vc, rg and vn and link them to ct unless they are nil
+func NewVehicleTenant(nm internal.TextNormalizer, vc internal.Vocabulary, ct internal.Catalog, rg internal.Registrations, vn internal.VINDecoder) *VehicleTenant {
+	return &VehicleTenant{tenants: make(map[string]*VehicleMap), nm: nm, vc: vc, ct: ct, rg: rg, vn: vn}
+}
+
+// VehicleTenant is a struct that implements the VehicleTenantRepository interface with a VehicleMap per tenant,
+// so IDs and registrations are unique per tenant and no operation crosses tenants
+type VehicleTenant struct {
+	// mu guards tenants, the namespaces guard their own vehicles
+	mu sync.RWMutex
+	// tenants are the namespaces by tenant, created by the first ReplaceAll of each tenant
+	tenants map[string]*VehicleMap
+	// nm normalizes the text fields of every namespace
+	nm internal.TextNormalizer
+	// vc is the vocabulary of the vehicles written in every namespace
+	vc internal.Vocabulary
+	// ct is the catalog shared by every namespace
+	ct internal.Catalog
+	// rg are the registrations of the vehicles written in every namespace
+	rg internal.Registrations
+	// vn decodes the VINs of the vehicles written in every namespace
+	vn internal.VINDecoder
+}
+
+// namespace is a method that returns the namespace of the tenant of ctx
+func (r *VehicleTenant) namespace(ctx context.Context) (rp *VehicleMap, err error) {
+	tenant := internal.TenantFromContext(ctx)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rp, ok := r.tenants[tenant]
+	if !ok {
+		err = fmt.Errorf("%w: %s", internal.ErrorTenantNotFound, tenant)
+	}
+	return
+}
+
+// FindAll is a method that returns a map of all vehicles, within the default tenant
+func (r *VehicleTenant) FindAll() (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindAllContext(context.Background())
+	return
+}
+
+// FindAllContext is like FindAll but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindAllContext(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindAllContext(ctx)
+	return
+}
+
+// FindByID is a method that returns a vehicle by its ID, within the default tenant
+func (r *VehicleTenant) FindByID(id int) (v internal.Vehicle, err error) {
+	v, err = r.FindByIDContext(context.Background(), id)
+	return
+}
+
+// FindByIDContext is like FindByID but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindByIDContext(ctx context.Context, id int) (v internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByIDContext(ctx, id)
+	return
+}
+
+// Create is a method that creates a new vehicle, within the default tenant
+func (r *VehicleTenant) Create(v *internal.Vehicle) (err error) {
+	err = r.CreateContext(context.Background(), v)
+	return
+}
+
+// CreateContext is like Create but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) CreateContext(ctx context.Context, v *internal.Vehicle) (err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	err = rp.CreateContext(ctx, v)
+	return
+}
+
+// FindByColorAndYear is a method that returns a map of vehicles that match the color and year, within the default tenant
+func (r *VehicleTenant) FindByColorAndYear(color string, year int) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByColorAndYearContext(context.Background(), color, year)
+	return
+}
+
+// FindByColorAndYearContext is like FindByColorAndYear but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindByColorAndYearContext(ctx context.Context, color string, year int) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByColorAndYearContext(ctx, color, year)
+	return
+}
+
+// FindByBrandAndRangeYear is a method that returns a map of vehicles that match the brand and range year, within the default tenant
+func (r *VehicleTenant) FindByBrandAndRangeYear(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByBrandAndRangeYearContext(context.Background(), brand, startYear, endYear)
+	return
+}
+
+// FindByBrandAndRangeYearContext is like FindByBrandAndRangeYear but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindByBrandAndRangeYearContext(ctx context.Context, brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByBrandAndRangeYearContext(ctx, brand, startYear, endYear)
+	return
+}
+
+// FindAverageSpeedByBrand is a method that returns a map of vehicles that match the average speed and brand, within the default tenant
+func (r *VehicleTenant) FindAverageSpeedByBrand(brand string) (avgSpeed float64, err error) {
+	avgSpeed, err = r.FindAverageSpeedByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageSpeedByBrandContext is like FindAverageSpeedByBrand but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindAverageSpeedByBrandContext(ctx context.Context, brand string) (avgSpeed float64, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	avgSpeed, err = rp.FindAverageSpeedByBrandContext(ctx, brand)
+	return
+}
+
+// CreateBatch is a method that creates a batch of vehicles, within the default tenant
+func (r *VehicleTenant) CreateBatch(v []internal.Vehicle) (err error) {
+	err = r.CreateBatchContext(context.Background(), v)
+	return
+}
+
+// CreateBatchContext is like CreateBatch but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) CreateBatchContext(ctx context.Context, v []internal.Vehicle) (err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	err = rp.CreateBatchContext(ctx, v)
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the max speed of a vehicle, within the default tenant
+func (r *VehicleTenant) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	err = r.UpdateMaxSpeedContext(context.Background(), id, maxSpeed)
+	return
+}
+
+// UpdateMaxSpeedContext is like UpdateMaxSpeed but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) UpdateMaxSpeedContext(ctx context.Context, id int, maxSpeed float64) (err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	err = rp.UpdateMaxSpeedContext(ctx, id, maxSpeed)
+	return
+}
+
+// FindByFuelType is a method that returns a map of vehicles that match the fuel type, within the default tenant
+func (r *VehicleTenant) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByFuelTypeContext(context.Background(), fuelType)
+	return
+}
+
+// FindByFuelTypeContext is like FindByFuelType but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindByFuelTypeContext(ctx context.Context, fuelType string) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByFuelTypeContext(ctx, fuelType)
+	return
+}
+
+// Delete is a method that deletes a vehicle, within the default tenant
+func (r *VehicleTenant) Delete(id int) (err error) {
+	err = r.DeleteContext(context.Background(), id)
+	return
+}
+
+// DeleteContext is like Delete but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) DeleteContext(ctx context.Context, id int) (err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	err = rp.DeleteContext(ctx, id)
+	return
+}
+
+// FindByTransmissionType is a method that returns a map of vehicles that match the transmission type, within the default tenant
+func (r *VehicleTenant) FindByTransmissionType(transmissionType string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByTransmissionTypeContext(context.Background(), transmissionType)
+	return
+}
+
+// FindByTransmissionTypeContext is like FindByTransmissionType but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindByTransmissionTypeContext(ctx context.Context, transmissionType string) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByTransmissionTypeContext(ctx, transmissionType)
+	return
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle, within the default tenant
+func (r *VehicleTenant) UpdateFuelType(id int, fuelType string) (err error) {
+	err = r.UpdateFuelTypeContext(context.Background(), id, fuelType)
+	return
+}
+
+// UpdateFuelTypeContext is like UpdateFuelType but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) UpdateFuelTypeContext(ctx context.Context, id int, fuelType string) (err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	err = rp.UpdateFuelTypeContext(ctx, id, fuelType)
+	return
+}
+
+// FindAverageCapacityByBrand is a method that returns a map of vehicles that match the average person capacity and brand, within the default tenant
+func (r *VehicleTenant) FindAverageCapacityByBrand(brand string) (avgCapacity float64, err error) {
+	avgCapacity, err = r.FindAverageCapacityByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageCapacityByBrandContext is like FindAverageCapacityByBrand but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindAverageCapacityByBrandContext(ctx context.Context, brand string) (avgCapacity float64, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	avgCapacity, err = rp.FindAverageCapacityByBrandContext(ctx, brand)
+	return
+}
+
+// FindByDimensions is a method that returns a map of vehicles that match the dimensions, within the default tenant
+func (r *VehicleTenant) FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByDimensionsContext(context.Background(), minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// FindByDimensionsContext is like FindByDimensions but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindByDimensionsContext(ctx context.Context, minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByDimensionsContext(ctx, minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// FindByWeightRange is a method that returns a map of vehicles that match the weight range, within the default tenant
+func (r *VehicleTenant) FindByWeightRange(minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	v, err = r.FindByWeightRangeContext(context.Background(), minWeight, maxWeight)
+	return
+}
+
+// FindByWeightRangeContext is like FindByWeightRange but honors the cancellation and deadline of ctx, within the tenant of ctx
+func (r *VehicleTenant) FindByWeightRangeContext(ctx context.Context, minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByWeightRangeContext(ctx, minWeight, maxWeight)
+	return
+}
+
+// SearchContext is a method that returns up to limit vehicles matching the words of a query, the most relevant first,
+// within the tenant of ctx
+func (r *VehicleTenant) SearchContext(ctx context.Context, query string, limit int) (hits []internal.VehicleHit, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	hits, err = rp.SearchContext(ctx, query, limit)
+	return
+}
+
+// FindByCatalogContext is a method that returns a map of vehicles linked to catalog entries that match a filter,
+// within the tenant of ctx
+func (r *VehicleTenant) FindByCatalogContext(ctx context.Context, filter internal.CatalogFilter) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByCatalogContext(ctx, filter)
+	return
+}
+
+// FindByRegistrationContext is a method that returns a map of vehicles whose registration has the normalized
+// form of a registration, within the tenant of ctx
+func (r *VehicleTenant) FindByRegistrationContext(ctx context.Context, registration string) (v map[int]internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByRegistrationContext(ctx, registration)
+	return
+}
+
+// FindRegistrationDuplicatesContext is a method that returns the registrations shared by several vehicles, sorted,
+// within the tenant of ctx
+func (r *VehicleTenant) FindRegistrationDuplicatesContext(ctx context.Context) (d []internal.RegistrationDuplicate, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	d, err = rp.FindRegistrationDuplicatesContext(ctx)
+	return
+}
+
+// FindByVINContext is a method that returns the vehicle whose VIN has the normalized form of a VIN,
+// within the tenant of ctx
+func (r *VehicleTenant) FindByVINContext(ctx context.Context, vin string) (v internal.Vehicle, err error) {
+	rp, err := r.namespace(ctx)
+	if err != nil {
+		return
+	}
+
+	v, err = rp.FindByVINContext(ctx, vin)
+	return
+}
+
+// ReplaceAll is a method that atomically replaces every vehicle with the given ones, within the default tenant
+func (r *VehicleTenant) ReplaceAll(v map[int]internal.Vehicle) (err error) {
+	err = r.ReplaceAllContext(context.Background(), v)
+	return
+}
+
+// ReplaceAllContext is like ReplaceAll but honors the cancellation and deadline of ctx, creating the namespace of the tenant of ctx if needed
+func (r *VehicleTenant) ReplaceAllContext(ctx context.Context, v map[int]internal.Vehicle) (err error) {
+	tenant := internal.TenantFromContext(ctx)
+
+	r.mu.Lock()
+	rp, ok := r.tenants[tenant]
+	if !ok {
+		rp = NewVehicleMap(nil, r.nm, r.vc, r.ct, r.rg, r.vn)
+		r.tenants[tenant] = rp
+	}
+	r.mu.Unlock()
+
+	err = rp.ReplaceAllContext(ctx, v)
+	return
+}
+
+// HasTenant is a method that reports whether a tenant has a namespace
+func (r *VehicleTenant) HasTenant(tenant string) (ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok = r.tenants[tenant]
+	return
+}
+
+// Tenants is a method that returns the number of vehicles of each tenant
+func (r *VehicleTenant) Tenants() (t map[string]int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t = make(map[string]int, len(r.tenants))
+	for tenant, rp := range r.tenants {
+		rp.mu.RLock()
+		t[tenant] = len(rp.db)
+		rp.mu.RUnlock()
+	}
+	return
+}
+
+// FindAllTenants is a method that returns the vehicles of every tenant, honoring the cancellation of ctx
+func (r *VehicleTenant) FindAllTenants(ctx context.Context) (v map[string]map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	tenants := make([]string, 0, len(r.tenants))
+	for tenant := range r.tenants {
+		tenants = append(tenants, tenant)
+	}
+	r.mu.RUnlock()
+
+	v = make(map[string]map[int]internal.Vehicle, len(tenants))
+	for _, tenant := range tenants {
+		var vehicles map[int]internal.Vehicle
+		vehicles, err = r.FindAllContext(internal.WithTenant(ctx, tenant))
+		if err != nil {
+			return
+		}
+		v[tenant] = vehicles
+	}
+	return
+}
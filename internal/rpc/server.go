@@ -0,0 +1,347 @@
+package rpc
+
+import (
+	"app/internal"
+	"app/internal/auth"
+	"app/internal/handler"
+	"app/internal/logging"
+	"app/internal/rpc/vehiclepb"
+	"app/internal/tracing"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys of the calls, the same as the headers of the REST API
+const (
+	MetadataAuthorization = "authorization"
+	MetadataAPIKey        = "x-api-key"
+	MetadataTenant        = "x-tenant-id"
+	MetadataRequestID     = "x-request-id"
+	MetadataTraceparent   = "traceparent"
+)
+
+// rule is a struct that represents how a method is governed
+type rule struct {
+	// handler is the name of the method in the authorization policy
+	handler string
+	// class is the class of the method in the rate limiter
+	class string
+	// timeout is the deadline of the method, zero for none
+	timeout time.Duration
+}
+
+// ConfigServer is a struct that represents the configuration for NewServer
+type ConfigServer struct {
+	// Service is the service behind the methods
+	Service internal.VehicleService
+	// Feed is the feed of the changes streamed by WatchChanges
+	Feed internal.VehicleChangeFeed
+	// Reloader reports whether the dataset has been loaded, the calls fail with Unavailable until then
+	Reloader internal.VehicleReloader
+	// Authenticator authenticates the calls and authorizes them with the policy (auth.DefaultPolicy)
+	Authenticator *auth.Authenticator
+	// Tenants resolves the tenant of each call
+	Tenants *handler.TenantDefault
+	// RateLimiter limits the calls by client and class
+	RateLimiter internal.RateLimiter
+	// Tracer starts a span per call
+	Tracer *tracing.Tracer
+	// Logger logs each call
+	Logger *slog.Logger
+	// RequestTimeout is the deadline of each call, streams of changes excepted
+	RequestTimeout time.Duration
+	// BatchTimeout is the deadline of the batch creation
+	BatchTimeout time.Duration
+	// Closing is done once the server shuts down, ending the streams of changes so they do not hold the drain
+	Closing context.Context
+}
+
+// NewServer is a function that returns a gRPC server serving the vehicle service, each call is
+// governed as the REST routes: readiness, authentication, policy, tenant, rate limit and timeout
+func NewServer(cfg ConfigServer) *grpc.Server {
+	// default values
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	if cfg.Tracer == nil {
+		cfg.Tracer = tracing.NewTracer(nil)
+	}
+
+	read := func(name string) rule {
+		return rule{handler: "VehicleServer." + name, class: internal.RateClassRead, timeout: cfg.RequestTimeout}
+	}
+	write := func(name string) rule {
+		return rule{handler: "VehicleServer." + name, class: internal.RateClassWrite, timeout: cfg.RequestTimeout}
+	}
+	gv := &governor{
+		cfg: cfg,
+		rules: map[string]rule{
+			"ListVehicles":            read("ListVehicles"),
+			"GetVehicle":              read("GetVehicle"),
+			"CreateVehicle":           write("CreateVehicle"),
+			"CreateVehicles":          {handler: "VehicleServer.CreateVehicles", class: internal.RateClassBatch, timeout: cfg.BatchTimeout},
+			"FindByColorAndYear":      read("FindByColorAndYear"),
+			"FindByBrandAndRangeYear": read("FindByBrandAndRangeYear"),
+			"FindByFuelType":          read("FindByFuelType"),
+			"FindByTransmissionType":  read("FindByTransmissionType"),
+			"FindByDimensions":        read("FindByDimensions"),
+			"FindByWeightRange":       read("FindByWeightRange"),
+			"AverageSpeedByBrand":     read("AverageSpeedByBrand"),
+			"AverageCapacityByBrand":  read("AverageCapacityByBrand"),
+			"UpdateMaxSpeed":          write("UpdateMaxSpeed"),
+			"UpdateFuelType":          write("UpdateFuelType"),
+			"DeleteVehicle":           write("DeleteVehicle"),
+			// - a stream of changes lasts as long as the client wants it
+			"WatchChanges": {handler: "VehicleServer.WatchChanges", class: internal.RateClassRead},
+		},
+	}
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(gv.unary),
+		grpc.ChainStreamInterceptor(gv.stream),
+	)
+	vehiclepb.RegisterVehicleServiceServer(srv, NewVehicleServer(cfg.Service, cfg.Feed))
+	return srv
+}
+
+// governor is a struct with the interceptors governing the calls
+type governor struct {
+	// cfg is the configuration of the server
+	cfg ConfigServer
+	// rules are the rules of the methods by name
+	rules map[string]rule
+}
+
+// unary is a method that governs the unary calls
+func (g *governor) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, h grpc.UnaryHandler) (res any, err error) {
+	ctx, done, err := g.begin(ctx, info.FullMethod)
+	defer func() { done(err) }()
+	if err != nil {
+		return
+	}
+
+	res, err = h(ctx, req)
+	return
+}
+
+// stream is a method that governs the streaming calls
+func (g *governor) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, h grpc.StreamHandler) (err error) {
+	ctx, done, err := g.begin(ss.Context(), info.FullMethod)
+	defer func() { done(err) }()
+	if err != nil {
+		return
+	}
+
+	err = h(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	return
+}
+
+// begin is a method that prepares the context of a call, err is the status the call is rejected with.
+// done must be called with the error the call ends with
+func (g *governor) begin(ctx context.Context, fullMethod string) (_ context.Context, done func(err error), err error) {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+	r := g.rules[path.Base(fullMethod)]
+
+	// - request ID and span, as the logging and tracing middlewares
+	id := first(md, MetadataRequestID)
+	if id == "" || len(id) > 128 {
+		id = logging.NewRequestID()
+	}
+	ctx = logging.WithRequestID(ctx, id)
+	ctx = tracing.Extract(ctx, http.Header{"Traceparent": md.Get(MetadataTraceparent)})
+	ctx, span := g.cfg.Tracer.Start(ctx, "grpc "+fullMethod)
+	cancel := context.CancelFunc(func() {})
+
+	done = func(err error) {
+		cancel()
+		st := status.Convert(err)
+		span.SetAttribute("rpc.grpc.status_code", st.Code().String())
+		var spanErr error
+		if isServerError(st.Code()) {
+			spanErr = err
+		}
+		span.End(spanErr)
+
+		attrs := []slog.Attr{
+			slog.String("method", fullMethod),
+			slog.String("code", st.Code().String()),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			attrs = append(attrs, slog.String("remote_addr", p.Addr.String()))
+		}
+		level := slog.LevelInfo
+		switch {
+		case isServerError(st.Code()):
+			level = slog.LevelError
+			attrs = append(attrs, slog.String("error", st.Message()))
+		case st.Code() != codes.OK:
+			level = slog.LevelWarn
+			attrs = append(attrs, slog.String("error", st.Message()))
+		}
+		g.cfg.Logger.LogAttrs(ctx, level, "rpc", attrs...)
+	}
+
+	if r.handler == "" {
+		err = status.Error(codes.Unimplemented, fullMethod)
+		return ctx, done, err
+	}
+
+	// - readiness
+	if g.cfg.Reloader != nil && g.cfg.Reloader.Status().LastSuccess.IsZero() {
+		err = status.Error(codes.Unavailable, internal.ErrorDatasetNotLoaded.Error())
+		return ctx, done, err
+	}
+
+	// - authentication and policy
+	if au := g.cfg.Authenticator; au != nil && au.Enabled() {
+		var p internal.Principal
+		p, err = au.AuthenticateCredential(credential(md))
+		if err != nil {
+			err = statusError(err)
+			return ctx, done, err
+		}
+		ctx = internal.WithPrincipal(ctx, p)
+		if !au.Authorize(r.handler, p) {
+			err = status.Error(codes.PermissionDenied, internal.ErrorForbidden.Error()+": "+r.handler)
+			return ctx, done, err
+		}
+	}
+
+	// - tenant
+	if g.cfg.Tenants != nil {
+		var tenant string
+		tenant, err = g.cfg.Tenants.ResolveTenant(ctx, first(md, MetadataTenant))
+		if err != nil {
+			err = statusError(err)
+			return ctx, done, err
+		}
+		ctx = internal.WithTenant(ctx, tenant)
+	}
+
+	// - rate limit
+	if g.cfg.RateLimiter != nil {
+		if d := g.cfg.RateLimiter.Take(clientKey(ctx), r.class); !d.Allowed {
+			err = status.Error(codes.ResourceExhausted, d.Err.Error())
+			return ctx, done, err
+		}
+	}
+
+	// - timeout, the streams of changes have none and end once the server is closing
+	var cancelCall context.CancelFunc
+	switch {
+	case r.timeout > 0:
+		ctx, cancelCall = context.WithTimeout(ctx, r.timeout)
+		cancel = cancelCall
+	case g.cfg.Closing != nil:
+		ctx, cancelCall = context.WithCancel(ctx)
+		stop := context.AfterFunc(g.cfg.Closing, cancelCall)
+		cancel = func() {
+			stop()
+			cancelCall()
+		}
+	}
+	return ctx, done, nil
+}
+
+// serverStream is a stream carrying the context prepared for the call
+type serverStream struct {
+	grpc.ServerStream
+	// ctx is the context of the call
+	ctx context.Context
+}
+
+// Context is a method that returns the context of the call
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// statusError is a function that returns the status of an error of the domain, nil if err is nil
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, internal.ErrorVehicleNotFound), errors.Is(err, internal.ErrorTenantNotFound):
+		code = codes.NotFound
+	case errors.Is(err, internal.ErrorVehicleAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, internal.ErrorInvalidMaxSpeedRange), errors.Is(err, internal.ErrorInvalidFuelTypeUpdate),
+		errors.Is(err, internal.ErrorInvalidFuelType), errors.Is(err, internal.ErrorInvalidTransmissionType),
+		errors.Is(err, internal.ErrorInvalidTransmissionValue), errors.Is(err, internal.ErrorVehicleNotInCatalog),
+		errors.Is(err, internal.ErrorInvalidRegistration), errors.Is(err, internal.ErrorInvalidVIN),
+		errors.Is(err, internal.ErrorVINMismatch), errors.Is(err, internal.ErrorTenantInvalid):
+		code = codes.InvalidArgument
+	case errors.Is(err, internal.ErrorAPIKeyMissing), errors.Is(err, internal.ErrorAPIKeyInvalid),
+		errors.Is(err, internal.ErrorTokenInvalid), errors.Is(err, internal.ErrorTokenExpired):
+		code = codes.Unauthenticated
+	case errors.Is(err, internal.ErrorTenantForbidden):
+		code = codes.PermissionDenied
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, internal.ErrorRequestTimeout.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, internal.ErrorRequestCanceled.Error())
+	default:
+		return status.Error(code, internal.ErrorInternalServer.Error())
+	}
+	return status.Error(code, err.Error())
+}
+
+// isServerError is a function that reports whether a code is a failure of the server rather than of the call
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.Unimplemented:
+		return true
+	}
+	return false
+}
+
+// credential is a function that returns the API key or token of a call, empty if there is none
+func credential(md metadata.MD) string {
+	if secret := first(md, MetadataAPIKey); secret != "" {
+		return strings.TrimSpace(secret)
+	}
+	scheme, secret, ok := strings.Cut(first(md, MetadataAuthorization), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(secret)
+	}
+	return ""
+}
+
+// clientKey is a function that returns the key a call is limited by, as ratelimit.ClientKey
+func clientKey(ctx context.Context) string {
+	if p, ok := internal.PrincipalFromContext(ctx); ok {
+		return p.Method + ":" + p.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		host := p.Addr.String()
+		if i := strings.LastIndex(host, ":"); i > 0 {
+			host = host[:i]
+		}
+		return "ip:" + host
+	}
+	return "ip:unknown"
+}
+
+// first is a function that returns the first value of a metadata key, empty if there is none
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
@@ -0,0 +1,342 @@
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative vehiclepb/vehicle.proto
+
+import (
+	"app/internal"
+	"app/internal/rpc/vehiclepb"
+	"context"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewVehicleServer is a function that returns a new instance of VehicleServer
+func NewVehicleServer(sv internal.VehicleService, feed internal.VehicleChangeFeed) *VehicleServer {
+	return &VehicleServer{sv: sv, feed: feed}
+}
+
+// VehicleServer is a struct that implements the gRPC VehicleService on top of the vehicle service
+type VehicleServer struct {
+	vehiclepb.UnimplementedVehicleServiceServer
+	// sv is the service that will be used by the server
+	sv internal.VehicleService
+	// feed is the feed of the changes streamed by WatchChanges
+	feed internal.VehicleChangeFeed
+}
+
+// ListVehicles is a method that streams every vehicle
+func (s *VehicleServer) ListVehicles(req *vehiclepb.ListVehiclesRequest, stream vehiclepb.VehicleService_ListVehiclesServer) error {
+	v, err := s.sv.FindAllContext(stream.Context())
+	return sendVehicles(stream, v, err)
+}
+
+// GetVehicle is a method that returns a vehicle by its ID
+func (s *VehicleServer) GetVehicle(ctx context.Context, req *vehiclepb.GetVehicleRequest) (*vehiclepb.Vehicle, error) {
+	if req.GetId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorParseID.Error())
+	}
+
+	v, err := s.sv.FindByIDContext(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return vehicleToPB(v), nil
+}
+
+// CreateVehicle is a method that creates a vehicle
+func (s *VehicleServer) CreateVehicle(ctx context.Context, req *vehiclepb.CreateVehicleRequest) (*vehiclepb.Vehicle, error) {
+	if err := validateVehicle(req.GetVehicle()); err != nil {
+		return nil, err
+	}
+
+	v := vehicleFromPB(req.GetVehicle())
+	if err := s.sv.CreateContext(ctx, &v); err != nil {
+		return nil, statusError(err)
+	}
+	return vehicleToPB(v), nil
+}
+
+// CreateVehicles is a method that creates several vehicles, none is created if any fails
+func (s *VehicleServer) CreateVehicles(ctx context.Context, req *vehiclepb.CreateVehiclesRequest) (*vehiclepb.CreateVehiclesResponse, error) {
+	if len(req.GetVehicles()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorInvalidVehicles.Error())
+	}
+	vehicles := make([]internal.Vehicle, 0, len(req.GetVehicles()))
+	for _, pb := range req.GetVehicles() {
+		if err := validateVehicle(pb); err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, vehicleFromPB(pb))
+	}
+
+	if err := s.sv.CreateBatchContext(ctx, vehicles); err != nil {
+		return nil, statusError(err)
+	}
+	return &vehiclepb.CreateVehiclesResponse{Created: int32(len(vehicles))}, nil
+}
+
+// FindByColorAndYear is a method that streams the vehicles of a color fabricated in a year
+func (s *VehicleServer) FindByColorAndYear(req *vehiclepb.FindByColorAndYearRequest, stream vehiclepb.VehicleService_FindByColorAndYearServer) error {
+	if req.GetColor() == "" {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidColorAndYear.Error())
+	}
+	if req.GetYear() < 0 {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidYear.Error())
+	}
+
+	v, err := s.sv.FindByColorAndYearContext(stream.Context(), req.GetColor(), int(req.GetYear()))
+	return sendVehicles(stream, v, err)
+}
+
+// FindByBrandAndRangeYear is a method that streams the vehicles of a brand fabricated between two years
+func (s *VehicleServer) FindByBrandAndRangeYear(req *vehiclepb.FindByBrandAndRangeYearRequest, stream vehiclepb.VehicleService_FindByBrandAndRangeYearServer) error {
+	if req.GetBrand() == "" {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidBrandAndRangeYear.Error())
+	}
+	if req.GetStartYear() < 0 || req.GetEndYear() < 0 {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidYear.Error())
+	}
+
+	v, err := s.sv.FindByBrandAndRangeYearContext(stream.Context(), req.GetBrand(), int(req.GetStartYear()), int(req.GetEndYear()))
+	return sendVehicles(stream, v, err)
+}
+
+// FindByFuelType is a method that streams the vehicles of a fuel type
+func (s *VehicleServer) FindByFuelType(req *vehiclepb.FindByFuelTypeRequest, stream vehiclepb.VehicleService_FindByFuelTypeServer) error {
+	if req.GetFuelType() == "" {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidFuelType.Error())
+	}
+
+	v, err := s.sv.FindByFuelTypeContext(stream.Context(), req.GetFuelType())
+	return sendVehicles(stream, v, err)
+}
+
+// FindByTransmissionType is a method that streams the vehicles of a transmission type
+func (s *VehicleServer) FindByTransmissionType(req *vehiclepb.FindByTransmissionTypeRequest, stream vehiclepb.VehicleService_FindByTransmissionTypeServer) error {
+	if req.GetTransmission() == "" {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidTransmissionType.Error())
+	}
+
+	v, err := s.sv.FindByTransmissionTypeContext(stream.Context(), req.GetTransmission())
+	return sendVehicles(stream, v, err)
+}
+
+// FindByDimensions is a method that streams the vehicles within ranges of height and width
+func (s *VehicleServer) FindByDimensions(req *vehiclepb.FindByDimensionsRequest, stream vehiclepb.VehicleService_FindByDimensionsServer) error {
+	height, width := req.GetHeight(), req.GetWidth()
+	if height == nil || width == nil {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidHeightAndWidth.Error())
+	}
+	if height.GetMin() > height.GetMax() || width.GetMin() > width.GetMax() {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidDimension.Error())
+	}
+
+	v, err := s.sv.FindByDimensionsContext(stream.Context(), height.GetMin(), height.GetMax(), width.GetMin(), width.GetMax())
+	return sendVehicles(stream, v, err)
+}
+
+// FindByWeightRange is a method that streams the vehicles within a range of weight
+func (s *VehicleServer) FindByWeightRange(req *vehiclepb.FindByWeightRangeRequest, stream vehiclepb.VehicleService_FindByWeightRangeServer) error {
+	weight := req.GetWeight()
+	if weight == nil || weight.GetMin() > weight.GetMax() {
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidWeightRange.Error())
+	}
+
+	v, err := s.sv.FindByWeightRangeContext(stream.Context(), weight.GetMin(), weight.GetMax())
+	return sendVehicles(stream, v, err)
+}
+
+// AverageSpeedByBrand is a method that returns the average maximum speed of the vehicles of a brand
+func (s *VehicleServer) AverageSpeedByBrand(ctx context.Context, req *vehiclepb.BrandRequest) (*vehiclepb.AverageResponse, error) {
+	if req.GetBrand() == "" {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorInvalidBrand.Error())
+	}
+
+	avg, err := s.sv.FindAverageSpeedByBrandContext(ctx, req.GetBrand())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &vehiclepb.AverageResponse{Brand: req.GetBrand(), Average: avg}, nil
+}
+
+// AverageCapacityByBrand is a method that returns the average capacity in passengers of the vehicles of a brand
+func (s *VehicleServer) AverageCapacityByBrand(ctx context.Context, req *vehiclepb.BrandRequest) (*vehiclepb.AverageResponse, error) {
+	if req.GetBrand() == "" {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorInvalidBrand.Error())
+	}
+
+	avg, err := s.sv.FindAverageCapacityByBrandContext(ctx, req.GetBrand())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &vehiclepb.AverageResponse{Brand: req.GetBrand(), Average: avg}, nil
+}
+
+// UpdateMaxSpeed is a method that updates the maximum speed of a vehicle
+func (s *VehicleServer) UpdateMaxSpeed(ctx context.Context, req *vehiclepb.UpdateMaxSpeedRequest) (*emptypb.Empty, error) {
+	if req.GetId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorParseID.Error())
+	}
+	if req.GetMaxSpeed() == 0 {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorInvalidMaxSpeed.Error())
+	}
+
+	if err := s.sv.UpdateMaxSpeedContext(ctx, int(req.GetId()), req.GetMaxSpeed()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle
+func (s *VehicleServer) UpdateFuelType(ctx context.Context, req *vehiclepb.UpdateFuelTypeRequest) (*emptypb.Empty, error) {
+	if req.GetId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorParseID.Error())
+	}
+	if req.GetFuelType() == "" {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorInvalidFuelType.Error())
+	}
+
+	if err := s.sv.UpdateFuelTypeContext(ctx, int(req.GetId()), req.GetFuelType()); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteVehicle is a method that deletes a vehicle
+func (s *VehicleServer) DeleteVehicle(ctx context.Context, req *vehiclepb.DeleteVehicleRequest) (*emptypb.Empty, error) {
+	if req.GetId() < 0 {
+		return nil, status.Error(codes.InvalidArgument, internal.ErrorParseID.Error())
+	}
+
+	if err := s.sv.DeleteContext(ctx, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchChanges is a method that streams the changes made to the vehicles of the tenant of the call
+// until the client cancels it or the server shuts down
+func (s *VehicleServer) WatchChanges(req *vehiclepb.WatchChangesRequest, stream vehiclepb.VehicleService_WatchChangesServer) error {
+	ctx := stream.Context()
+	tenant := internal.TenantFromContext(ctx)
+
+	for change := range s.feed.Subscribe(ctx) {
+		if change.Tenant != tenant {
+			continue
+		}
+		if err := stream.Send(changeToPB(change)); err != nil {
+			return err
+		}
+	}
+	// - the subscription is only closed once the stream is done
+	return statusError(ctx.Err())
+}
+
+// vehicleSender is the stream of the methods streaming vehicles
+type vehicleSender interface {
+	Send(*vehiclepb.Vehicle) error
+}
+
+// sendVehicles is a function that streams the vehicles found by a query in ID order
+func sendVehicles(stream vehicleSender, v map[int]internal.Vehicle, err error) error {
+	if err != nil {
+		return statusError(err)
+	}
+
+	ids := make([]int, 0, len(v))
+	for id := range v {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if err := stream.Send(vehicleToPB(v[id])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateVehicle is a function that checks the fields the REST API requires to create a vehicle
+func validateVehicle(v *vehiclepb.Vehicle) error {
+	switch {
+	case v == nil:
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidBodyRequest.Error())
+	case v.GetId() < 0:
+		return status.Error(codes.InvalidArgument, internal.ErrorParseID.Error())
+	case v.GetBrand() == "", v.GetModel() == "", v.GetRegistration() == "", v.GetColor() == "", v.GetYear() == 0:
+		return status.Error(codes.InvalidArgument, internal.ErrorInvalidRequest.Error()+": brand, model, registration, color and year are required")
+	}
+	return nil
+}
+
+// vehicleToPB is a function that returns the message of a vehicle
+func vehicleToPB(v internal.Vehicle) *vehiclepb.Vehicle {
+	return &vehiclepb.Vehicle{
+		Id:           int64(v.Id),
+		Brand:        v.Brand,
+		Model:        v.Model,
+		Registration: v.Registration,
+		Vin:          v.VIN,
+		Color:        v.Color,
+		Year:         int32(v.FabricationYear),
+		Passengers:   int32(v.Capacity),
+		MaxSpeed:     v.MaxSpeed,
+		FuelType:     string(v.FuelType),
+		Transmission: string(v.Transmission),
+		Weight:       v.Weight,
+		Height:       v.Height,
+		Length:       v.Length,
+		Width:        v.Width,
+	}
+}
+
+// vehicleFromPB is a function that returns the vehicle of a message
+func vehicleFromPB(pb *vehiclepb.Vehicle) internal.Vehicle {
+	return internal.Vehicle{
+		Id: int(pb.GetId()),
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           pb.GetBrand(),
+			Model:           pb.GetModel(),
+			Registration:    pb.GetRegistration(),
+			VIN:             pb.GetVin(),
+			Color:           pb.GetColor(),
+			FabricationYear: int(pb.GetYear()),
+			Capacity:        int(pb.GetPassengers()),
+			MaxSpeed:        pb.GetMaxSpeed(),
+			FuelType:        internal.FuelType(pb.GetFuelType()),
+			Transmission:    internal.Transmission(pb.GetTransmission()),
+			Weight:          pb.GetWeight(),
+			Dimensions: internal.Dimensions{
+				Height: pb.GetHeight(),
+				Length: pb.GetLength(),
+				Width:  pb.GetWidth(),
+			},
+		},
+	}
+}
+
+// changeTypes are the message types of the changes
+var changeTypes = map[string]vehiclepb.VehicleChange_Type{
+	internal.VehicleChangeCreated:  vehiclepb.VehicleChange_TYPE_CREATED,
+	internal.VehicleChangeUpdated:  vehiclepb.VehicleChange_TYPE_UPDATED,
+	internal.VehicleChangeDeleted:  vehiclepb.VehicleChange_TYPE_DELETED,
+	internal.VehicleChangeReloaded: vehiclepb.VehicleChange_TYPE_RELOADED,
+}
+
+// changeToPB is a function that returns the message of a change
+func changeToPB(c internal.VehicleChange) *vehiclepb.VehicleChange {
+	pb := &vehiclepb.VehicleChange{
+		Type:   changeTypes[c.Type],
+		Tenant: c.Tenant,
+		Id:     int64(c.ID),
+		Time:   timestamppb.New(c.Time),
+	}
+	if c.Type == internal.VehicleChangeCreated || c.Type == internal.VehicleChangeUpdated {
+		pb.Vehicle = vehicleToPB(c.Vehicle)
+	}
+	return pb
+}
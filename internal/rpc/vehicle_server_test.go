@@ -0,0 +1,351 @@
+package rpc_test
+
+import (
+	"app/internal"
+	"app/internal/auth"
+	"app/internal/handler"
+	"app/internal/repository"
+	"app/internal/rpc"
+	"app/internal/rpc/vehiclepb"
+	"app/internal/service"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// reloaderStub is a struct that implements the VehicleReloader interface with a fixed status
+type reloaderStub struct {
+	// status is the status reported
+	status internal.ReloadStatus
+}
+
+// Reload is a method that returns the fixed status
+func (r *reloaderStub) Reload() (s internal.ReloadStatus, err error) {
+	return r.status, nil
+}
+
+// Status is a method that returns the fixed status
+func (r *reloaderStub) Status() (s internal.ReloadStatus) {
+	return r.status
+}
+
+// fixture is a struct with a server served over an in-memory connection and its client
+type fixture struct {
+	// client is the client of the server
+	client vehiclepb.VehicleServiceClient
+	// feed is the feed of the changes of the server
+	feed *service.VehicleFeed
+}
+
+// newFixture is a function that serves the vehicle service over bufconn with the vehicles 1 and 2 loaded
+// in the default tenant, cfg is completed with the service, the feed and the tenants
+func newFixture(t *testing.T, cfg rpc.ConfigServer) fixture {
+	t.Helper()
+
+	rp := repository.NewVehicleTenant(nil, nil, nil, nil, nil)
+	err := rp.ReplaceAllContext(internal.WithTenant(context.Background(), internal.DefaultTenant), map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Acura", Model: "MDX", Registration: "A1", Color: "Red", FabricationYear: 2010}},
+		2: {Id: 2, VehicleAttributes: internal.VehicleAttributes{Brand: "Audi", Model: "A4", Registration: "A2", Color: "Blue", FabricationYear: 2015}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := service.NewVehicleFeed(service.NewVehicleDefault(rp))
+	cfg.Service = fd
+	cfg.Feed = fd
+	cfg.Tenants = handler.NewTenantDefault(rp)
+	cfg.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	lis := bufconn.Listen(1 << 20)
+	srv := rpc.NewServer(cfg)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return fixture{client: vehiclepb.NewVehicleServiceClient(conn), feed: fd}
+}
+
+// newVehicle is a function that returns the message of a valid vehicle
+func newVehicle(id int64, registration string) *vehiclepb.Vehicle {
+	return &vehiclepb.Vehicle{Id: id, Brand: "BMW", Model: "X5", Registration: registration, Color: "Black", Year: 2020}
+}
+
+func TestVehicleServer_Unary(t *testing.T) {
+	cases := []struct {
+		name string
+		call func(ctx context.Context, c vehiclepb.VehicleServiceClient) error
+		code codes.Code
+	}{
+		{
+			name: "get a vehicle",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				v, err := c.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{Id: 2})
+				if err == nil && v.GetRegistration() != "A2" {
+					t.Errorf("vehicle = %v, want registration A2", v)
+				}
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name: "get a missing vehicle",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{Id: 99})
+				return err
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "create a vehicle",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				v, err := c.CreateVehicle(ctx, &vehiclepb.CreateVehicleRequest{Vehicle: newVehicle(0, "B1")})
+				if err == nil && v.GetId() != 3 {
+					t.Errorf("ID = %d, want 3", v.GetId())
+				}
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name: "create a vehicle with a stored registration",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.CreateVehicle(ctx, &vehiclepb.CreateVehicleRequest{Vehicle: newVehicle(0, "A1")})
+				return err
+			},
+			code: codes.AlreadyExists,
+		},
+		{
+			name: "create a vehicle without its required fields",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.CreateVehicle(ctx, &vehiclepb.CreateVehicleRequest{Vehicle: &vehiclepb.Vehicle{Brand: "BMW"}})
+				return err
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "create a batch",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				res, err := c.CreateVehicles(ctx, &vehiclepb.CreateVehiclesRequest{Vehicles: []*vehiclepb.Vehicle{newVehicle(0, "B1"), newVehicle(0, "B2")}})
+				if err == nil && res.GetCreated() != 2 {
+					t.Errorf("created = %d, want 2", res.GetCreated())
+				}
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name: "create a batch with a stored vehicle creates none",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.CreateVehicles(ctx, &vehiclepb.CreateVehiclesRequest{Vehicles: []*vehiclepb.Vehicle{newVehicle(0, "B1"), newVehicle(0, "A2")}})
+				if _, errGet := c.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{Id: 3}); status.Code(errGet) != codes.NotFound {
+					t.Errorf("vehicle 3 of the failed batch: code = %v, want %v", status.Code(errGet), codes.NotFound)
+				}
+				return err
+			},
+			code: codes.AlreadyExists,
+		},
+		{
+			name: "delete a vehicle",
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.DeleteVehicle(ctx, &vehiclepb.DeleteVehicleRequest{Id: 1})
+				return err
+			},
+			code: codes.OK,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := newFixture(t, rpc.ConfigServer{})
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if code := status.Code(c.call(ctx, f.client)); code != c.code {
+				t.Errorf("code = %v, want %v", code, c.code)
+			}
+		})
+	}
+}
+
+func TestVehicleServer_ListVehicles(t *testing.T) {
+	f := newFixture(t, rpc.ConfigServer{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := f.client.ListVehicles(ctx, &vehiclepb.ListVehiclesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int64
+	for {
+		v, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, v.GetId())
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("IDs = %v, want [1 2]", ids)
+	}
+}
+
+func TestNewServer_Governance(t *testing.T) {
+	st, err := auth.NewAPIKeyFile(filepath.Join(t.TempDir(), "keys.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, reader, err := st.Issue("reader", "", []string{internal.ScopeVehiclesRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	au := auth.NewAuthenticator(auth.ConfigAuthenticator{APIKeys: st})
+	loaded := &reloaderStub{status: internal.ReloadStatus{LastSuccess: time.Now()}}
+
+	cases := []struct {
+		name string
+		cfg  rpc.ConfigServer
+		md   metadata.MD
+		call func(ctx context.Context, c vehiclepb.VehicleServiceClient) error
+		code codes.Code
+	}{
+		{
+			name: "dataset not loaded",
+			cfg:  rpc.ConfigServer{Reloader: &reloaderStub{}},
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{Id: 1})
+				return err
+			},
+			code: codes.Unavailable,
+		},
+		{
+			name: "no credential",
+			cfg:  rpc.ConfigServer{Reloader: loaded, Authenticator: au},
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{Id: 1})
+				return err
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "read with a read key",
+			cfg:  rpc.ConfigServer{Reloader: loaded, Authenticator: au},
+			md:   metadata.Pairs(rpc.MetadataAPIKey, reader),
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{Id: 1})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name: "write with a read key",
+			cfg:  rpc.ConfigServer{Reloader: loaded, Authenticator: au},
+			md:   metadata.Pairs(rpc.MetadataAPIKey, reader),
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.DeleteVehicle(ctx, &vehiclepb.DeleteVehicleRequest{Id: 1})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "unknown tenant",
+			md:   metadata.Pairs(rpc.MetadataTenant, "unknown"),
+			call: func(ctx context.Context, c vehiclepb.VehicleServiceClient) error {
+				_, err := c.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{Id: 1})
+				return err
+			},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			f := newFixture(t, c.cfg)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if c.md != nil {
+				ctx = metadata.NewOutgoingContext(ctx, c.md)
+			}
+
+			if code := status.Code(c.call(ctx, f.client)); code != c.code {
+				t.Errorf("code = %v, want %v", code, c.code)
+			}
+		})
+	}
+}
+
+func TestVehicleServer_WatchChanges(t *testing.T) {
+	f := newFixture(t, rpc.ConfigServer{})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := f.client.WatchChanges(ctx, &vehiclepb.WatchChangesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := make(chan *vehiclepb.VehicleChange)
+	go func() {
+		defer close(changes)
+		for {
+			change, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			changes <- change
+		}
+	}()
+
+	// - the stream subscribes once the call reaches the server, reloads are published until one is received
+	reloaded := internal.WithTenant(context.Background(), internal.DefaultTenant)
+	var change *vehiclepb.VehicleChange
+	for change == nil {
+		f.feed.PublishReload(reloaded)
+		select {
+		case change = <-changes:
+		case <-time.After(10 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("no change received")
+		}
+	}
+	if change.GetType() != vehiclepb.VehicleChange_TYPE_RELOADED || change.GetId() != 0 || change.GetVehicle() != nil || change.GetTenant() != internal.DefaultTenant {
+		t.Errorf("change = %v, want a reload of the default tenant without vehicle", change)
+	}
+
+	// - a failed batch publishes nothing, the next change is the vehicle created afterwards
+	_, err = f.client.CreateVehicles(ctx, &vehiclepb.CreateVehiclesRequest{Vehicles: []*vehiclepb.Vehicle{newVehicle(0, "B1"), newVehicle(0, "A1")}})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("batch: code = %v, want %v", status.Code(err), codes.AlreadyExists)
+	}
+	if _, err = f.client.CreateVehicle(ctx, &vehiclepb.CreateVehicleRequest{Vehicle: newVehicle(0, "C1")}); err != nil {
+		t.Fatal(err)
+	}
+	for change = range changes {
+		if change.GetType() != vehiclepb.VehicleChange_TYPE_RELOADED {
+			break
+		}
+	}
+	if change.GetType() != vehiclepb.VehicleChange_TYPE_CREATED || change.GetVehicle().GetRegistration() != "C1" {
+		t.Errorf("change = %v, want the creation of C1", change)
+	}
+}
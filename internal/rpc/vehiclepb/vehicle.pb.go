@@ -0,0 +1,1770 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v4.25.3
+// source: vehiclepb/vehicle.proto
+
+package vehiclepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VehicleChange_Type int32
+
+const (
+	VehicleChange_TYPE_UNSPECIFIED VehicleChange_Type = 0
+	VehicleChange_TYPE_CREATED     VehicleChange_Type = 1
+	VehicleChange_TYPE_UPDATED     VehicleChange_Type = 2
+	VehicleChange_TYPE_DELETED     VehicleChange_Type = 3
+	VehicleChange_TYPE_RELOADED    VehicleChange_Type = 4
+)
+
+// Enum value maps for VehicleChange_Type.
+var (
+	VehicleChange_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CREATED",
+		2: "TYPE_UPDATED",
+		3: "TYPE_DELETED",
+		4: "TYPE_RELOADED",
+	}
+	VehicleChange_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CREATED":     1,
+		"TYPE_UPDATED":     2,
+		"TYPE_DELETED":     3,
+		"TYPE_RELOADED":    4,
+	}
+)
+
+func (x VehicleChange_Type) Enum() *VehicleChange_Type {
+	p := new(VehicleChange_Type)
+	*p = x
+	return p
+}
+
+func (x VehicleChange_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VehicleChange_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_vehiclepb_vehicle_proto_enumTypes[0].Descriptor()
+}
+
+func (VehicleChange_Type) Type() protoreflect.EnumType {
+	return &file_vehiclepb_vehicle_proto_enumTypes[0]
+}
+
+func (x VehicleChange_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VehicleChange_Type.Descriptor instead.
+func (VehicleChange_Type) EnumDescriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{19, 0}
+}
+
+type Vehicle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Brand        string  `protobuf:"bytes,2,opt,name=brand,proto3" json:"brand,omitempty"`
+	Model        string  `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Registration string  `protobuf:"bytes,4,opt,name=registration,proto3" json:"registration,omitempty"`
+	Color        string  `protobuf:"bytes,5,opt,name=color,proto3" json:"color,omitempty"`
+	Year         int32   `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
+	Passengers   int32   `protobuf:"varint,7,opt,name=passengers,proto3" json:"passengers,omitempty"`
+	MaxSpeed     float64 `protobuf:"fixed64,8,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
+	FuelType     string  `protobuf:"bytes,9,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+	Transmission string  `protobuf:"bytes,10,opt,name=transmission,proto3" json:"transmission,omitempty"`
+	Weight       float64 `protobuf:"fixed64,11,opt,name=weight,proto3" json:"weight,omitempty"`
+	Height       float64 `protobuf:"fixed64,12,opt,name=height,proto3" json:"height,omitempty"`
+	Length       float64 `protobuf:"fixed64,13,opt,name=length,proto3" json:"length,omitempty"`
+	Width        float64 `protobuf:"fixed64,14,opt,name=width,proto3" json:"width,omitempty"`
+	Vin          string  `protobuf:"bytes,15,opt,name=vin,proto3" json:"vin,omitempty"`
+}
+
+func (x *Vehicle) Reset() {
+	*x = Vehicle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vehicle) ProtoMessage() {}
+
+func (x *Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
+func (*Vehicle) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vehicle) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Vehicle) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Vehicle) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Vehicle) GetRegistration() string {
+	if x != nil {
+		return x.Registration
+	}
+	return ""
+}
+
+func (x *Vehicle) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Vehicle) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Vehicle) GetPassengers() int32 {
+	if x != nil {
+		return x.Passengers
+	}
+	return 0
+}
+
+func (x *Vehicle) GetMaxSpeed() float64 {
+	if x != nil {
+		return x.MaxSpeed
+	}
+	return 0
+}
+
+func (x *Vehicle) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *Vehicle) GetTransmission() string {
+	if x != nil {
+		return x.Transmission
+	}
+	return ""
+}
+
+func (x *Vehicle) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Vehicle) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Vehicle) GetLength() float64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *Vehicle) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Vehicle) GetVin() string {
+	if x != nil {
+		return x.Vin
+	}
+	return ""
+}
+
+type ListVehiclesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListVehiclesRequest) Reset() {
+	*x = ListVehiclesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehiclesRequest) ProtoMessage() {}
+
+func (x *ListVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*ListVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{1}
+}
+
+type GetVehicleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetVehicleRequest) Reset() {
+	*x = GetVehicleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleRequest) ProtoMessage() {}
+
+func (x *GetVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehicleRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetVehicleRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateVehicleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vehicle *Vehicle `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+}
+
+func (x *CreateVehicleRequest) Reset() {
+	*x = CreateVehicleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehicleRequest) ProtoMessage() {}
+
+func (x *CreateVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehicleRequest.ProtoReflect.Descriptor instead.
+func (*CreateVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateVehicleRequest) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+type CreateVehiclesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vehicles []*Vehicle `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+}
+
+func (x *CreateVehiclesRequest) Reset() {
+	*x = CreateVehiclesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehiclesRequest) ProtoMessage() {}
+
+func (x *CreateVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*CreateVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateVehiclesRequest) GetVehicles() []*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+type CreateVehiclesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Created int32 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+}
+
+func (x *CreateVehiclesResponse) Reset() {
+	*x = CreateVehiclesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateVehiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehiclesResponse) ProtoMessage() {}
+
+func (x *CreateVehiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehiclesResponse.ProtoReflect.Descriptor instead.
+func (*CreateVehiclesResponse) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateVehiclesResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+type FindByColorAndYearRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Color string `protobuf:"bytes,1,opt,name=color,proto3" json:"color,omitempty"`
+	Year  int32  `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+}
+
+func (x *FindByColorAndYearRequest) Reset() {
+	*x = FindByColorAndYearRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByColorAndYearRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByColorAndYearRequest) ProtoMessage() {}
+
+func (x *FindByColorAndYearRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByColorAndYearRequest.ProtoReflect.Descriptor instead.
+func (*FindByColorAndYearRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FindByColorAndYearRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *FindByColorAndYearRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+type FindByBrandAndRangeYearRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Brand     string `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	StartYear int32  `protobuf:"varint,2,opt,name=start_year,json=startYear,proto3" json:"start_year,omitempty"`
+	EndYear   int32  `protobuf:"varint,3,opt,name=end_year,json=endYear,proto3" json:"end_year,omitempty"`
+}
+
+func (x *FindByBrandAndRangeYearRequest) Reset() {
+	*x = FindByBrandAndRangeYearRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByBrandAndRangeYearRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByBrandAndRangeYearRequest) ProtoMessage() {}
+
+func (x *FindByBrandAndRangeYearRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByBrandAndRangeYearRequest.ProtoReflect.Descriptor instead.
+func (*FindByBrandAndRangeYearRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FindByBrandAndRangeYearRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *FindByBrandAndRangeYearRequest) GetStartYear() int32 {
+	if x != nil {
+		return x.StartYear
+	}
+	return 0
+}
+
+func (x *FindByBrandAndRangeYearRequest) GetEndYear() int32 {
+	if x != nil {
+		return x.EndYear
+	}
+	return 0
+}
+
+type FindByFuelTypeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FuelType string `protobuf:"bytes,1,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+}
+
+func (x *FindByFuelTypeRequest) Reset() {
+	*x = FindByFuelTypeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByFuelTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByFuelTypeRequest) ProtoMessage() {}
+
+func (x *FindByFuelTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByFuelTypeRequest.ProtoReflect.Descriptor instead.
+func (*FindByFuelTypeRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FindByFuelTypeRequest) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+type FindByTransmissionTypeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transmission string `protobuf:"bytes,1,opt,name=transmission,proto3" json:"transmission,omitempty"`
+}
+
+func (x *FindByTransmissionTypeRequest) Reset() {
+	*x = FindByTransmissionTypeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByTransmissionTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByTransmissionTypeRequest) ProtoMessage() {}
+
+func (x *FindByTransmissionTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByTransmissionTypeRequest.ProtoReflect.Descriptor instead.
+func (*FindByTransmissionTypeRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FindByTransmissionTypeRequest) GetTransmission() string {
+	if x != nil {
+		return x.Transmission
+	}
+	return ""
+}
+
+type Range struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min float64 `protobuf:"fixed64,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max float64 `protobuf:"fixed64,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Range.ProtoReflect.Descriptor instead.
+func (*Range) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Range) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *Range) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type FindByDimensionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height *Range `protobuf:"bytes,1,opt,name=height,proto3" json:"height,omitempty"`
+	Width  *Range `protobuf:"bytes,2,opt,name=width,proto3" json:"width,omitempty"`
+}
+
+func (x *FindByDimensionsRequest) Reset() {
+	*x = FindByDimensionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByDimensionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByDimensionsRequest) ProtoMessage() {}
+
+func (x *FindByDimensionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByDimensionsRequest.ProtoReflect.Descriptor instead.
+func (*FindByDimensionsRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FindByDimensionsRequest) GetHeight() *Range {
+	if x != nil {
+		return x.Height
+	}
+	return nil
+}
+
+func (x *FindByDimensionsRequest) GetWidth() *Range {
+	if x != nil {
+		return x.Width
+	}
+	return nil
+}
+
+type FindByWeightRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Weight *Range `protobuf:"bytes,1,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *FindByWeightRangeRequest) Reset() {
+	*x = FindByWeightRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindByWeightRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindByWeightRangeRequest) ProtoMessage() {}
+
+func (x *FindByWeightRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindByWeightRangeRequest.ProtoReflect.Descriptor instead.
+func (*FindByWeightRangeRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FindByWeightRangeRequest) GetWeight() *Range {
+	if x != nil {
+		return x.Weight
+	}
+	return nil
+}
+
+type BrandRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Brand string `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+}
+
+func (x *BrandRequest) Reset() {
+	*x = BrandRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BrandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrandRequest) ProtoMessage() {}
+
+func (x *BrandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrandRequest.ProtoReflect.Descriptor instead.
+func (*BrandRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BrandRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+type AverageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Brand   string  `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	Average float64 `protobuf:"fixed64,2,opt,name=average,proto3" json:"average,omitempty"`
+}
+
+func (x *AverageResponse) Reset() {
+	*x = AverageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AverageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AverageResponse) ProtoMessage() {}
+
+func (x *AverageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AverageResponse.ProtoReflect.Descriptor instead.
+func (*AverageResponse) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AverageResponse) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *AverageResponse) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+type UpdateMaxSpeedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MaxSpeed float64 `protobuf:"fixed64,2,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
+}
+
+func (x *UpdateMaxSpeedRequest) Reset() {
+	*x = UpdateMaxSpeedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateMaxSpeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMaxSpeedRequest) ProtoMessage() {}
+
+func (x *UpdateMaxSpeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMaxSpeedRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMaxSpeedRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateMaxSpeedRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMaxSpeedRequest) GetMaxSpeed() float64 {
+	if x != nil {
+		return x.MaxSpeed
+	}
+	return 0
+}
+
+type UpdateFuelTypeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FuelType string `protobuf:"bytes,2,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"`
+}
+
+func (x *UpdateFuelTypeRequest) Reset() {
+	*x = UpdateFuelTypeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateFuelTypeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFuelTypeRequest) ProtoMessage() {}
+
+func (x *UpdateFuelTypeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFuelTypeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFuelTypeRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateFuelTypeRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateFuelTypeRequest) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+type DeleteVehicleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteVehicleRequest) Reset() {
+	*x = DeleteVehicleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVehicleRequest) ProtoMessage() {}
+
+func (x *DeleteVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVehicleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteVehicleRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchChangesRequest) Reset() {
+	*x = WatchChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchChangesRequest) ProtoMessage() {}
+
+func (x *WatchChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchChangesRequest.ProtoReflect.Descriptor instead.
+func (*WatchChangesRequest) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{18}
+}
+
+type VehicleChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    VehicleChange_Type     `protobuf:"varint,1,opt,name=type,proto3,enum=vehicle.v1.VehicleChange_Type" json:"type,omitempty"`
+	Tenant  string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Id      int64                  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	Vehicle *Vehicle               `protobuf:"bytes,4,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *VehicleChange) Reset() {
+	*x = VehicleChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_vehiclepb_vehicle_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VehicleChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleChange) ProtoMessage() {}
+
+func (x *VehicleChange) ProtoReflect() protoreflect.Message {
+	mi := &file_vehiclepb_vehicle_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleChange.ProtoReflect.Descriptor instead.
+func (*VehicleChange) Descriptor() ([]byte, []int) {
+	return file_vehiclepb_vehicle_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *VehicleChange) GetType() VehicleChange_Type {
+	if x != nil {
+		return x.Type
+	}
+	return VehicleChange_TYPE_UNSPECIFIED
+}
+
+func (x *VehicleChange) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *VehicleChange) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VehicleChange) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+func (x *VehicleChange) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+var File_vehiclepb_vehicle_proto protoreflect.FileDescriptor
+
+var file_vehiclepb_vehicle_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x81, 0x03, 0x0a, 0x07, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x62, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x0a, 0x0c, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x73,
+	0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70,
+	0x61, 0x73, 0x73, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75, 0x65, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12,
+	0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x76, 0x69, 0x6e, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x76, 0x69, 0x6e, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x23,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x45, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x07, 0x76,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x52, 0x07, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x22, 0x48, 0x0a, 0x15, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x08, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x19, 0x46, 0x69, 0x6e, 0x64,
+	0x42, 0x79, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x41, 0x6e, 0x64, 0x59, 0x65, 0x61, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x79,
+	0x65, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x22,
+	0x70, 0x0a, 0x1e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x41, 0x6e,
+	0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x59, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x79, 0x65, 0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x59, 0x65, 0x61, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x79, 0x65,
+	0x61, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x59, 0x65, 0x61,
+	0x72, 0x22, 0x34, 0x0a, 0x15, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x46, 0x75, 0x65, 0x6c, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75,
+	0x65, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
+	0x75, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x22, 0x43, 0x0a, 0x1d, 0x46, 0x69, 0x6e, 0x64, 0x42,
+	0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x2b, 0x0a, 0x05,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x6d, 0x0a, 0x17, 0x46, 0x69, 0x6e,
+	0x64, 0x42, 0x79, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x27, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x22, 0x45, 0x0a, 0x18, 0x46, 0x69, 0x6e, 0x64,
+	0x42, 0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0x24, 0x0a, 0x0c, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x62, 0x72, 0x61, 0x6e, 0x64, 0x22, 0x41, 0x0a, 0x0f, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x72, 0x61, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4d, 0x61, 0x78, 0x53, 0x70, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x70, 0x65, 0x65, 0x64, 0x22, 0x44,
+	0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75, 0x65, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x22, 0x26, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x15, 0x0a, 0x13,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0xb1, 0x02, 0x0a, 0x0d, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x2d, 0x0a, 0x07, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x07, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x22, 0x65, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10,
+	0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01,
+	0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54,
+	0x45, 0x44, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x4c,
+	0x4f, 0x41, 0x44, 0x45, 0x44, 0x10, 0x04, 0x32, 0x89, 0x0a, 0x0a, 0x0e, 0x56, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x0c, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x76, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x30, 0x01, 0x12, 0x40, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x12, 0x1d, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x12, 0x46, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x20, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x57, 0x0a, 0x0e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x21,
+	0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x12, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x41, 0x6e, 0x64, 0x59, 0x65, 0x61, 0x72, 0x12, 0x25, 0x2e, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x41, 0x6e, 0x64, 0x59, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x17, 0x46, 0x69, 0x6e,
+	0x64, 0x42, 0x79, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x41, 0x6e, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x59, 0x65, 0x61, 0x72, 0x12, 0x2a, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x41, 0x6e, 0x64,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x59, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0e, 0x46, 0x69, 0x6e, 0x64, 0x42,
+	0x79, 0x46, 0x75, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x2e, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x46, 0x75, 0x65,
+	0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x30, 0x01, 0x12, 0x5a, 0x0a, 0x16, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x29, 0x2e,
+	0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42,
+	0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x30, 0x01, 0x12,
+	0x4e, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x23, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x30, 0x01, 0x12,
+	0x50, 0x0a, 0x11, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x24, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x42, 0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x76, 0x65, 0x68,
+	0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x30,
+	0x01, 0x12, 0x4c, 0x0a, 0x13, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x65,
+	0x64, 0x42, 0x79, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x18, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4f, 0x0a, 0x16, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69,
+	0x74, 0x79, 0x42, 0x79, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x12, 0x18, 0x2e, 0x76, 0x65, 0x68, 0x69,
+	0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4b, 0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x78, 0x53, 0x70, 0x65,
+	0x65, 0x64, 0x12, 0x21, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x78, 0x53, 0x70, 0x65, 0x65, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4b, 0x0a,
+	0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x21, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x46, 0x75, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x49, 0x0a, 0x0d, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x12, 0x20, 0x2e, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x56,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4c, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x30, 0x01, 0x42, 0x1c, 0x5a, 0x1a, 0x61, 0x70, 0x70, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_vehiclepb_vehicle_proto_rawDescOnce sync.Once
+	file_vehiclepb_vehicle_proto_rawDescData = file_vehiclepb_vehicle_proto_rawDesc
+)
+
+func file_vehiclepb_vehicle_proto_rawDescGZIP() []byte {
+	file_vehiclepb_vehicle_proto_rawDescOnce.Do(func() {
+		file_vehiclepb_vehicle_proto_rawDescData = protoimpl.X.CompressGZIP(file_vehiclepb_vehicle_proto_rawDescData)
+	})
+	return file_vehiclepb_vehicle_proto_rawDescData
+}
+
+var file_vehiclepb_vehicle_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_vehiclepb_vehicle_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_vehiclepb_vehicle_proto_goTypes = []any{
+	(VehicleChange_Type)(0),                // 0: vehicle.v1.VehicleChange.Type
+	(*Vehicle)(nil),                        // 1: vehicle.v1.Vehicle
+	(*ListVehiclesRequest)(nil),            // 2: vehicle.v1.ListVehiclesRequest
+	(*GetVehicleRequest)(nil),              // 3: vehicle.v1.GetVehicleRequest
+	(*CreateVehicleRequest)(nil),           // 4: vehicle.v1.CreateVehicleRequest
+	(*CreateVehiclesRequest)(nil),          // 5: vehicle.v1.CreateVehiclesRequest
+	(*CreateVehiclesResponse)(nil),         // 6: vehicle.v1.CreateVehiclesResponse
+	(*FindByColorAndYearRequest)(nil),      // 7: vehicle.v1.FindByColorAndYearRequest
+	(*FindByBrandAndRangeYearRequest)(nil), // 8: vehicle.v1.FindByBrandAndRangeYearRequest
+	(*FindByFuelTypeRequest)(nil),          // 9: vehicle.v1.FindByFuelTypeRequest
+	(*FindByTransmissionTypeRequest)(nil),  // 10: vehicle.v1.FindByTransmissionTypeRequest
+	(*Range)(nil),                          // 11: vehicle.v1.Range
+	(*FindByDimensionsRequest)(nil),        // 12: vehicle.v1.FindByDimensionsRequest
+	(*FindByWeightRangeRequest)(nil),       // 13: vehicle.v1.FindByWeightRangeRequest
+	(*BrandRequest)(nil),                   // 14: vehicle.v1.BrandRequest
+	(*AverageResponse)(nil),                // 15: vehicle.v1.AverageResponse
+	(*UpdateMaxSpeedRequest)(nil),          // 16: vehicle.v1.UpdateMaxSpeedRequest
+	(*UpdateFuelTypeRequest)(nil),          // 17: vehicle.v1.UpdateFuelTypeRequest
+	(*DeleteVehicleRequest)(nil),           // 18: vehicle.v1.DeleteVehicleRequest
+	(*WatchChangesRequest)(nil),            // 19: vehicle.v1.WatchChangesRequest
+	(*VehicleChange)(nil),                  // 20: vehicle.v1.VehicleChange
+	(*timestamppb.Timestamp)(nil),          // 21: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                  // 22: google.protobuf.Empty
+}
+var file_vehiclepb_vehicle_proto_depIdxs = []int32{
+	1,  // 0: vehicle.v1.CreateVehicleRequest.vehicle:type_name -> vehicle.v1.Vehicle
+	1,  // 1: vehicle.v1.CreateVehiclesRequest.vehicles:type_name -> vehicle.v1.Vehicle
+	11, // 2: vehicle.v1.FindByDimensionsRequest.height:type_name -> vehicle.v1.Range
+	11, // 3: vehicle.v1.FindByDimensionsRequest.width:type_name -> vehicle.v1.Range
+	11, // 4: vehicle.v1.FindByWeightRangeRequest.weight:type_name -> vehicle.v1.Range
+	0,  // 5: vehicle.v1.VehicleChange.type:type_name -> vehicle.v1.VehicleChange.Type
+	1,  // 6: vehicle.v1.VehicleChange.vehicle:type_name -> vehicle.v1.Vehicle
+	21, // 7: vehicle.v1.VehicleChange.time:type_name -> google.protobuf.Timestamp
+	2,  // 8: vehicle.v1.VehicleService.ListVehicles:input_type -> vehicle.v1.ListVehiclesRequest
+	3,  // 9: vehicle.v1.VehicleService.GetVehicle:input_type -> vehicle.v1.GetVehicleRequest
+	4,  // 10: vehicle.v1.VehicleService.CreateVehicle:input_type -> vehicle.v1.CreateVehicleRequest
+	5,  // 11: vehicle.v1.VehicleService.CreateVehicles:input_type -> vehicle.v1.CreateVehiclesRequest
+	7,  // 12: vehicle.v1.VehicleService.FindByColorAndYear:input_type -> vehicle.v1.FindByColorAndYearRequest
+	8,  // 13: vehicle.v1.VehicleService.FindByBrandAndRangeYear:input_type -> vehicle.v1.FindByBrandAndRangeYearRequest
+	9,  // 14: vehicle.v1.VehicleService.FindByFuelType:input_type -> vehicle.v1.FindByFuelTypeRequest
+	10, // 15: vehicle.v1.VehicleService.FindByTransmissionType:input_type -> vehicle.v1.FindByTransmissionTypeRequest
+	12, // 16: vehicle.v1.VehicleService.FindByDimensions:input_type -> vehicle.v1.FindByDimensionsRequest
+	13, // 17: vehicle.v1.VehicleService.FindByWeightRange:input_type -> vehicle.v1.FindByWeightRangeRequest
+	14, // 18: vehicle.v1.VehicleService.AverageSpeedByBrand:input_type -> vehicle.v1.BrandRequest
+	14, // 19: vehicle.v1.VehicleService.AverageCapacityByBrand:input_type -> vehicle.v1.BrandRequest
+	16, // 20: vehicle.v1.VehicleService.UpdateMaxSpeed:input_type -> vehicle.v1.UpdateMaxSpeedRequest
+	17, // 21: vehicle.v1.VehicleService.UpdateFuelType:input_type -> vehicle.v1.UpdateFuelTypeRequest
+	18, // 22: vehicle.v1.VehicleService.DeleteVehicle:input_type -> vehicle.v1.DeleteVehicleRequest
+	19, // 23: vehicle.v1.VehicleService.WatchChanges:input_type -> vehicle.v1.WatchChangesRequest
+	1,  // 24: vehicle.v1.VehicleService.ListVehicles:output_type -> vehicle.v1.Vehicle
+	1,  // 25: vehicle.v1.VehicleService.GetVehicle:output_type -> vehicle.v1.Vehicle
+	1,  // 26: vehicle.v1.VehicleService.CreateVehicle:output_type -> vehicle.v1.Vehicle
+	6,  // 27: vehicle.v1.VehicleService.CreateVehicles:output_type -> vehicle.v1.CreateVehiclesResponse
+	1,  // 28: vehicle.v1.VehicleService.FindByColorAndYear:output_type -> vehicle.v1.Vehicle
+	1,  // 29: vehicle.v1.VehicleService.FindByBrandAndRangeYear:output_type -> vehicle.v1.Vehicle
+	1,  // 30: vehicle.v1.VehicleService.FindByFuelType:output_type -> vehicle.v1.Vehicle
+	1,  // 31: vehicle.v1.VehicleService.FindByTransmissionType:output_type -> vehicle.v1.Vehicle
+	1,  // 32: vehicle.v1.VehicleService.FindByDimensions:output_type -> vehicle.v1.Vehicle
+	1,  // 33: vehicle.v1.VehicleService.FindByWeightRange:output_type -> vehicle.v1.Vehicle
+	15, // 34: vehicle.v1.VehicleService.AverageSpeedByBrand:output_type -> vehicle.v1.AverageResponse
+	15, // 35: vehicle.v1.VehicleService.AverageCapacityByBrand:output_type -> vehicle.v1.AverageResponse
+	22, // 36: vehicle.v1.VehicleService.UpdateMaxSpeed:output_type -> google.protobuf.Empty
+	22, // 37: vehicle.v1.VehicleService.UpdateFuelType:output_type -> google.protobuf.Empty
+	22, // 38: vehicle.v1.VehicleService.DeleteVehicle:output_type -> google.protobuf.Empty
+	20, // 39: vehicle.v1.VehicleService.WatchChanges:output_type -> vehicle.v1.VehicleChange
+	24, // [24:40] is the sub-list for method output_type
+	8,  // [8:24] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_vehiclepb_vehicle_proto_init() }
+func file_vehiclepb_vehicle_proto_init() {
+	if File_vehiclepb_vehicle_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_vehiclepb_vehicle_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Vehicle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListVehiclesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetVehicleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateVehicleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateVehiclesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateVehiclesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*FindByColorAndYearRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*FindByBrandAndRangeYearRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*FindByFuelTypeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*FindByTransmissionTypeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*FindByDimensionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*FindByWeightRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*BrandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*AverageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateMaxSpeedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateFuelTypeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteVehicleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_vehiclepb_vehicle_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*VehicleChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_vehiclepb_vehicle_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_vehiclepb_vehicle_proto_goTypes,
+		DependencyIndexes: file_vehiclepb_vehicle_proto_depIdxs,
+		EnumInfos:         file_vehiclepb_vehicle_proto_enumTypes,
+		MessageInfos:      file_vehiclepb_vehicle_proto_msgTypes,
+	}.Build()
+	File_vehiclepb_vehicle_proto = out.File
+	file_vehiclepb_vehicle_proto_rawDesc = nil
+	file_vehiclepb_vehicle_proto_goTypes = nil
+	file_vehiclepb_vehicle_proto_depIdxs = nil
+}
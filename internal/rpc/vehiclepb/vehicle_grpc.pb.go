@@ -0,0 +1,898 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v4.25.3
+// source: vehiclepb/vehicle.proto
+
+package vehiclepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	VehicleService_ListVehicles_FullMethodName            = "/vehicle.v1.VehicleService/ListVehicles"
+	VehicleService_GetVehicle_FullMethodName              = "/vehicle.v1.VehicleService/GetVehicle"
+	VehicleService_CreateVehicle_FullMethodName           = "/vehicle.v1.VehicleService/CreateVehicle"
+	VehicleService_CreateVehicles_FullMethodName          = "/vehicle.v1.VehicleService/CreateVehicles"
+	VehicleService_FindByColorAndYear_FullMethodName      = "/vehicle.v1.VehicleService/FindByColorAndYear"
+	VehicleService_FindByBrandAndRangeYear_FullMethodName = "/vehicle.v1.VehicleService/FindByBrandAndRangeYear"
+	VehicleService_FindByFuelType_FullMethodName          = "/vehicle.v1.VehicleService/FindByFuelType"
+	VehicleService_FindByTransmissionType_FullMethodName  = "/vehicle.v1.VehicleService/FindByTransmissionType"
+	VehicleService_FindByDimensions_FullMethodName        = "/vehicle.v1.VehicleService/FindByDimensions"
+	VehicleService_FindByWeightRange_FullMethodName       = "/vehicle.v1.VehicleService/FindByWeightRange"
+	VehicleService_AverageSpeedByBrand_FullMethodName     = "/vehicle.v1.VehicleService/AverageSpeedByBrand"
+	VehicleService_AverageCapacityByBrand_FullMethodName  = "/vehicle.v1.VehicleService/AverageCapacityByBrand"
+	VehicleService_UpdateMaxSpeed_FullMethodName          = "/vehicle.v1.VehicleService/UpdateMaxSpeed"
+	VehicleService_UpdateFuelType_FullMethodName          = "/vehicle.v1.VehicleService/UpdateFuelType"
+	VehicleService_DeleteVehicle_FullMethodName           = "/vehicle.v1.VehicleService/DeleteVehicle"
+	VehicleService_WatchChanges_FullMethodName            = "/vehicle.v1.VehicleService/WatchChanges"
+)
+
+// VehicleServiceClient is the client API for VehicleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VehicleServiceClient interface {
+	ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (VehicleService_ListVehiclesClient, error)
+	GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	CreateVehicle(ctx context.Context, in *CreateVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	CreateVehicles(ctx context.Context, in *CreateVehiclesRequest, opts ...grpc.CallOption) (*CreateVehiclesResponse, error)
+	FindByColorAndYear(ctx context.Context, in *FindByColorAndYearRequest, opts ...grpc.CallOption) (VehicleService_FindByColorAndYearClient, error)
+	FindByBrandAndRangeYear(ctx context.Context, in *FindByBrandAndRangeYearRequest, opts ...grpc.CallOption) (VehicleService_FindByBrandAndRangeYearClient, error)
+	FindByFuelType(ctx context.Context, in *FindByFuelTypeRequest, opts ...grpc.CallOption) (VehicleService_FindByFuelTypeClient, error)
+	FindByTransmissionType(ctx context.Context, in *FindByTransmissionTypeRequest, opts ...grpc.CallOption) (VehicleService_FindByTransmissionTypeClient, error)
+	FindByDimensions(ctx context.Context, in *FindByDimensionsRequest, opts ...grpc.CallOption) (VehicleService_FindByDimensionsClient, error)
+	FindByWeightRange(ctx context.Context, in *FindByWeightRangeRequest, opts ...grpc.CallOption) (VehicleService_FindByWeightRangeClient, error)
+	AverageSpeedByBrand(ctx context.Context, in *BrandRequest, opts ...grpc.CallOption) (*AverageResponse, error)
+	AverageCapacityByBrand(ctx context.Context, in *BrandRequest, opts ...grpc.CallOption) (*AverageResponse, error)
+	UpdateMaxSpeed(ctx context.Context, in *UpdateMaxSpeedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateFuelType(ctx context.Context, in *UpdateFuelTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (VehicleService_WatchChangesClient, error)
+}
+
+type vehicleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVehicleServiceClient(cc grpc.ClientConnInterface) VehicleServiceClient {
+	return &vehicleServiceClient{cc}
+}
+
+func (c *vehicleServiceClient) ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (VehicleService_ListVehiclesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[0], VehicleService_ListVehicles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceListVehiclesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_ListVehiclesClient interface {
+	Recv() (*Vehicle, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceListVehiclesClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceListVehiclesClient) Recv() (*Vehicle, error) {
+	m := new(Vehicle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vehicleServiceClient) GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) CreateVehicle(ctx context.Context, in *CreateVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, VehicleService_CreateVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) CreateVehicles(ctx context.Context, in *CreateVehiclesRequest, opts ...grpc.CallOption) (*CreateVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVehiclesResponse)
+	err := c.cc.Invoke(ctx, VehicleService_CreateVehicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) FindByColorAndYear(ctx context.Context, in *FindByColorAndYearRequest, opts ...grpc.CallOption) (VehicleService_FindByColorAndYearClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[1], VehicleService_FindByColorAndYear_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceFindByColorAndYearClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_FindByColorAndYearClient interface {
+	Recv() (*Vehicle, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceFindByColorAndYearClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceFindByColorAndYearClient) Recv() (*Vehicle, error) {
+	m := new(Vehicle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vehicleServiceClient) FindByBrandAndRangeYear(ctx context.Context, in *FindByBrandAndRangeYearRequest, opts ...grpc.CallOption) (VehicleService_FindByBrandAndRangeYearClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[2], VehicleService_FindByBrandAndRangeYear_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceFindByBrandAndRangeYearClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_FindByBrandAndRangeYearClient interface {
+	Recv() (*Vehicle, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceFindByBrandAndRangeYearClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceFindByBrandAndRangeYearClient) Recv() (*Vehicle, error) {
+	m := new(Vehicle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vehicleServiceClient) FindByFuelType(ctx context.Context, in *FindByFuelTypeRequest, opts ...grpc.CallOption) (VehicleService_FindByFuelTypeClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[3], VehicleService_FindByFuelType_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceFindByFuelTypeClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_FindByFuelTypeClient interface {
+	Recv() (*Vehicle, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceFindByFuelTypeClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceFindByFuelTypeClient) Recv() (*Vehicle, error) {
+	m := new(Vehicle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vehicleServiceClient) FindByTransmissionType(ctx context.Context, in *FindByTransmissionTypeRequest, opts ...grpc.CallOption) (VehicleService_FindByTransmissionTypeClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[4], VehicleService_FindByTransmissionType_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceFindByTransmissionTypeClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_FindByTransmissionTypeClient interface {
+	Recv() (*Vehicle, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceFindByTransmissionTypeClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceFindByTransmissionTypeClient) Recv() (*Vehicle, error) {
+	m := new(Vehicle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vehicleServiceClient) FindByDimensions(ctx context.Context, in *FindByDimensionsRequest, opts ...grpc.CallOption) (VehicleService_FindByDimensionsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[5], VehicleService_FindByDimensions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceFindByDimensionsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_FindByDimensionsClient interface {
+	Recv() (*Vehicle, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceFindByDimensionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceFindByDimensionsClient) Recv() (*Vehicle, error) {
+	m := new(Vehicle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vehicleServiceClient) FindByWeightRange(ctx context.Context, in *FindByWeightRangeRequest, opts ...grpc.CallOption) (VehicleService_FindByWeightRangeClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[6], VehicleService_FindByWeightRange_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceFindByWeightRangeClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_FindByWeightRangeClient interface {
+	Recv() (*Vehicle, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceFindByWeightRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceFindByWeightRangeClient) Recv() (*Vehicle, error) {
+	m := new(Vehicle)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vehicleServiceClient) AverageSpeedByBrand(ctx context.Context, in *BrandRequest, opts ...grpc.CallOption) (*AverageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AverageResponse)
+	err := c.cc.Invoke(ctx, VehicleService_AverageSpeedByBrand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) AverageCapacityByBrand(ctx context.Context, in *BrandRequest, opts ...grpc.CallOption) (*AverageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AverageResponse)
+	err := c.cc.Invoke(ctx, VehicleService_AverageCapacityByBrand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) UpdateMaxSpeed(ctx context.Context, in *UpdateMaxSpeedRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, VehicleService_UpdateMaxSpeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) UpdateFuelType(ctx context.Context, in *UpdateFuelTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, VehicleService_UpdateFuelType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, VehicleService_DeleteVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (VehicleService_WatchChangesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &VehicleService_ServiceDesc.Streams[7], VehicleService_WatchChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleServiceWatchChangesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleService_WatchChangesClient interface {
+	Recv() (*VehicleChange, error)
+	grpc.ClientStream
+}
+
+type vehicleServiceWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleServiceWatchChangesClient) Recv() (*VehicleChange, error) {
+	m := new(VehicleChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// VehicleServiceServer is the server API for VehicleService service.
+// All implementations must embed UnimplementedVehicleServiceServer
+// for forward compatibility
+type VehicleServiceServer interface {
+	ListVehicles(*ListVehiclesRequest, VehicleService_ListVehiclesServer) error
+	GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error)
+	CreateVehicle(context.Context, *CreateVehicleRequest) (*Vehicle, error)
+	CreateVehicles(context.Context, *CreateVehiclesRequest) (*CreateVehiclesResponse, error)
+	FindByColorAndYear(*FindByColorAndYearRequest, VehicleService_FindByColorAndYearServer) error
+	FindByBrandAndRangeYear(*FindByBrandAndRangeYearRequest, VehicleService_FindByBrandAndRangeYearServer) error
+	FindByFuelType(*FindByFuelTypeRequest, VehicleService_FindByFuelTypeServer) error
+	FindByTransmissionType(*FindByTransmissionTypeRequest, VehicleService_FindByTransmissionTypeServer) error
+	FindByDimensions(*FindByDimensionsRequest, VehicleService_FindByDimensionsServer) error
+	FindByWeightRange(*FindByWeightRangeRequest, VehicleService_FindByWeightRangeServer) error
+	AverageSpeedByBrand(context.Context, *BrandRequest) (*AverageResponse, error)
+	AverageCapacityByBrand(context.Context, *BrandRequest) (*AverageResponse, error)
+	UpdateMaxSpeed(context.Context, *UpdateMaxSpeedRequest) (*emptypb.Empty, error)
+	UpdateFuelType(context.Context, *UpdateFuelTypeRequest) (*emptypb.Empty, error)
+	DeleteVehicle(context.Context, *DeleteVehicleRequest) (*emptypb.Empty, error)
+	WatchChanges(*WatchChangesRequest, VehicleService_WatchChangesServer) error
+	mustEmbedUnimplementedVehicleServiceServer()
+}
+
+// UnimplementedVehicleServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedVehicleServiceServer struct {
+}
+
+func (UnimplementedVehicleServiceServer) ListVehicles(*ListVehiclesRequest, VehicleService_ListVehiclesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListVehicles not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) CreateVehicle(context.Context, *CreateVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) CreateVehicles(context.Context, *CreateVehiclesRequest) (*CreateVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVehicles not implemented")
+}
+func (UnimplementedVehicleServiceServer) FindByColorAndYear(*FindByColorAndYearRequest, VehicleService_FindByColorAndYearServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindByColorAndYear not implemented")
+}
+func (UnimplementedVehicleServiceServer) FindByBrandAndRangeYear(*FindByBrandAndRangeYearRequest, VehicleService_FindByBrandAndRangeYearServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindByBrandAndRangeYear not implemented")
+}
+func (UnimplementedVehicleServiceServer) FindByFuelType(*FindByFuelTypeRequest, VehicleService_FindByFuelTypeServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindByFuelType not implemented")
+}
+func (UnimplementedVehicleServiceServer) FindByTransmissionType(*FindByTransmissionTypeRequest, VehicleService_FindByTransmissionTypeServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindByTransmissionType not implemented")
+}
+func (UnimplementedVehicleServiceServer) FindByDimensions(*FindByDimensionsRequest, VehicleService_FindByDimensionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindByDimensions not implemented")
+}
+func (UnimplementedVehicleServiceServer) FindByWeightRange(*FindByWeightRangeRequest, VehicleService_FindByWeightRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindByWeightRange not implemented")
+}
+func (UnimplementedVehicleServiceServer) AverageSpeedByBrand(context.Context, *BrandRequest) (*AverageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AverageSpeedByBrand not implemented")
+}
+func (UnimplementedVehicleServiceServer) AverageCapacityByBrand(context.Context, *BrandRequest) (*AverageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AverageCapacityByBrand not implemented")
+}
+func (UnimplementedVehicleServiceServer) UpdateMaxSpeed(context.Context, *UpdateMaxSpeedRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMaxSpeed not implemented")
+}
+func (UnimplementedVehicleServiceServer) UpdateFuelType(context.Context, *UpdateFuelTypeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFuelType not implemented")
+}
+func (UnimplementedVehicleServiceServer) DeleteVehicle(context.Context, *DeleteVehicleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) WatchChanges(*WatchChangesRequest, VehicleService_WatchChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedVehicleServiceServer) mustEmbedUnimplementedVehicleServiceServer() {}
+
+// UnsafeVehicleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VehicleServiceServer will
+// result in compilation errors.
+type UnsafeVehicleServiceServer interface {
+	mustEmbedUnimplementedVehicleServiceServer()
+}
+
+func RegisterVehicleServiceServer(s grpc.ServiceRegistrar, srv VehicleServiceServer) {
+	s.RegisterService(&VehicleService_ServiceDesc, srv)
+}
+
+func _VehicleService_ListVehicles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVehiclesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).ListVehicles(m, &vehicleServiceListVehiclesServer{ServerStream: stream})
+}
+
+type VehicleService_ListVehiclesServer interface {
+	Send(*Vehicle) error
+	grpc.ServerStream
+}
+
+type vehicleServiceListVehiclesServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceListVehiclesServer) Send(m *Vehicle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VehicleService_GetVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehicle(ctx, req.(*GetVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_CreateVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).CreateVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_CreateVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).CreateVehicle(ctx, req.(*CreateVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_CreateVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).CreateVehicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_CreateVehicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).CreateVehicles(ctx, req.(*CreateVehiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_FindByColorAndYear_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByColorAndYearRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).FindByColorAndYear(m, &vehicleServiceFindByColorAndYearServer{ServerStream: stream})
+}
+
+type VehicleService_FindByColorAndYearServer interface {
+	Send(*Vehicle) error
+	grpc.ServerStream
+}
+
+type vehicleServiceFindByColorAndYearServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceFindByColorAndYearServer) Send(m *Vehicle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VehicleService_FindByBrandAndRangeYear_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByBrandAndRangeYearRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).FindByBrandAndRangeYear(m, &vehicleServiceFindByBrandAndRangeYearServer{ServerStream: stream})
+}
+
+type VehicleService_FindByBrandAndRangeYearServer interface {
+	Send(*Vehicle) error
+	grpc.ServerStream
+}
+
+type vehicleServiceFindByBrandAndRangeYearServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceFindByBrandAndRangeYearServer) Send(m *Vehicle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VehicleService_FindByFuelType_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByFuelTypeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).FindByFuelType(m, &vehicleServiceFindByFuelTypeServer{ServerStream: stream})
+}
+
+type VehicleService_FindByFuelTypeServer interface {
+	Send(*Vehicle) error
+	grpc.ServerStream
+}
+
+type vehicleServiceFindByFuelTypeServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceFindByFuelTypeServer) Send(m *Vehicle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VehicleService_FindByTransmissionType_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByTransmissionTypeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).FindByTransmissionType(m, &vehicleServiceFindByTransmissionTypeServer{ServerStream: stream})
+}
+
+type VehicleService_FindByTransmissionTypeServer interface {
+	Send(*Vehicle) error
+	grpc.ServerStream
+}
+
+type vehicleServiceFindByTransmissionTypeServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceFindByTransmissionTypeServer) Send(m *Vehicle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VehicleService_FindByDimensions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByDimensionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).FindByDimensions(m, &vehicleServiceFindByDimensionsServer{ServerStream: stream})
+}
+
+type VehicleService_FindByDimensionsServer interface {
+	Send(*Vehicle) error
+	grpc.ServerStream
+}
+
+type vehicleServiceFindByDimensionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceFindByDimensionsServer) Send(m *Vehicle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VehicleService_FindByWeightRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByWeightRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).FindByWeightRange(m, &vehicleServiceFindByWeightRangeServer{ServerStream: stream})
+}
+
+type VehicleService_FindByWeightRangeServer interface {
+	Send(*Vehicle) error
+	grpc.ServerStream
+}
+
+type vehicleServiceFindByWeightRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceFindByWeightRangeServer) Send(m *Vehicle) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VehicleService_AverageSpeedByBrand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).AverageSpeedByBrand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_AverageSpeedByBrand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).AverageSpeedByBrand(ctx, req.(*BrandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_AverageCapacityByBrand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BrandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).AverageCapacityByBrand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_AverageCapacityByBrand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).AverageCapacityByBrand(ctx, req.(*BrandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_UpdateMaxSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMaxSpeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).UpdateMaxSpeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_UpdateMaxSpeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).UpdateMaxSpeed(ctx, req.(*UpdateMaxSpeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_UpdateFuelType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFuelTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).UpdateFuelType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_UpdateFuelType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).UpdateFuelType(ctx, req.(*UpdateFuelTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_DeleteVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).DeleteVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_DeleteVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).DeleteVehicle(ctx, req.(*DeleteVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleServiceServer).WatchChanges(m, &vehicleServiceWatchChangesServer{ServerStream: stream})
+}
+
+type VehicleService_WatchChangesServer interface {
+	Send(*VehicleChange) error
+	grpc.ServerStream
+}
+
+type vehicleServiceWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleServiceWatchChangesServer) Send(m *VehicleChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// VehicleService_ServiceDesc is the grpc.ServiceDesc for VehicleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VehicleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vehicle.v1.VehicleService",
+	HandlerType: (*VehicleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVehicle",
+			Handler:    _VehicleService_GetVehicle_Handler,
+		},
+		{
+			MethodName: "CreateVehicle",
+			Handler:    _VehicleService_CreateVehicle_Handler,
+		},
+		{
+			MethodName: "CreateVehicles",
+			Handler:    _VehicleService_CreateVehicles_Handler,
+		},
+		{
+			MethodName: "AverageSpeedByBrand",
+			Handler:    _VehicleService_AverageSpeedByBrand_Handler,
+		},
+		{
+			MethodName: "AverageCapacityByBrand",
+			Handler:    _VehicleService_AverageCapacityByBrand_Handler,
+		},
+		{
+			MethodName: "UpdateMaxSpeed",
+			Handler:    _VehicleService_UpdateMaxSpeed_Handler,
+		},
+		{
+			MethodName: "UpdateFuelType",
+			Handler:    _VehicleService_UpdateFuelType_Handler,
+		},
+		{
+			MethodName: "DeleteVehicle",
+			Handler:    _VehicleService_DeleteVehicle_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListVehicles",
+			Handler:       _VehicleService_ListVehicles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByColorAndYear",
+			Handler:       _VehicleService_FindByColorAndYear_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByBrandAndRangeYear",
+			Handler:       _VehicleService_FindByBrandAndRangeYear_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByFuelType",
+			Handler:       _VehicleService_FindByFuelType_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByTransmissionType",
+			Handler:       _VehicleService_FindByTransmissionType_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByDimensions",
+			Handler:       _VehicleService_FindByDimensions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByWeightRange",
+			Handler:       _VehicleService_FindByWeightRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _VehicleService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "vehiclepb/vehicle.proto",
+}
@@ -0,0 +1,214 @@
+package search
+
+import (
+	"app/internal/normalize"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scores of a term of the index matching a term of a query, multiplied by the weight of its field
+const (
+	// ScoreExact is the score of a term equal to the query term
+	ScoreExact = 1.0
+	// ScorePrefix is the score of a term starting with the query term
+	ScorePrefix = 0.7
+	// ScoreTypo is the score of a term within the allowed edit distance of the query term, divided by the distance
+	ScoreTypo = 0.5
+)
+
+// MinPrefixLength is the length a query term needs to match the terms it is a prefix of
+const MinPrefixLength = 2
+
+// Field is a struct that represents a text indexed for a document and how much its matches weigh
+type Field struct {
+	// Text is the text, split in terms by Tokenize
+	Text string
+	// Weight multiplies the score of the matches of its terms
+	Weight float64
+}
+
+// Match is a struct that represents a document matching a query
+type Match struct {
+	// ID is the ID of the document
+	ID int
+	// Score is the relevance of the document, higher is better
+	Score float64
+}
+
+// NewIndex is a function that returns a new instance of Index, empty
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[int]float64),
+		docs:     make(map[int][]string),
+	}
+}
+
+// Index is a struct that represents an inverted index of documents by ID: each term points to the documents
+// containing it with the weight of its heaviest field. It is not safe for concurrent use, its owner guards it
+type Index struct {
+	// postings are the weights of the documents by ID, by term
+	postings map[string]map[int]float64
+	// docs are the terms of each document by ID, to remove it
+	docs map[int][]string
+}
+
+// Add is a method that indexes the fields of a document, replacing it if it was indexed
+func (x *Index) Add(id int, fields ...Field) {
+	x.Remove(id)
+
+	weights := make(map[string]float64)
+	for _, f := range fields {
+		for _, term := range Tokenize(f.Text) {
+			weights[term] = max(weights[term], f.Weight)
+		}
+	}
+	terms := make([]string, 0, len(weights))
+	for term, weight := range weights {
+		if x.postings[term] == nil {
+			x.postings[term] = make(map[int]float64)
+		}
+		x.postings[term][id] = weight
+		terms = append(terms, term)
+	}
+	x.docs[id] = terms
+}
+
+// Remove is a method that removes a document from the index
+func (x *Index) Remove(id int) {
+	for _, term := range x.docs[id] {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	delete(x.docs, id)
+}
+
+// Len is a method that returns the number of documents indexed
+func (x *Index) Len() int {
+	return len(x.docs)
+}
+
+// Search is a method that returns up to limit documents matching any of the query terms, the most relevant first
+// and by ID on a tie. Each query term adds the best score of the index terms it matches exactly, as a prefix or
+// within an edit distance of its length (MaxEdits), and the total is scaled by the share of query terms matched
+func (x *Index) Search(query []string, limit int) (matches []Match) {
+	scores := make(map[int]float64)
+	matched := make(map[int]int)
+	for _, q := range query {
+		best := make(map[int]float64)
+		edits := MaxEdits(q)
+		for term, docs := range x.postings {
+			score := 0.0
+			switch {
+			case term == q:
+				score = ScoreExact
+			case len(q) >= MinPrefixLength && strings.HasPrefix(term, q):
+				score = ScorePrefix
+			case edits > 0:
+				if d := distance(q, term, edits); d <= edits {
+					score = ScoreTypo / float64(d)
+				}
+			}
+			if score == 0 {
+				continue
+			}
+			for id, weight := range docs {
+				best[id] = max(best[id], score*weight)
+			}
+		}
+		for id, score := range best {
+			scores[id] += score
+			matched[id]++
+		}
+	}
+
+	matches = make([]Match, 0, len(scores))
+	for id, score := range scores {
+		matches = append(matches, Match{ID: id, Score: score * float64(matched[id]) / float64(len(query))})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return
+}
+
+// Tokenize is a function that splits a text in terms: folded as normalize.Fold and split on anything
+// but letters and digits, each term once
+func Tokenize(text string) (terms []string) {
+	seen := make(map[string]bool)
+	for _, term := range strings.FieldsFunc(normalize.Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return
+}
+
+// MaxEdits is a function that returns the edit distance tolerated for a query term: none for short terms,
+// which would match too much, one from 4 letters and two from 8
+func MaxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// distance is a function that returns the edit distance between two terms counting insertions, deletions,
+// substitutions and transpositions of adjacent letters, any value over bound once it is exceeded
+func distance(a, b string, bound int) int {
+	ra, rb := []rune(a), []rune(b)
+	if abs(len(ra)-len(rb)) > bound {
+		return bound + 1
+	}
+
+	// - three rows of the dynamic programming table: the previous two and the current one
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > bound {
+			return bound + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+// abs is a function that returns the absolute value of an integer
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
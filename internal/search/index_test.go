@@ -0,0 +1,156 @@
+package search_test
+
+import (
+	"app/internal/search"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		name  string
+		text  string
+		terms []string
+	}{
+		{name: "folded and split on separators", text: "Land-Rover  Defender_110", terms: []string{"land", "rover", "defender", "110"}},
+		{name: "split on punctuation", text: "ABC.123/x", terms: []string{"abc", "123", "x"}},
+		{name: "accents stripped", text: "Citroën Méhari", terms: []string{"citroen", "mehari"}},
+		{name: "each term once", text: "Red red RED", terms: []string{"red"}},
+		{name: "empty", text: " - ", terms: nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if terms := search.Tokenize(c.text); !slices.Equal(terms, c.terms) {
+				t.Errorf("Tokenize(%q) = %q, want %q", c.text, terms, c.terms)
+			}
+		})
+	}
+}
+
+func TestMaxEdits(t *testing.T) {
+	cases := []struct {
+		term  string
+		edits int
+	}{
+		{term: "bmw", edits: 0},
+		{term: "ford", edits: 1},
+		{term: "corolla", edits: 1},
+		{term: "ñandúes", edits: 1},
+		{term: "defender", edits: 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.term, func(t *testing.T) {
+			if edits := search.MaxEdits(c.term); edits != c.edits {
+				t.Errorf("MaxEdits(%q) = %d, want %d", c.term, edits, c.edits)
+			}
+		})
+	}
+}
+
+func TestIndex_Search(t *testing.T) {
+	x := search.NewIndex()
+	x.Add(1, search.Field{Text: "Toyota Corolla", Weight: 1})
+	x.Add(2, search.Field{Text: "Chevrolet Silverado", Weight: 1})
+	x.Add(3, search.Field{Text: "Ford Focus", Weight: 1}, search.Field{Text: "Toyota", Weight: 0.5})
+
+	cases := []struct {
+		name    string
+		query   string
+		limit   int
+		matches []search.Match
+	}{
+		{
+			name:    "exact, weighted by the heaviest field",
+			query:   "toyota",
+			matches: []search.Match{{ID: 1, Score: search.ScoreExact}, {ID: 3, Score: search.ScoreExact * 0.5}},
+		},
+		{
+			name:    "prefix",
+			query:   "coro",
+			matches: []search.Match{{ID: 1, Score: search.ScorePrefix}},
+		},
+		{
+			name:  "prefix shorter than the minimum",
+			query: "c",
+		},
+		{
+			name:    "typo: deletion",
+			query:   "corola",
+			matches: []search.Match{{ID: 1, Score: search.ScoreTypo}},
+		},
+		{
+			name:    "typo: transposition of adjacent letters",
+			query:   "croolla",
+			matches: []search.Match{{ID: 1, Score: search.ScoreTypo}},
+		},
+		{
+			name:    "typo: two substitutions of a long term",
+			query:   "shevrolat",
+			matches: []search.Match{{ID: 2, Score: search.ScoreTypo / 2}},
+		},
+		{
+			name:  "typo: two edits of a term allowed one",
+			query: "carola",
+		},
+		{
+			name:  "typo: short term",
+			query: "frd",
+		},
+		{
+			name:    "scaled by the share of query terms matched, ties by ID",
+			query:   "ford corolla",
+			matches: []search.Match{{ID: 1, Score: search.ScoreExact / 2}, {ID: 3, Score: search.ScoreExact / 2}},
+		},
+		{
+			name:    "every query term matched first",
+			query:   "ford toyota",
+			matches: []search.Match{{ID: 3, Score: search.ScoreExact + search.ScoreExact*0.5}, {ID: 1, Score: search.ScoreExact / 2}},
+		},
+		{
+			name:    "limit",
+			query:   "toyota",
+			limit:   1,
+			matches: []search.Match{{ID: 1, Score: search.ScoreExact}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			matches := x.Search(search.Tokenize(c.query), c.limit)
+			if len(matches) != len(c.matches) {
+				t.Fatalf("matches = %+v, want %+v", matches, c.matches)
+			}
+			for i, m := range matches {
+				if m.ID != c.matches[i].ID || math.Abs(m.Score-c.matches[i].Score) > 1e-9 {
+					t.Errorf("match %d = %+v, want %+v", i, m, c.matches[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIndex_AddRemove(t *testing.T) {
+	x := search.NewIndex()
+	x.Add(1, search.Field{Text: "Toyota", Weight: 1})
+	x.Add(2, search.Field{Text: "Ford", Weight: 1})
+
+	// - adding a document again replaces its terms
+	x.Add(1, search.Field{Text: "Honda", Weight: 1})
+	if matches := x.Search([]string{"toyota"}, 0); len(matches) != 0 {
+		t.Errorf("matches of the replaced terms = %+v, want none", matches)
+	}
+	if matches := x.Search([]string{"honda"}, 0); len(matches) != 1 || matches[0].ID != 1 {
+		t.Errorf("matches of the new terms = %+v, want document 1", matches)
+	}
+
+	x.Remove(1)
+	if x.Len() != 1 {
+		t.Errorf("len = %d, want 1", x.Len())
+	}
+	if matches := x.Search([]string{"honda"}, 0); len(matches) != 0 {
+		t.Errorf("matches of a removed document = %+v, want none", matches)
+	}
+}
@@ -1,6 +1,10 @@
 package service
 
-import "app/internal"
+import (
+	"app/internal"
+	"context"
+	"log/slog"
+)
 
 // NewVehicleDefault is a function that returns a new instance of VehicleDefault
 func NewVehicleDefault(rp internal.VehicleRepository) *VehicleDefault {
@@ -15,90 +19,249 @@ type VehicleDefault struct {
 
 // FindAll is a method that returns a map of all vehicles
 func (s *VehicleDefault) FindAll() (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindAll()
+	v, err = s.FindAllContext(context.Background())
+	return
+}
+
+// FindAllContext is a method that returns a map of all vehicles, honoring the cancellation of ctx
+func (s *VehicleDefault) FindAllContext(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindAllContext(ctx)
 	return
 }
 
 // FindByID is a method that returns a vehicle by ID
 func (s *VehicleDefault) FindByID(id int) (v internal.Vehicle, err error) {
-	v, err = s.rp.FindByID(id)
+	v, err = s.FindByIDContext(context.Background(), id)
+	return
+}
+
+// FindByIDContext is a method that returns a vehicle by ID, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByIDContext(ctx context.Context, id int) (v internal.Vehicle, err error) {
+	v, err = s.rp.FindByIDContext(ctx, id)
 	return
 }
 
 // Create is a method that creates a new vehicle
 func (s *VehicleDefault) Create(v *internal.Vehicle) (err error) {
-	err = s.rp.Create(v)
+	err = s.CreateContext(context.Background(), v)
+	return
+}
+
+// CreateContext is a method that creates a new vehicle, honoring the cancellation of ctx
+func (s *VehicleDefault) CreateContext(ctx context.Context, v *internal.Vehicle) (err error) {
+	err = s.rp.CreateContext(ctx, v)
+	if err == nil {
+		slog.InfoContext(ctx, "vehicle created", slog.Int("id", v.Id), caller(ctx))
+	}
 	return
 }
 
 // FindByColorAndYear is a method that returns a map of vehicles that match the color and year
 func (s *VehicleDefault) FindByColorAndYear(color string, year int) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByColorAndYear(color, year)
+	v, err = s.FindByColorAndYearContext(context.Background(), color, year)
+	return
+}
+
+// FindByColorAndYearContext is a method that returns a map of vehicles that match the color and year, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByColorAndYearContext(ctx context.Context, color string, year int) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByColorAndYearContext(ctx, color, year)
 	return
 }
 
 // FindByBrandAndRangeYear is a method that returns a map of vehicles that match the brand and range year
 func (s *VehicleDefault) FindByBrandAndRangeYear(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByBrandAndRangeYear(brand, startYear, endYear)
+	v, err = s.FindByBrandAndRangeYearContext(context.Background(), brand, startYear, endYear)
+	return
+}
+
+// FindByBrandAndRangeYearContext is a method that returns a map of vehicles that match the brand and range year, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByBrandAndRangeYearContext(ctx context.Context, brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByBrandAndRangeYearContext(ctx, brand, startYear, endYear)
 	return
 }
 
 // FindAverageSpeedByBrand is a method that returns a value of average speed by brand
 func (s *VehicleDefault) FindAverageSpeedByBrand(brand string) (avgSpeed float64, err error) {
-	avgSpeed, err = s.rp.FindAverageSpeedByBrand(brand)
+	avgSpeed, err = s.FindAverageSpeedByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageSpeedByBrandContext is a method that returns a value of average speed by brand, honoring the cancellation of ctx
+func (s *VehicleDefault) FindAverageSpeedByBrandContext(ctx context.Context, brand string) (avgSpeed float64, err error) {
+	avgSpeed, err = s.rp.FindAverageSpeedByBrandContext(ctx, brand)
 	return
 }
 
 // CreateBatch is a method that creates a batch of vehicles
 func (s *VehicleDefault) CreateBatch(v []internal.Vehicle) (err error) {
-	err = s.rp.CreateBatch(v)
+	err = s.CreateBatchContext(context.Background(), v)
+	return
+}
+
+// CreateBatchContext is a method that creates a batch of vehicles, honoring the cancellation of ctx
+func (s *VehicleDefault) CreateBatchContext(ctx context.Context, v []internal.Vehicle) (err error) {
+	err = s.rp.CreateBatchContext(ctx, v)
+	if err == nil {
+		slog.InfoContext(ctx, "vehicles created", slog.Int("count", len(v)), caller(ctx))
+	}
 	return
 }
 
 // UpdateMaxSpeed is a method that updates the max speed of a vehicle
 func (s *VehicleDefault) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
-	err = s.rp.UpdateMaxSpeed(id, maxSpeed)
+	err = s.UpdateMaxSpeedContext(context.Background(), id, maxSpeed)
+	return
+}
+
+// UpdateMaxSpeedContext is a method that updates the max speed of a vehicle, honoring the cancellation of ctx
+func (s *VehicleDefault) UpdateMaxSpeedContext(ctx context.Context, id int, maxSpeed float64) (err error) {
+	err = s.rp.UpdateMaxSpeedContext(ctx, id, maxSpeed)
+	if err == nil {
+		slog.InfoContext(ctx, "vehicle max speed updated", slog.Int("id", id), slog.Float64("max_speed", maxSpeed), caller(ctx))
+	}
 	return
 }
 
 // FindByFuelType is a method that returns a map of vehicles that match the fuel type
 func (s *VehicleDefault) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByFuelType(fuelType)
+	v, err = s.FindByFuelTypeContext(context.Background(), fuelType)
+	return
+}
+
+// FindByFuelTypeContext is a method that returns a map of vehicles that match the fuel type, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByFuelTypeContext(ctx context.Context, fuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByFuelTypeContext(ctx, fuelType)
 	return
 }
 
 // Delete is a method that deletes a vehicle
 func (s *VehicleDefault) Delete(id int) (err error) {
-	err = s.rp.Delete(id)
+	err = s.DeleteContext(context.Background(), id)
+	return
+}
+
+// DeleteContext is a method that deletes a vehicle, honoring the cancellation of ctx
+func (s *VehicleDefault) DeleteContext(ctx context.Context, id int) (err error) {
+	err = s.rp.DeleteContext(ctx, id)
+	if err == nil {
+		slog.InfoContext(ctx, "vehicle deleted", slog.Int("id", id), caller(ctx))
+	}
 	return
 }
 
 // FindByTransmissionType is a method that returns a map of vehicles that match the transmission type
 func (s *VehicleDefault) FindByTransmissionType(transmissionType string) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByTransmissionType(transmissionType)
+	v, err = s.FindByTransmissionTypeContext(context.Background(), transmissionType)
+	return
+}
+
+// FindByTransmissionTypeContext is a method that returns a map of vehicles that match the transmission type, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByTransmissionTypeContext(ctx context.Context, transmissionType string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByTransmissionTypeContext(ctx, transmissionType)
 	return
 }
 
 // FindAverageCapacityByBrand is a method that returns a value of average person capacity by brand
 func (s *VehicleDefault) FindAverageCapacityByBrand(brand string) (avgCapacity float64, err error) {
-	avgCapacity, err = s.rp.FindAverageCapacityByBrand(brand)
+	avgCapacity, err = s.FindAverageCapacityByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageCapacityByBrandContext is a method that returns a value of average person capacity by brand, honoring the cancellation of ctx
+func (s *VehicleDefault) FindAverageCapacityByBrandContext(ctx context.Context, brand string) (avgCapacity float64, err error) {
+	avgCapacity, err = s.rp.FindAverageCapacityByBrandContext(ctx, brand)
 	return
 }
 
 // UpdateFuelType is a method that updates the fuel type of a vehicle
 func (s *VehicleDefault) UpdateFuelType(id int, fuelType string) (err error) {
-	err = s.rp.UpdateFuelType(id, fuelType)
+	err = s.UpdateFuelTypeContext(context.Background(), id, fuelType)
+	return
+}
+
+// UpdateFuelTypeContext is a method that updates the fuel type of a vehicle, honoring the cancellation of ctx
+func (s *VehicleDefault) UpdateFuelTypeContext(ctx context.Context, id int, fuelType string) (err error) {
+	err = s.rp.UpdateFuelTypeContext(ctx, id, fuelType)
+	if err == nil {
+		slog.InfoContext(ctx, "vehicle fuel type updated", slog.Int("id", id), slog.String("fuel_type", fuelType), caller(ctx))
+	}
 	return
 }
 
 // FindByDimensions is a method that returns a map of vehicles that match the dimensions
 func (s *VehicleDefault) FindByDimensions(minHeight float64, maxHeight float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByDimensions(minHeight, maxHeight, minWidth, maxWidth)
+	v, err = s.FindByDimensionsContext(context.Background(), minHeight, maxHeight, minWidth, maxWidth)
+	return
+}
+
+// FindByDimensionsContext is a method that returns a map of vehicles that match the dimensions, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByDimensionsContext(ctx context.Context, minHeight float64, maxHeight float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByDimensionsContext(ctx, minHeight, maxHeight, minWidth, maxWidth)
 	return
 }
 
 // FindByWeightRange is a method that returns a map of vehicles that match the weight range
 func (s *VehicleDefault) FindByWeightRange(minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByWeightRange(minWeight, maxWeight)
+	v, err = s.FindByWeightRangeContext(context.Background(), minWeight, maxWeight)
+	return
+}
+
+// FindByWeightRangeContext is a method that returns a map of vehicles that match the weight range, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByWeightRangeContext(ctx context.Context, minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByWeightRangeContext(ctx, minWeight, maxWeight)
 	return
 }
+
+// SearchContext is a method that returns up to limit vehicles matching the words of a query, the most relevant first,
+// honoring the cancellation of ctx. The limit defaults to SearchDefaultLimit and is capped at SearchMaxLimit
+func (s *VehicleDefault) SearchContext(ctx context.Context, query string, limit int) (hits []internal.VehicleHit, err error) {
+	if limit <= 0 {
+		limit = internal.SearchDefaultLimit
+	}
+	limit = min(limit, internal.SearchMaxLimit)
+
+	hits, err = s.rp.SearchContext(ctx, query, limit)
+	return
+}
+
+// FindByCatalogContext is a method that returns a map of vehicles linked by their brand and model to catalog
+// entries that match a filter, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByCatalogContext(ctx context.Context, filter internal.CatalogFilter) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByCatalogContext(ctx, filter)
+	return
+}
+
+// FindByRegistrationContext is a method that returns a map of vehicles whose registration has the normalized
+// form of a registration, honoring the cancellation of ctx
+func (s *VehicleDefault) FindByRegistrationContext(ctx context.Context, registration string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByRegistrationContext(ctx, registration)
+	return
+}
+
+// FindRegistrationDuplicatesContext is a method that returns the registrations shared by several vehicles,
+// sorted, honoring the cancellation of ctx
+func (s *VehicleDefault) FindRegistrationDuplicatesContext(ctx context.Context) (d []internal.RegistrationDuplicate, err error) {
+	d, err = s.rp.FindRegistrationDuplicatesContext(ctx)
+	return
+}
+
+// FindByVINContext is a method that returns the vehicle whose VIN has the normalized form of a VIN,
+// honoring the cancellation of ctx
+func (s *VehicleDefault) FindByVINContext(ctx context.Context, vin string) (v internal.Vehicle, err error) {
+	v, err = s.rp.FindByVINContext(ctx, vin)
+	return
+}
+
+// caller is a function that returns the log attribute identifying the authenticated caller of ctx and its tenant
+func caller(ctx context.Context) slog.Attr {
+	p, ok := internal.PrincipalFromContext(ctx)
+	if !ok {
+		p.Subject = "anonymous"
+	}
+	return slog.Group("caller",
+		slog.String("subject", p.Subject),
+		slog.String("name", p.Name),
+		slog.String("method", p.Method),
+		slog.String("tenant", internal.TenantFromContext(ctx)),
+	)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"app/internal"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// feedBuffer is the number of changes a subscriber may fall behind before it misses changes
+const feedBuffer = 64
+
+// NewVehicleFeed is a function that returns a new instance of VehicleFeed publishing the changes made through sv
+func NewVehicleFeed(sv internal.VehicleService) *VehicleFeed {
+	return &VehicleFeed{
+		VehicleService: sv,
+		subscribers:    make(map[chan internal.VehicleChange]struct{}),
+		now:            time.Now,
+	}
+}
+
+// VehicleFeed is a struct that decorates a service publishing each successful create, update and delete
+// to its subscribers, and the reloads told by PublishReload. The queries are served by the decorated service as they are
+type VehicleFeed struct {
+	internal.VehicleService
+	// mu guards subscribers
+	mu sync.Mutex
+	// subscribers are the channels of the subscribers
+	subscribers map[chan internal.VehicleChange]struct{}
+	// now returns the current time
+	now func() time.Time
+}
+
+// Subscribe is a method that returns a channel receiving the changes made from now on, closed once ctx is done
+func (s *VehicleFeed) Subscribe(ctx context.Context) (ch <-chan internal.VehicleChange) {
+	c := make(chan internal.VehicleChange, feedBuffer)
+	s.mu.Lock()
+	s.subscribers[c] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		delete(s.subscribers, c)
+		close(c)
+		s.mu.Unlock()
+	}()
+	return c
+}
+
+// Create is a method that creates a new vehicle and publishes it
+func (s *VehicleFeed) Create(v *internal.Vehicle) (err error) {
+	err = s.CreateContext(context.Background(), v)
+	return
+}
+
+// CreateContext is a method that creates a new vehicle and publishes it, honoring the cancellation of ctx
+func (s *VehicleFeed) CreateContext(ctx context.Context, v *internal.Vehicle) (err error) {
+	err = s.VehicleService.CreateContext(ctx, v)
+	if err == nil {
+		s.publish(ctx, internal.VehicleChangeCreated, v.Id, *v)
+	}
+	return
+}
+
+// CreateBatch is a method that creates a batch of vehicles and publishes each of them
+func (s *VehicleFeed) CreateBatch(v []internal.Vehicle) (err error) {
+	err = s.CreateBatchContext(context.Background(), v)
+	return
+}
+
+// CreateBatchContext is a method that creates a batch of vehicles and publishes each of them, honoring the cancellation of ctx.
+// A batch is created as a whole or not at all, a failed one has no vehicle to publish
+func (s *VehicleFeed) CreateBatchContext(ctx context.Context, v []internal.Vehicle) (err error) {
+	err = s.VehicleService.CreateBatchContext(ctx, v)
+	if err == nil {
+		for _, vehicle := range v {
+			s.publish(ctx, internal.VehicleChangeCreated, vehicle.Id, vehicle)
+		}
+	}
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the max speed of a vehicle and publishes it
+func (s *VehicleFeed) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	err = s.UpdateMaxSpeedContext(context.Background(), id, maxSpeed)
+	return
+}
+
+// UpdateMaxSpeedContext is a method that updates the max speed of a vehicle and publishes it, honoring the cancellation of ctx
+func (s *VehicleFeed) UpdateMaxSpeedContext(ctx context.Context, id int, maxSpeed float64) (err error) {
+	err = s.VehicleService.UpdateMaxSpeedContext(ctx, id, maxSpeed)
+	if err == nil {
+		s.publishUpdate(ctx, id)
+	}
+	return
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle and publishes it
+func (s *VehicleFeed) UpdateFuelType(id int, fuelType string) (err error) {
+	err = s.UpdateFuelTypeContext(context.Background(), id, fuelType)
+	return
+}
+
+// UpdateFuelTypeContext is a method that updates the fuel type of a vehicle and publishes it, honoring the cancellation of ctx
+func (s *VehicleFeed) UpdateFuelTypeContext(ctx context.Context, id int, fuelType string) (err error) {
+	err = s.VehicleService.UpdateFuelTypeContext(ctx, id, fuelType)
+	if err == nil {
+		s.publishUpdate(ctx, id)
+	}
+	return
+}
+
+// Delete is a method that deletes a vehicle and publishes it
+func (s *VehicleFeed) Delete(id int) (err error) {
+	err = s.DeleteContext(context.Background(), id)
+	return
+}
+
+// DeleteContext is a method that deletes a vehicle and publishes it, honoring the cancellation of ctx
+func (s *VehicleFeed) DeleteContext(ctx context.Context, id int) (err error) {
+	err = s.VehicleService.DeleteContext(ctx, id)
+	if err == nil {
+		s.publish(ctx, internal.VehicleChangeDeleted, id, internal.Vehicle{})
+	}
+	return
+}
+
+// PublishReload is a method that publishes the reload of the vehicles of the tenant of ctx,
+// swapped by the reloader without going through the service
+func (s *VehicleFeed) PublishReload(ctx context.Context) {
+	s.publish(ctx, internal.VehicleChangeReloaded, 0, internal.Vehicle{})
+}
+
+// publishUpdate is a method that publishes the vehicle as it is after an update
+func (s *VehicleFeed) publishUpdate(ctx context.Context, id int) {
+	// - the change is made, a canceled request must not hide it from the subscribers
+	v, err := s.VehicleService.FindByIDContext(context.WithoutCancel(ctx), id)
+	if err != nil {
+		slog.WarnContext(ctx, "updated vehicle could not be read for the change feed", slog.Int("id", id), slog.String("error", err.Error()))
+	}
+	s.publish(ctx, internal.VehicleChangeUpdated, id, v)
+}
+
+// publish is a method that sends a change to every subscriber, skipping those whose buffer is full
+func (s *VehicleFeed) publish(ctx context.Context, typ string, id int, v internal.Vehicle) {
+	change := internal.VehicleChange{
+		Type:    typ,
+		Tenant:  internal.TenantFromContext(ctx),
+		ID:      id,
+		Vehicle: v,
+		Time:    s.now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.subscribers {
+		select {
+		case c <- change:
+		default:
+			slog.WarnContext(ctx, "change feed subscriber is behind, change dropped", slog.String("type", typ), slog.Int("id", id))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// DefaultTenant is the tenant of the requests that do not name one
+const DefaultTenant = "default"
+
+// TenantPattern is the format of a tenant name
+const TenantPattern = `^[a-z0-9][a-z0-9_-]{0,62}$`
+
+// tenantPattern is the compiled TenantPattern
+var tenantPattern = regexp.MustCompile(TenantPattern)
+
+// ValidTenant is a function that reports whether a tenant name is well formed
+func ValidTenant(tenant string) bool {
+	return tenantPattern.MatchString(tenant)
+}
+
+// tenantKey is the key of the tenant in a context
+type tenantKey struct{}
+
+// WithTenant is a function that returns a copy of ctx scoped to a tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext is a function that returns the tenant ctx is scoped to, DefaultTenant if there is none
+func TenantFromContext(ctx context.Context) (tenant string) {
+	tenant, _ = ctx.Value(tenantKey{}).(string)
+	if tenant == "" {
+		tenant = DefaultTenant
+	}
+	return
+}
+
+// VehicleTenantRepository is an interface that represents a repository with a namespace of vehicles per tenant,
+// the operations of VehicleRepository apply to the tenant of their context (DefaultTenant without one)
+type VehicleTenantRepository interface {
+	VehicleRepository
+
+	// HasTenant is a method that reports whether a tenant has a namespace
+	HasTenant(tenant string) (ok bool)
+
+	// Tenants is a method that returns the number of vehicles of each tenant
+	Tenants() (t map[string]int)
+
+	// FindAllTenants is a method that returns the vehicles of every tenant, honoring the cancellation of ctx
+	FindAllTenants(ctx context.Context) (v map[string]map[int]Vehicle, err error)
+}
+
+// Errors in tenants
+var (
+	ErrorTenantInvalid   = errors.New("Invalid tenant")
+	ErrorTenantNotFound  = errors.New("Tenant not found")
+	ErrorTenantForbidden = errors.New("Credentials are bound to another tenant")
+)
@@ -0,0 +1,27 @@
+package internal
+
+import "errors"
+
+// Text fields of the vehicles compared by the finders and the catalog
+const (
+	TextFieldBrand        = "brand"
+	TextFieldModel        = "model"
+	TextFieldColor        = "color"
+	TextFieldFuelType     = "fuel_type"
+	TextFieldTransmission = "transmission"
+)
+
+// TextFields are all the text fields compared by the finders and the catalog
+var TextFields = []string{TextFieldBrand, TextFieldModel, TextFieldColor, TextFieldFuelType, TextFieldTransmission}
+
+// TextNormalizer is an interface that represents how the values of the text fields are compared:
+// two values are the same when their keys are equal, the values themselves are kept for display
+type TextNormalizer interface {
+	// Normalize is a method that returns the key of a value of a text field
+	Normalize(field string, value string) (key string)
+}
+
+// Errors in text normalizers
+var (
+	ErrorInvalidSynonym = errors.New("Invalid synonym")
+)
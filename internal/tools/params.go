@@ -0,0 +1,63 @@
+package tools
+
+import "context"
+
+// Range is a struct that represents a closed range of numbers, written {min}-{max} in a query
+type Range struct {
+	Min float64
+	Max float64
+}
+
+// Params is a map of the path and query parameters of a request by name, already converted
+// to the type of their schema: int, float64, bool, string, Range or a slice of them ([]string for strings)
+type Params map[string]any
+
+// Has is a method that reports whether a parameter was given
+func (p Params) Has(name string) (ok bool) {
+	_, ok = p[name]
+	return
+}
+
+// Int is a method that returns an integer parameter, zero if it is missing
+func (p Params) Int(name string) (v int) {
+	v, _ = p[name].(int)
+	return
+}
+
+// Float is a method that returns a number parameter, zero if it is missing
+func (p Params) Float(name string) (v float64) {
+	v, _ = p[name].(float64)
+	return
+}
+
+// String is a method that returns a string parameter, empty if it is missing
+func (p Params) String(name string) (v string) {
+	v, _ = p[name].(string)
+	return
+}
+
+// Strings is a method that returns a parameter holding a list of strings, nil if it is missing
+func (p Params) Strings(name string) (v []string) {
+	v, _ = p[name].([]string)
+	return
+}
+
+// Range is a method that returns a range parameter, the zero range if it is missing
+func (p Params) Range(name string) (v Range) {
+	v, _ = p[name].(Range)
+	return
+}
+
+// paramsKey is the key of the parameters in a context
+type paramsKey struct{}
+
+// WithParams is a function that returns a copy of ctx carrying the validated parameters of a request
+func WithParams(ctx context.Context, p Params) context.Context {
+	return context.WithValue(ctx, paramsKey{}, p)
+}
+
+// ParamsFromContext is a function that returns the validated parameters carried by ctx, empty if there are none
+func ParamsFromContext(ctx context.Context) (p Params) {
+	p, _ = ctx.Value(paramsKey{}).(Params)
+	return
+}
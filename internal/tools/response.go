@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// maxCapturedBody is the number of bytes of an error response kept by ResponseCapture
+const maxCapturedBody = 1024
+
+// NewResponseCapture is a function that returns a new instance of ResponseCapture wrapping w
+func NewResponseCapture(w http.ResponseWriter, r *http.Request) *ResponseCapture {
+	return &ResponseCapture{WrapResponseWriter: middleware.NewWrapResponseWriter(w, r.ProtoMajor)}
+}
+
+// ResponseCapture is a response writer that records the status code and keeps the body of error responses
+type ResponseCapture struct {
+	middleware.WrapResponseWriter
+	// body is the beginning of the body of an error response
+	body bytes.Buffer
+}
+
+// Write is a method that writes the body keeping a copy of error responses
+func (w *ResponseCapture) Write(b []byte) (int, error) {
+	if w.Status() >= http.StatusBadRequest && w.body.Len() < maxCapturedBody {
+		w.body.Write(b)
+	}
+	return w.WrapResponseWriter.Write(b)
+}
+
+// StatusCode is a method that returns the status code written, 200 if none was written explicitly
+func (w *ResponseCapture) StatusCode() int {
+	if w.Status() == 0 {
+		return http.StatusOK
+	}
+	return w.Status()
+}
+
+// ErrorMessage is a method that returns the message of the captured error response
+// (the "message" field written by response.Error), or the raw body when it is not JSON
+func (w *ResponseCapture) ErrorMessage() string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(w.body.Bytes(), &body); err != nil {
+		return w.body.String()
+	}
+	return body.Message
+}
@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Exporters selectable by name
+const (
+	// ExporterNone drops every span, the traceparent is still propagated
+	ExporterNone = "none"
+	// ExporterStdout writes the spans to the standard output
+	ExporterStdout = "stdout"
+	// ExporterFile appends the spans to a file
+	ExporterFile = "file"
+)
+
+// Errors in the tracing
+var (
+	ErrorInvalidExporter    = errors.New("Invalid exporter")
+	ErrorExporterFile       = errors.New("Exporter file could not be opened")
+	ErrorInvalidTraceparent = errors.New("Invalid traceparent")
+)
+
+// NewExporter is a function that returns the exporter selected by name and a function releasing it,
+// path is only used by the file exporter
+func NewExporter(name string, path string) (e Exporter, closeFn func() error, err error) {
+	closeFn = func() error { return nil }
+	switch name {
+	case ExporterNone, "":
+	case ExporterStdout:
+		e = NewWriterExporter(os.Stdout)
+	case ExporterFile:
+		if path == "" {
+			err = fmt.Errorf("%w: file exporter requires a path", ErrorInvalidExporter)
+			return
+		}
+		var f *os.File
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			err = fmt.Errorf("%w: %w", ErrorExporterFile, err)
+			return
+		}
+		e = NewWriterExporter(f)
+		closeFn = f.Close
+	default:
+		err = fmt.Errorf("%w: %s", ErrorInvalidExporter, name)
+	}
+	return
+}
+
+// NewWriterExporter is a function that returns a new instance of WriterExporter writing to w (stdout, a file)
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{enc: json.NewEncoder(w)}
+}
+
+// WriterExporter is a struct that implements the Exporter interface writing one JSON span per line
+type WriterExporter struct {
+	// mu serializes the writes
+	mu sync.Mutex
+	// enc encodes the spans
+	enc *json.Encoder
+}
+
+// ExportSpan is a method that writes a finished span
+func (e *WriterExporter) ExportSpan(s SpanData) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	err = e.enc.Encode(s)
+	return
+}
+
+// NewMemoryExporter is a function that returns a new instance of MemoryExporter
+func NewMemoryExporter() *MemoryExporter {
+	return &MemoryExporter{}
+}
+
+// MemoryExporter is a struct that implements the Exporter interface keeping the spans in memory, meant for tests
+type MemoryExporter struct {
+	// mu guards spans
+	mu sync.Mutex
+	// spans are the exported spans in the order they ended
+	spans []SpanData
+}
+
+// ExportSpan is a method that keeps a finished span
+func (e *MemoryExporter) ExportSpan(s SpanData) (err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.spans = append(e.spans, s)
+	return
+}
+
+// Spans is a method that returns a copy of the exported spans
+func (e *MemoryExporter) Spans() (s []SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	s = make([]SpanData, len(e.spans))
+	copy(s, e.spans)
+	return
+}
+
+// Reset is a method that discards the exported spans
+func (e *MemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.spans = nil
+}
@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"app/internal/tools"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Middleware is a method that returns a middleware tracing each request in a span named after
+// its chi route pattern, continuing the trace of an incoming traceparent header
+func (t *Tracer) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := t.Start(Extract(r.Context(), r.Header), r.Method+" "+r.URL.Path)
+			span.SetAttribute("http.method", r.Method)
+			span.SetAttribute("http.target", r.URL.RequestURI())
+
+			// the client can correlate the response with the trace
+			Inject(ctx, w.Header())
+
+			ww := tools.NewResponseCapture(w, r)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			// the pattern is known once the router has matched the request
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				span.SetName(r.Method + " " + rctx.RoutePattern())
+				span.SetAttribute("http.route", rctx.RoutePattern())
+			}
+			code := ww.StatusCode()
+			span.SetAttribute("http.status_code", code)
+
+			var err error
+			if code >= http.StatusInternalServerError {
+				err = fmt.Errorf("%d %s", code, ww.ErrorMessage())
+			}
+			span.End(err)
+		})
+	}
+}
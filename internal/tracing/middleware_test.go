@@ -0,0 +1,118 @@
+package tracing_test
+
+import (
+	"app/internal/tracing"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestTracer_Middleware(t *testing.T) {
+	const (
+		trace  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parent = "00f067aa0ba902b7"
+	)
+
+	cases := []struct {
+		name        string
+		traceparent string
+		target      string
+		spanName    string
+		status      int
+		err         bool
+		exported    bool
+		parent      string
+	}{
+		{
+			name:     "root span named after the route pattern",
+			target:   "/vehicles/1",
+			spanName: "GET /vehicles/{id}",
+			status:   http.StatusOK,
+			exported: true,
+		},
+		{
+			name:        "trace continued from the traceparent of the request",
+			traceparent: "00-" + trace + "-" + parent + "-01",
+			target:      "/vehicles/1",
+			spanName:    "GET /vehicles/{id}",
+			status:      http.StatusOK,
+			exported:    true,
+			parent:      parent,
+		},
+		{
+			name:     "server error recorded in the span",
+			target:   "/vehicles/0",
+			spanName: "GET /vehicles/{id}",
+			status:   http.StatusInternalServerError,
+			err:      true,
+			exported: true,
+		},
+		{
+			name:        "trace not sampled by the caller",
+			traceparent: "00-" + trace + "-" + parent + "-00",
+			target:      "/vehicles/1",
+			status:      http.StatusOK,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			exp := tracing.NewMemoryExporter()
+			tr := tracing.NewTracer(exp)
+			rt := chi.NewRouter()
+			rt.Use(tr.Middleware())
+			rt.Get("/vehicles/{id}", func(w http.ResponseWriter, r *http.Request) {
+				// - the handler works within a child of the request span
+				_, span := tr.Start(r.Context(), "handler")
+				defer span.End(nil)
+
+				if chi.URLParam(r, "id") == "0" {
+					http.Error(w, "boom", http.StatusInternalServerError)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, c.target, nil)
+			if c.traceparent != "" {
+				req.Header.Set(tracing.HeaderTraceparent, c.traceparent)
+			}
+			res := httptest.NewRecorder()
+			rt.ServeHTTP(res, req)
+
+			if res.Code != c.status {
+				t.Fatalf("status = %d, want %d", res.Code, c.status)
+			}
+			spans := exp.Spans()
+			if !c.exported {
+				if len(spans) != 0 {
+					t.Errorf("spans = %+v, want none", spans)
+				}
+				return
+			}
+			if len(spans) != 2 {
+				t.Fatalf("spans = %+v, want the handler and the request ones", spans)
+			}
+			child, span := spans[0], spans[1]
+
+			if span.Name != c.spanName || span.Attributes["http.status_code"] != c.status || (span.Error != "") != c.err {
+				t.Errorf("span = %+v, want %s with status %d and error %t", span, c.spanName, c.status, c.err)
+			}
+			if span.ParentSpanID != c.parent {
+				t.Errorf("parent span ID = %q, want %q", span.ParentSpanID, c.parent)
+			}
+			if c.parent != "" && span.TraceID != trace {
+				t.Errorf("trace ID = %s, want the one of the request %s", span.TraceID, trace)
+			}
+			if child.TraceID != span.TraceID || child.ParentSpanID != span.SpanID {
+				t.Errorf("handler span = %+v, want a child of %s", child, span.SpanID)
+			}
+			// - the response carries the request span so the client can correlate it
+			if got, want := res.Header().Get(tracing.HeaderTraceparent), "00-"+span.TraceID+"-"+span.SpanID+"-01"; got != want {
+				t.Errorf("traceparent of the response = %q, want %q", got, want)
+			}
+		})
+	}
+}
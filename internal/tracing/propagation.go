@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HeaderTraceparent is the W3C Trace Context header
+const HeaderTraceparent = "traceparent"
+
+// ParseTraceparent is a function that parses a W3C traceparent value: version-traceid-spanid-flags
+func ParseTraceparent(value string) (sc SpanContext, err error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	// future versions may append fields, version 00 has exactly four
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		err = ErrorInvalidTraceparent
+		return
+	}
+
+	var traceID TraceID
+	var spanID SpanID
+	var flags [1]byte
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 ||
+		decode(traceID[:], parts[1]) != nil || decode(spanID[:], parts[2]) != nil || decode(flags[:], parts[3]) != nil {
+		err = ErrorInvalidTraceparent
+		return
+	}
+
+	sc = SpanContext{TraceID: traceID, SpanID: spanID, Sampled: flags[0]&0x01 == 0x01}
+	if !sc.IsValid() {
+		sc = SpanContext{}
+		err = ErrorInvalidTraceparent
+	}
+	return
+}
+
+// FormatTraceparent is a function that formats a span context as a W3C traceparent value
+func FormatTraceparent(sc SpanContext) string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// Extract is a function that returns a copy of ctx carrying the span context of the traceparent header,
+// ctx is returned unchanged when the header is missing or invalid
+func Extract(ctx context.Context, h http.Header) context.Context {
+	sc, err := ParseTraceparent(h.Get(HeaderTraceparent))
+	if err != nil {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Inject is a function that sets the traceparent header to the current span of ctx
+func Inject(ctx context.Context, h http.Header) {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	h.Set(HeaderTraceparent, FormatTraceparent(sc))
+}
+
+// decode is a function that decodes lowercase hex into dst
+func decode(dst []byte, s string) (err error) {
+	if strings.ToLower(s) != s {
+		err = ErrorInvalidTraceparent
+		return
+	}
+	_, err = hex.Decode(dst, []byte(s))
+	return
+}
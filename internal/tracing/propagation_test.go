@@ -0,0 +1,86 @@
+package tracing_test
+
+import (
+	"app/internal/tracing"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		trace   string
+		span    string
+		sampled bool
+		err     error
+	}{
+		{
+			name:    "sampled",
+			value:   "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			trace:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			span:    "00f067aa0ba902b7",
+			sampled: true,
+		},
+		{
+			name:  "not sampled",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			trace: "4bf92f3577b34da6a3ce929d0e0e4736",
+			span:  "00f067aa0ba902b7",
+		},
+		{
+			name:    "future version with more fields",
+			value:   "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			trace:   "4bf92f3577b34da6a3ce929d0e0e4736",
+			span:    "00f067aa0ba902b7",
+			sampled: true,
+		},
+		{name: "empty", value: "", err: tracing.ErrorInvalidTraceparent},
+		{name: "version 00 with more fields", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", err: tracing.ErrorInvalidTraceparent},
+		{name: "forbidden version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", err: tracing.ErrorInvalidTraceparent},
+		{name: "upper case", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", err: tracing.ErrorInvalidTraceparent},
+		{name: "short trace ID", value: "00-4bf92f3577b34da6-00f067aa0ba902b7-01", err: tracing.ErrorInvalidTraceparent},
+		{name: "zero trace ID", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", err: tracing.ErrorInvalidTraceparent},
+		{name: "zero span ID", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", err: tracing.ErrorInvalidTraceparent},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sc, err := tracing.ParseTraceparent(c.value)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err != nil {
+				if sc.IsValid() {
+					t.Errorf("span context = %+v, want the zero value", sc)
+				}
+				return
+			}
+			if sc.TraceID.String() != c.trace || sc.SpanID.String() != c.span || sc.Sampled != c.sampled {
+				t.Errorf("span context = %s %s %t, want %s %s %t", sc.TraceID, sc.SpanID, sc.Sampled, c.trace, c.span, c.sampled)
+			}
+		})
+	}
+}
+
+func TestInject_Extract(t *testing.T) {
+	value := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	// - the span context extracted from a request is injected back as it was
+	ctx := tracing.Extract(context.Background(), http.Header{"Traceparent": {value}})
+	h := http.Header{}
+	tracing.Inject(ctx, h)
+	if got := h.Get(tracing.HeaderTraceparent); got != value {
+		t.Errorf("traceparent = %q, want %q", got, value)
+	}
+
+	// - an invalid header leaves the context without span, nothing is injected
+	ctx = tracing.Extract(context.Background(), http.Header{"Traceparent": {"invalid"}})
+	h = http.Header{}
+	tracing.Inject(ctx, h)
+	if got := h.Get(tracing.HeaderTraceparent); got != "" {
+		t.Errorf("traceparent = %q, want none", got)
+	}
+}
@@ -0,0 +1,219 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// TraceID is the identifier of a trace
+type TraceID [16]byte
+
+// String is a method that returns the trace ID in lowercase hex
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// IsValid is a method that reports whether the trace ID is not all zeros
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// SpanID is the identifier of a span
+type SpanID [8]byte
+
+// String is a method that returns the span ID in lowercase hex
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// IsValid is a method that reports whether the span ID is not all zeros
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanContext is a struct that represents the identity of a span propagated across boundaries
+type SpanContext struct {
+	// TraceID is the trace the span belongs to
+	TraceID TraceID
+	// SpanID is the identifier of the span
+	SpanID SpanID
+	// Sampled reports whether the span is exported
+	Sampled bool
+}
+
+// IsValid is a method that reports whether the span context has both identifiers
+func (sc SpanContext) IsValid() bool { return sc.TraceID.IsValid() && sc.SpanID.IsValid() }
+
+// SpanData is a struct that represents a finished span as handed to the exporters
+type SpanData struct {
+	// Name is the name of the operation
+	Name string `json:"name"`
+	// TraceID is the trace the span belongs to
+	TraceID string `json:"trace_id"`
+	// SpanID is the identifier of the span
+	SpanID string `json:"span_id"`
+	// ParentSpanID is the identifier of the parent span, empty for root spans
+	ParentSpanID string `json:"parent_span_id,omitempty"`
+	// Start is the time the operation started
+	Start time.Time `json:"start"`
+	// End is the time the operation ended
+	End time.Time `json:"end"`
+	// DurationMs is the duration of the operation in milliseconds
+	DurationMs float64 `json:"duration_ms"`
+	// Attributes are the key-values describing the operation
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Error is the error the operation ended with, empty on success
+	Error string `json:"error,omitempty"`
+}
+
+// Exporter is an interface that represents the destination of finished spans
+type Exporter interface {
+	// ExportSpan is a method that exports a finished span
+	ExportSpan(s SpanData) (err error)
+}
+
+// NewTracer is a function that returns a new instance of Tracer, a nil exporter drops every span
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// Tracer is a struct that creates spans and hands them to an exporter once ended
+type Tracer struct {
+	// exporter is the destination of finished spans
+	exporter Exporter
+}
+
+// Start is a method that starts a span child of the span in ctx (or of the remote span
+// extracted from a traceparent), returning a context carrying the new span
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+
+	sc := SpanContext{SpanID: newSpanID(), Sampled: true}
+	if parent.IsValid() {
+		sc.TraceID = parent.TraceID
+		sc.Sampled = parent.Sampled
+	} else {
+		sc.TraceID = newTraceID()
+	}
+
+	s := &Span{
+		tracer: t,
+		sc:     sc,
+		parent: parent.SpanID,
+		name:   name,
+		start:  time.Now(),
+	}
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Span is a struct that represents an operation being traced
+type Span struct {
+	// tracer is the tracer that created the span
+	tracer *Tracer
+	// sc is the identity of the span
+	sc SpanContext
+	// parent is the identifier of the parent span
+	parent SpanID
+	// name is the name of the operation
+	name string
+	// start is the time the operation started
+	start time.Time
+	// mu guards the fields below
+	mu sync.Mutex
+	// attributes are the key-values describing the operation
+	attributes map[string]any
+	// ended reports whether End was called
+	ended bool
+}
+
+// SpanContext is a method that returns the identity of the span
+func (s *Span) SpanContext() SpanContext { return s.sc }
+
+// SetName is a method that renames the span, e.g. once the route pattern is known
+func (s *Span) SetName(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.name = name
+}
+
+// SetAttribute is a method that sets a key-value describing the operation
+func (s *Span) SetAttribute(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.attributes == nil {
+		s.attributes = make(map[string]any)
+	}
+	s.attributes[key] = value
+}
+
+// End is a method that ends the span with the error of the operation (nil on success) and exports it
+func (s *Span) End(err error) {
+	end := time.Now()
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	data := SpanData{
+		Name:       s.name,
+		TraceID:    s.sc.TraceID.String(),
+		SpanID:     s.sc.SpanID.String(),
+		Start:      s.start,
+		End:        end,
+		DurationMs: float64(end.Sub(s.start)) / float64(time.Millisecond),
+		Attributes: s.attributes,
+	}
+	s.mu.Unlock()
+
+	if s.parent.IsValid() {
+		data.ParentSpanID = s.parent.String()
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+
+	if !s.sc.Sampled || s.tracer.exporter == nil {
+		return
+	}
+	// exporting must never break the traced operation
+	_ = s.tracer.exporter.ExportSpan(data)
+}
+
+// spanKey is the key of the current span in a context
+type spanKey struct{}
+
+// remoteKey is the key of a span context extracted from an incoming request
+type remoteKey struct{}
+
+// SpanFromContext is a function that returns the current span of ctx, nil if there is none
+func SpanFromContext(ctx context.Context) (s *Span) {
+	s, _ = ctx.Value(spanKey{}).(*Span)
+	return
+}
+
+// SpanContextFromContext is a function that returns the identity of the current span of ctx,
+// or the remote one extracted from an incoming request
+func SpanContextFromContext(ctx context.Context) (sc SpanContext) {
+	if s := SpanFromContext(ctx); s != nil {
+		sc = s.sc
+		return
+	}
+	sc, _ = ctx.Value(remoteKey{}).(SpanContext)
+	return
+}
+
+// ContextWithRemoteSpanContext is a function that returns a copy of ctx carrying a remote span context
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// newTraceID is a function that returns a random trace ID
+func newTraceID() (t TraceID) {
+	_, _ = rand.Read(t[:])
+	return
+}
+
+// newSpanID is a function that returns a random span ID
+func newSpanID() (s SpanID) {
+	_, _ = rand.Read(s[:])
+	return
+}
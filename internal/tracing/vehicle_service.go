@@ -0,0 +1,289 @@
+package tracing
+
+import (
+	"app/internal"
+	"context"
+)
+
+// NewVehicleService is a function that returns a new instance of VehicleService
+func NewVehicleService(sv internal.VehicleService, t *Tracer) *VehicleService {
+	return &VehicleService{sv: sv, t: t}
+}
+
+// VehicleService is a struct that implements the VehicleService interface tracing every operation of another service
+type VehicleService struct {
+	// sv is the traced service
+	sv internal.VehicleService
+	// t creates the spans
+	t *Tracer
+}
+
+// FindAll is a method that returns a map of all vehicles
+func (s *VehicleService) FindAll() (v map[int]internal.Vehicle, err error) {
+	v, err = s.FindAllContext(context.Background())
+	return
+}
+
+// FindAllContext is like FindAll but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindAllContext(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindAll")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindAllContext(ctx)
+	return
+}
+
+// FindByID is a method that returns a vehicle by its ID
+func (s *VehicleService) FindByID(id int) (v internal.Vehicle, err error) {
+	v, err = s.FindByIDContext(context.Background(), id)
+	return
+}
+
+// FindByIDContext is like FindByID but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindByIDContext(ctx context.Context, id int) (v internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByID")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByIDContext(ctx, id)
+	return
+}
+
+// Create is a method that creates a new vehicle
+func (s *VehicleService) Create(v *internal.Vehicle) (err error) {
+	err = s.CreateContext(context.Background(), v)
+	return
+}
+
+// CreateContext is like Create but honors the cancellation and deadline of ctx
+func (s *VehicleService) CreateContext(ctx context.Context, v *internal.Vehicle) (err error) {
+	ctx, span := s.t.Start(ctx, "service.Create")
+	defer func() { span.End(err) }()
+
+	err = s.sv.CreateContext(ctx, v)
+	return
+}
+
+// FindByColorAndYear is a method that returns a map of vehicles that match the color and year
+func (s *VehicleService) FindByColorAndYear(color string, year int) (v map[int]internal.Vehicle, err error) {
+	v, err = s.FindByColorAndYearContext(context.Background(), color, year)
+	return
+}
+
+// FindByColorAndYearContext is like FindByColorAndYear but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindByColorAndYearContext(ctx context.Context, color string, year int) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByColorAndYear")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByColorAndYearContext(ctx, color, year)
+	return
+}
+
+// FindByBrandAndRangeYear is a method that returns a map of vehicles that match the brand and range year
+func (s *VehicleService) FindByBrandAndRangeYear(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v, err = s.FindByBrandAndRangeYearContext(context.Background(), brand, startYear, endYear)
+	return
+}
+
+// FindByBrandAndRangeYearContext is like FindByBrandAndRangeYear but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindByBrandAndRangeYearContext(ctx context.Context, brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByBrandAndRangeYear")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByBrandAndRangeYearContext(ctx, brand, startYear, endYear)
+	return
+}
+
+// FindAverageSpeedByBrand is a method that returns a map of vehicles that match the average speed and brand
+func (s *VehicleService) FindAverageSpeedByBrand(brand string) (avgSpeed float64, err error) {
+	avgSpeed, err = s.FindAverageSpeedByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageSpeedByBrandContext is like FindAverageSpeedByBrand but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindAverageSpeedByBrandContext(ctx context.Context, brand string) (avgSpeed float64, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindAverageSpeedByBrand")
+	defer func() { span.End(err) }()
+
+	avgSpeed, err = s.sv.FindAverageSpeedByBrandContext(ctx, brand)
+	return
+}
+
+// CreateBatch is a method that creates a batch of vehicles
+func (s *VehicleService) CreateBatch(v []internal.Vehicle) (err error) {
+	err = s.CreateBatchContext(context.Background(), v)
+	return
+}
+
+// CreateBatchContext is like CreateBatch but honors the cancellation and deadline of ctx
+func (s *VehicleService) CreateBatchContext(ctx context.Context, v []internal.Vehicle) (err error) {
+	ctx, span := s.t.Start(ctx, "service.CreateBatch")
+	defer func() { span.End(err) }()
+
+	err = s.sv.CreateBatchContext(ctx, v)
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the max speed of a vehicle
+func (s *VehicleService) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	err = s.UpdateMaxSpeedContext(context.Background(), id, maxSpeed)
+	return
+}
+
+// UpdateMaxSpeedContext is like UpdateMaxSpeed but honors the cancellation and deadline of ctx
+func (s *VehicleService) UpdateMaxSpeedContext(ctx context.Context, id int, maxSpeed float64) (err error) {
+	ctx, span := s.t.Start(ctx, "service.UpdateMaxSpeed")
+	defer func() { span.End(err) }()
+
+	err = s.sv.UpdateMaxSpeedContext(ctx, id, maxSpeed)
+	return
+}
+
+// FindByFuelType is a method that returns a map of vehicles that match the fuel type
+func (s *VehicleService) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.FindByFuelTypeContext(context.Background(), fuelType)
+	return
+}
+
+// FindByFuelTypeContext is like FindByFuelType but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindByFuelTypeContext(ctx context.Context, fuelType string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByFuelType")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByFuelTypeContext(ctx, fuelType)
+	return
+}
+
+// Delete is a method that deletes a vehicle
+func (s *VehicleService) Delete(id int) (err error) {
+	err = s.DeleteContext(context.Background(), id)
+	return
+}
+
+// DeleteContext is like Delete but honors the cancellation and deadline of ctx
+func (s *VehicleService) DeleteContext(ctx context.Context, id int) (err error) {
+	ctx, span := s.t.Start(ctx, "service.Delete")
+	defer func() { span.End(err) }()
+
+	err = s.sv.DeleteContext(ctx, id)
+	return
+}
+
+// FindByTransmissionType is a method that returns a map of vehicles that match the transmission type
+func (s *VehicleService) FindByTransmissionType(transmissionType string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.FindByTransmissionTypeContext(context.Background(), transmissionType)
+	return
+}
+
+// FindByTransmissionTypeContext is like FindByTransmissionType but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindByTransmissionTypeContext(ctx context.Context, transmissionType string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByTransmissionType")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByTransmissionTypeContext(ctx, transmissionType)
+	return
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle
+func (s *VehicleService) UpdateFuelType(id int, fuelType string) (err error) {
+	err = s.UpdateFuelTypeContext(context.Background(), id, fuelType)
+	return
+}
+
+// UpdateFuelTypeContext is like UpdateFuelType but honors the cancellation and deadline of ctx
+func (s *VehicleService) UpdateFuelTypeContext(ctx context.Context, id int, fuelType string) (err error) {
+	ctx, span := s.t.Start(ctx, "service.UpdateFuelType")
+	defer func() { span.End(err) }()
+
+	err = s.sv.UpdateFuelTypeContext(ctx, id, fuelType)
+	return
+}
+
+// FindAverageCapacityByBrand is a method that returns a map of vehicles that match the average person capacity and brand
+func (s *VehicleService) FindAverageCapacityByBrand(brand string) (avgCapacity float64, err error) {
+	avgCapacity, err = s.FindAverageCapacityByBrandContext(context.Background(), brand)
+	return
+}
+
+// FindAverageCapacityByBrandContext is like FindAverageCapacityByBrand but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindAverageCapacityByBrandContext(ctx context.Context, brand string) (avgCapacity float64, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindAverageCapacityByBrand")
+	defer func() { span.End(err) }()
+
+	avgCapacity, err = s.sv.FindAverageCapacityByBrandContext(ctx, brand)
+	return
+}
+
+// FindByDimensions is a method that returns a map of vehicles that match the dimensions
+func (s *VehicleService) FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v, err = s.FindByDimensionsContext(context.Background(), minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// FindByDimensionsContext is like FindByDimensions but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindByDimensionsContext(ctx context.Context, minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByDimensions")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByDimensionsContext(ctx, minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// FindByWeightRange is a method that returns a map of vehicles that match the weight range
+func (s *VehicleService) FindByWeightRange(minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	v, err = s.FindByWeightRangeContext(context.Background(), minWeight, maxWeight)
+	return
+}
+
+// FindByWeightRangeContext is like FindByWeightRange but honors the cancellation and deadline of ctx
+func (s *VehicleService) FindByWeightRangeContext(ctx context.Context, minWeight float64, maxWeight float64) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByWeightRange")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByWeightRangeContext(ctx, minWeight, maxWeight)
+	return
+}
+
+// SearchContext is a method that returns up to limit vehicles matching the words of a query, the most relevant first
+func (s *VehicleService) SearchContext(ctx context.Context, query string, limit int) (hits []internal.VehicleHit, err error) {
+	ctx, span := s.t.Start(ctx, "service.Search")
+	defer func() { span.End(err) }()
+
+	hits, err = s.sv.SearchContext(ctx, query, limit)
+	return
+}
+
+// FindByCatalogContext is a method that returns a map of vehicles linked to catalog entries that match a filter
+func (s *VehicleService) FindByCatalogContext(ctx context.Context, filter internal.CatalogFilter) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByCatalog")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByCatalogContext(ctx, filter)
+	return
+}
+
+// FindByRegistrationContext is a method that returns a map of vehicles whose registration has the normalized form of a registration
+func (s *VehicleService) FindByRegistrationContext(ctx context.Context, registration string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByRegistration")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByRegistrationContext(ctx, registration)
+	return
+}
+
+// FindRegistrationDuplicatesContext is a method that returns the registrations shared by several vehicles
+func (s *VehicleService) FindRegistrationDuplicatesContext(ctx context.Context) (d []internal.RegistrationDuplicate, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindRegistrationDuplicates")
+	defer func() { span.End(err) }()
+
+	d, err = s.sv.FindRegistrationDuplicatesContext(ctx)
+	return
+}
+
+// FindByVINContext is a method that returns the vehicle whose VIN has the normalized form of a VIN
+func (s *VehicleService) FindByVINContext(ctx context.Context, vin string) (v internal.Vehicle, err error) {
+	ctx, span := s.t.Start(ctx, "service.FindByVIN")
+	defer func() { span.End(err) }()
+
+	v, err = s.sv.FindByVINContext(ctx, vin)
+	return
+}
@@ -18,6 +18,8 @@ type VehicleAttributes struct {
 	Model string
 	// Registration is the registration of the vehicle
 	Registration string
+	// VIN is the vehicle identification number of the vehicle, empty if unknown
+	VIN string
 	// Color is the color of the vehicle
 	Color string
 	// FabricationYear is the fabrication year of the vehicle
@@ -27,9 +29,9 @@ type VehicleAttributes struct {
 	// MaxSpeed is the maximum speed of the vehicle
 	MaxSpeed float64
 	// FuelType is the fuel type of the vehicle
-	FuelType string
+	FuelType FuelType
 	// Transmission is the transmission of the vehicle
-	Transmission string
+	Transmission Transmission
 	// Weight is the weight of the vehicle
 	Weight float64
 	// Dimensions is the dimensions of the vehicle
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+// Types of the changes made to the vehicles
+const (
+	VehicleChangeCreated = "created"
+	VehicleChangeUpdated = "updated"
+	VehicleChangeDeleted = "deleted"
+	// VehicleChangeReloaded is the change of every vehicle of a tenant, replaced by a reload of its dataset
+	VehicleChangeReloaded = "reloaded"
+)
+
+// VehicleChange is a struct that represents a change made to a vehicle through the service,
+// or to every vehicle of a tenant by a reload
+type VehicleChange struct {
+	// Type is what happened to the vehicle
+	Type string
+	// Tenant is the tenant of the vehicle
+	Tenant string
+	// ID is the ID of the vehicle, 0 for a reload
+	ID int
+	// Vehicle is the vehicle after the change, the zero vehicle when it was deleted or reloaded
+	Vehicle Vehicle
+	// Time is when the change was made
+	Time time.Time
+}
+
+// VehicleChangeFeed is an interface that represents a feed of the changes made to the vehicles
+type VehicleChangeFeed interface {
+	// Subscribe is a method that returns a channel receiving the changes made from now on,
+	// it is closed once ctx is done. A subscriber too slow to keep up misses changes
+	Subscribe(ctx context.Context) (ch <-chan VehicleChange)
+}
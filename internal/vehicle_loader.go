@@ -1,7 +1,49 @@
 package internal
 
+import "errors"
+
 // VehicleLoader is an interface that represents the loader for vehicles
 type VehicleLoader interface {
 	// Load is a method that loads the vehicles
 	Load() (v map[int]Vehicle, err error)
-}
\ No newline at end of file
+}
+
+// VehicleMergeConflict is a struct that represents a collision between the sources of a VehicleMergeLoader
+type VehicleMergeConflict struct {
+	// Id is the ID of the incoming vehicle in its source
+	Id int
+	// Registration is the registration of the incoming vehicle
+	Registration string
+	// Source is the file the incoming vehicle was read from
+	Source string
+	// ConflictingIds are the IDs of the already loaded vehicles it collided with
+	ConflictingIds []int
+	// Resolution is the action taken by the merge policy
+	Resolution string
+}
+
+// VehicleMergeReport is a struct that represents how the sources of a VehicleMergeLoader were merged
+type VehicleMergeReport struct {
+	// Files are the files that were loaded, in order
+	Files []string
+	// Vehicles are the number of vehicles kept from each file, by file
+	Vehicles map[string]int
+	// Conflicts are the collisions found between the files
+	Conflicts []VehicleMergeConflict
+}
+
+// VehicleMergeLoader is an interface that represents a loader merging several sources
+type VehicleMergeLoader interface {
+	VehicleLoader
+
+	// MergeReport is a method that returns how the sources of the last successful load were merged
+	MergeReport() (r VehicleMergeReport)
+}
+
+// Errors in loaders
+var (
+	ErrorLoaderNoSources      = errors.New("No sources to load vehicles from")
+	ErrorLoaderSourceNotFound = errors.New("Source not found")
+	ErrorLoaderConflict       = errors.New("Vehicle conflicts with another source")
+	ErrorLoaderInvalidPolicy  = errors.New("Invalid merge policy, must be first-wins, last-wins, fail or renumber")
+)
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+// ReloadStatus is a struct that represents the state of the dataset reloads
+type ReloadStatus struct {
+	// Source is the description of what is being loaded
+	Source string
+	// DatasetSize is the number of vehicles loaded by the last successful reload
+	DatasetSize int
+	// Reloads is the number of successful reloads
+	Reloads int
+	// Failures is the number of failed reloads
+	Failures int
+	// LastAttempt is the time of the last reload attempt
+	LastAttempt time.Time
+	// LastSuccess is the time of the last successful reload
+	LastSuccess time.Time
+	// LastDuration is the duration of the last reload attempt
+	LastDuration time.Duration
+	// LastError is the error of the last reload attempt, empty on success
+	LastError string
+	// Merge is how the sources of the last successful reload were merged, nil when the loader reads a single source
+	Merge *VehicleMergeReport
+}
+
+// VehicleReloader is an interface that represents a reloader of the vehicles dataset
+type VehicleReloader interface {
+	// Reload is a method that loads the dataset, validates it and swaps the repository contents
+	Reload() (s ReloadStatus, err error)
+
+	// Status is a method that returns the state of the reloads
+	Status() (s ReloadStatus)
+}
+
+// Errors in reloaders
+var (
+	ErrorReloadEmptyDataset   = errors.New("Dataset has no vehicles")
+	ErrorReloadInvalidVehicle = errors.New("Invalid vehicle in dataset")
+	ErrorReloadInProgress     = errors.New("Reload already in progress")
+	ErrorDatasetNotLoaded     = errors.New("Dataset not loaded yet")
+)
@@ -1,49 +1,134 @@
 package internal
 
+import "context"
+
 // VehicleRepository is an interface that represents a vehicle repository
 type VehicleRepository interface {
 	// FindAll is a method that returns a map of all vehicles
 	FindAll() (v map[int]Vehicle, err error)
 
+	// FindAllContext is like FindAll but honors the cancellation and deadline of ctx
+	FindAllContext(ctx context.Context) (v map[int]Vehicle, err error)
+
 	// FindByID is a method that returns a vehicle by its ID
 	FindByID(id int) (v Vehicle, err error)
 
+	// FindByIDContext is like FindByID but honors the cancellation and deadline of ctx
+	FindByIDContext(ctx context.Context, id int) (v Vehicle, err error)
+
 	// Create is a method that creates a new vehicle
 	Create(v *Vehicle) (err error)
 
+	// CreateContext is like Create but honors the cancellation and deadline of ctx
+	CreateContext(ctx context.Context, v *Vehicle) (err error)
+
 	// FindByColorAndYear is a method that returns a map of vehicles that match the color and year
 	FindByColorAndYear(color string, year int) (v map[int]Vehicle, err error)
 
+	// FindByColorAndYearContext is like FindByColorAndYear but honors the cancellation and deadline of ctx
+	FindByColorAndYearContext(ctx context.Context, color string, year int) (v map[int]Vehicle, err error)
+
 	// FindByBrandAndRangeYear is a method that returns a map of vehicles that match the brand and range year
 	FindByBrandAndRangeYear(brand string, startYear int, endYear int) (v map[int]Vehicle, err error)
 
+	// FindByBrandAndRangeYearContext is like FindByBrandAndRangeYear but honors the cancellation and deadline of ctx
+	FindByBrandAndRangeYearContext(ctx context.Context, brand string, startYear int, endYear int) (v map[int]Vehicle, err error)
+
 	// FindAverageSpeedByBrand is a method that returns a map of vehicles that match the average speed and brand
 	FindAverageSpeedByBrand(brand string) (avgSpeed float64, err error)
 
-	// CreateBatch is a method that creates a batch of vehicles
+	// FindAverageSpeedByBrandContext is like FindAverageSpeedByBrand but honors the cancellation and deadline of ctx
+	FindAverageSpeedByBrandContext(ctx context.Context, brand string) (avgSpeed float64, err error)
+
+	// CreateBatch is a method that creates a batch of vehicles, all of them or none when any fails
 	CreateBatch(v []Vehicle) (err error)
 
+	// CreateBatchContext is like CreateBatch but honors the cancellation and deadline of ctx
+	CreateBatchContext(ctx context.Context, v []Vehicle) (err error)
+
 	// UpdateMaxSpeed is a method that updates the max speed of a vehicle
 	UpdateMaxSpeed(id int, maxSpeed float64) (err error)
 
+	// UpdateMaxSpeedContext is like UpdateMaxSpeed but honors the cancellation and deadline of ctx
+	UpdateMaxSpeedContext(ctx context.Context, id int, maxSpeed float64) (err error)
+
 	// FindByFuelType is a method that returns a map of vehicles that match the fuel type
 	FindByFuelType(fuelType string) (v map[int]Vehicle, err error)
 
+	// FindByFuelTypeContext is like FindByFuelType but honors the cancellation and deadline of ctx
+	FindByFuelTypeContext(ctx context.Context, fuelType string) (v map[int]Vehicle, err error)
+
 	// Delete is a method that deletes a vehicle
 	Delete(id int) (err error)
 
+	// DeleteContext is like Delete but honors the cancellation and deadline of ctx
+	DeleteContext(ctx context.Context, id int) (err error)
+
 	// FindByTransmissionType is a method that returns a map of vehicles that match the transmission type
 	FindByTransmissionType(transmissionType string) (v map[int]Vehicle, err error)
 
+	// FindByTransmissionTypeContext is like FindByTransmissionType but honors the cancellation and deadline of ctx
+	FindByTransmissionTypeContext(ctx context.Context, transmissionType string) (v map[int]Vehicle, err error)
+
 	// UpdateFuelType is a method that updates the fuel type of a vehicle
 	UpdateFuelType(id int, fuelType string) (err error)
 
+	// UpdateFuelTypeContext is like UpdateFuelType but honors the cancellation and deadline of ctx
+	UpdateFuelTypeContext(ctx context.Context, id int, fuelType string) (err error)
+
 	// FindAverageCapacityByBrand is a method that returns a map of vehicles that match the average person capacity and brand
 	FindAverageCapacityByBrand(brand string) (avgCapacity float64, err error)
 
+	// FindAverageCapacityByBrandContext is like FindAverageCapacityByBrand but honors the cancellation and deadline of ctx
+	FindAverageCapacityByBrandContext(ctx context.Context, brand string) (avgCapacity float64, err error)
+
 	// FindByDimensions is a method that returns a map of vehicles that match the dimensions
 	FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]Vehicle, err error)
 
+	// FindByDimensionsContext is like FindByDimensions but honors the cancellation and deadline of ctx
+	FindByDimensionsContext(ctx context.Context, minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]Vehicle, err error)
+
 	// FindByWeightRange is a method that returns a map of vehicles that match the weight range
 	FindByWeightRange(minWeight float64, maxWeight float64) (v map[int]Vehicle, err error)
+
+	// FindByWeightRangeContext is like FindByWeightRange but honors the cancellation and deadline of ctx
+	FindByWeightRangeContext(ctx context.Context, minWeight float64, maxWeight float64) (v map[int]Vehicle, err error)
+
+	// ReplaceAll is a method that atomically replaces every vehicle with the given ones
+	ReplaceAll(v map[int]Vehicle) (err error)
+
+	// ReplaceAllContext is like ReplaceAll but honors the cancellation and deadline of ctx
+	ReplaceAllContext(ctx context.Context, v map[int]Vehicle) (err error)
+
+	// SearchContext is a method that returns up to limit vehicles matching the words of a query by brand, model,
+	// color and registration, the most relevant first, honoring the cancellation and deadline of ctx
+	SearchContext(ctx context.Context, query string, limit int) (hits []VehicleHit, err error)
+
+	// FindByCatalogContext is a method that returns a map of vehicles linked by their brand and model to catalog
+	// entries that match a filter, honoring the cancellation and deadline of ctx
+	FindByCatalogContext(ctx context.Context, filter CatalogFilter) (v map[int]Vehicle, err error)
+
+	// FindByRegistrationContext is a method that returns a map of vehicles whose registration has the normalized
+	// form of a registration, honoring the cancellation and deadline of ctx
+	FindByRegistrationContext(ctx context.Context, registration string) (v map[int]Vehicle, err error)
+
+	// FindRegistrationDuplicatesContext is a method that returns the registrations shared by several vehicles,
+	// sorted, honoring the cancellation and deadline of ctx
+	FindRegistrationDuplicatesContext(ctx context.Context) (d []RegistrationDuplicate, err error)
+
+	// FindByVINContext is a method that returns the vehicle whose VIN has the normalized form of a VIN,
+	// honoring the cancellation and deadline of ctx
+	FindByVINContext(ctx context.Context, vin string) (v Vehicle, err error)
+}
+
+// VehicleRepositoryPinger is an interface implemented by repositories backed by a storage that may be unreachable
+type VehicleRepositoryPinger interface {
+	// Ping is a method that checks that the storage is reachable
+	Ping() (err error)
+}
+
+// VehicleRepositoryFlusher is an interface implemented by repositories that buffer writes to a persistent storage
+type VehicleRepositoryFlusher interface {
+	// Flush is a method that writes every pending change to the storage
+	Flush() (err error)
 }
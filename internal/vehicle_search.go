@@ -0,0 +1,24 @@
+package internal
+
+import "errors"
+
+// Limits of the vehicles returned by a text search
+const (
+	// SearchDefaultLimit is the number of vehicles returned when no limit is given
+	SearchDefaultLimit = 20
+	// SearchMaxLimit is the largest number of vehicles returned
+	SearchMaxLimit = 100
+)
+
+// VehicleHit is a struct that represents a vehicle found by a text search
+type VehicleHit struct {
+	// Vehicle is the vehicle found
+	Vehicle Vehicle
+	// Score is the relevance of the vehicle to the query, higher is better
+	Score float64
+}
+
+// Errors in text searches
+var (
+	ErrorInvalidSearchQuery = errors.New("Search query must have a letter or digit")
+)
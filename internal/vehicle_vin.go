@@ -0,0 +1,31 @@
+package internal
+
+import "errors"
+
+// VINInfo is a struct that represents what a vehicle identification number tells about its vehicle
+type VINInfo struct {
+	// VIN is the normalized vehicle identification number
+	VIN string
+	// WMI is the world manufacturer identifier, its first three characters
+	WMI string
+	// Manufacturers are the brands the WMI is assigned to, empty if it is unknown
+	Manufacturers []string
+	// ModelYears are the model years the tenth character may stand for, as its codes repeat every 30 years
+	ModelYears []int
+}
+
+// VINDecoder is an interface that represents the decoder of the vehicle identification numbers (ISO 3779)
+type VINDecoder interface {
+	// Normalize is a method that returns the normalized form of a VIN, the key of the uniqueness index
+	Normalize(vin string) (normalized string)
+
+	// Decode is a method that returns what a VIN tells about its vehicle, failing when it is malformed
+	// or its check digit does not match
+	Decode(vin string) (info VINInfo, err error)
+}
+
+// Errors in vehicle identification numbers
+var (
+	ErrorInvalidVIN  = errors.New("VIN is invalid")
+	ErrorVINMismatch = errors.New("VIN does not match the vehicle")
+)
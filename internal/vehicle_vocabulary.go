@@ -0,0 +1,49 @@
+package internal
+
+import "errors"
+
+// FuelType is the fuel type of a vehicle, one of the fuel types of the Vocabulary
+type FuelType string
+
+// Fuel types allowed by default
+const (
+	FuelTypeGasoline  FuelType = "gasoline"
+	FuelTypeDiesel    FuelType = "diesel"
+	FuelTypeBiodiesel FuelType = "biodiesel"
+	FuelTypeGas       FuelType = "gas"
+	FuelTypeElectric  FuelType = "electric"
+	FuelTypeHybrid    FuelType = "hybrid"
+)
+
+// Transmission is the transmission of a vehicle, one of the transmissions of the Vocabulary
+type Transmission string
+
+// Transmissions allowed by default
+const (
+	TransmissionManual        Transmission = "manual"
+	TransmissionAutomatic     Transmission = "automatic"
+	TransmissionSemiAutomatic Transmission = "semi-automatic"
+	TransmissionCVT           Transmission = "cvt"
+)
+
+// Vocabulary is an interface that represents the values allowed for the enumerated fields of the vehicles,
+// a value is allowed when it is compared as the same value as one of them
+type Vocabulary interface {
+	// FuelTypes is a method that returns the fuel types allowed
+	FuelTypes() (fuelTypes []FuelType)
+
+	// Transmissions is a method that returns the transmissions allowed
+	Transmissions() (transmissions []Transmission)
+
+	// CheckFuelType is a method that returns an error when a fuel type is empty or not allowed
+	CheckFuelType(fuelType FuelType) (err error)
+
+	// CheckTransmission is a method that returns an error when a transmission is empty or not allowed
+	CheckTransmission(transmission Transmission) (err error)
+}
+
+// Errors in vocabularies
+var (
+	ErrorInvalidVocabulary        = errors.New("Invalid vocabulary")
+	ErrorInvalidTransmissionValue = errors.New("Transmission type is invalid")
+)
@@ -0,0 +1,156 @@
+package vin
+
+import (
+	"app/internal"
+	"fmt"
+	"strings"
+)
+
+// Length is the length of a vehicle identification number
+const Length = 17
+
+// Model years of the tenth character: its codes stand for the years from FirstModelYear and repeat
+// every YearCycle years, decoded up to LastModelYear
+const (
+	FirstModelYear = 1980
+	YearCycle      = 30
+	LastModelYear  = 2099
+)
+
+// yearCodes are the codes of the tenth character in the order of the model years, from FirstModelYear
+const yearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// weights are the weights of the positions in the check digit, the ninth position is the check digit itself
+var weights = [Length]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// values are the values of the letters in the check digit, the digits are worth themselves.
+// I, O and Q are not used so they are not confused with 1 and 0
+var values = map[rune]int{
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
+
+// DefaultManufacturers are the brands of the world manufacturer identifiers (WMI) known by default,
+// by WMI. A WMI of a manufacturer selling several brands lists them all
+var DefaultManufacturers = map[string][]string{
+	"19U": {"Acura"}, "JH4": {"Acura"},
+	"SCF": {"Aston Martin"},
+	"WAU": {"Audi"}, "WA1": {"Audi"}, "TRU": {"Audi"},
+	"WBA": {"BMW"}, "WBS": {"BMW"}, "WBX": {"BMW"}, "4US": {"BMW"}, "5UX": {"BMW"}, "5UM": {"BMW"},
+	"SCB": {"Bentley"},
+	"1G4": {"Buick"}, "2G4": {"Buick"},
+	"1G6": {"Cadillac"}, "1GY": {"Cadillac"},
+	"1G1": {"Chevrolet"}, "1GC": {"Chevrolet"}, "1GN": {"Chevrolet"}, "2G1": {"Chevrolet"}, "3G1": {"Chevrolet"}, "3GN": {"Chevrolet"}, "KL1": {"Chevrolet"},
+	"1C3": {"Chrysler", "Dodge"}, "1C4": {"Chrysler", "Dodge", "Jeep"}, "2C3": {"Chrysler", "Dodge"},
+	"1B3": {"Dodge"}, "1B4": {"Dodge"}, "1B7": {"Dodge"}, "2B3": {"Dodge"}, "2B4": {"Dodge"}, "1D4": {"Dodge"}, "1D7": {"Dodge"}, "3D7": {"Dodge"},
+	"2E3": {"Eagle"},
+	"ZFF": {"Ferrari"},
+	"1FA": {"Ford"}, "1FB": {"Ford"}, "1FD": {"Ford"}, "1FM": {"Ford"}, "1FT": {"Ford"}, "2FA": {"Ford"}, "2FM": {"Ford"}, "2FT": {"Ford"}, "3FA": {"Ford"}, "WF0": {"Ford"},
+	"1GD": {"GMC"}, "1GK": {"GMC"}, "1GT": {"GMC"}, "2GT": {"GMC"}, "3GT": {"GMC"},
+	"1HG": {"Honda"}, "2HG": {"Honda"}, "5FN": {"Honda"}, "5J6": {"Honda"}, "JHL": {"Honda"}, "JHM": {"Honda"},
+	"137": {"Hummer"}, "5GR": {"Hummer"},
+	"KMH": {"Hyundai"}, "KM8": {"Hyundai"}, "5NP": {"Hyundai"},
+	"JNK": {"Infiniti"}, "JNR": {"Infiniti"}, "5N3": {"Infiniti"},
+	"JAA": {"Isuzu"}, "JAC": {"Isuzu"}, "4S2": {"Isuzu"},
+	"1J4": {"Jeep"}, "1J8": {"Jeep"},
+	"KNA": {"Kia"}, "KND": {"Kia"}, "5XY": {"Kia"},
+	"ZA9": {"Lamborghini"}, "ZHW": {"Lamborghini"},
+	"SAL": {"Land Rover"},
+	"JTH": {"Lexus"}, "JTJ": {"Lexus"}, "2T2": {"Lexus"},
+	"ZAM": {"Maserati"},
+	"JM1": {"Mazda"}, "JM3": {"Mazda"}, "1YV": {"Mazda"}, "4F2": {"Mazda"}, "4F4": {"Mazda"},
+	"WDB": {"Mercedes-Benz"}, "WDC": {"Mercedes-Benz"}, "WDD": {"Mercedes-Benz"}, "W1K": {"Mercedes-Benz"}, "4JG": {"Mercedes-Benz"},
+	"1ME": {"Mercury"}, "2ME": {"Mercury"}, "4M2": {"Mercury"},
+	"JA3": {"Mitsubishi"}, "JA4": {"Mitsubishi"}, "JA7": {"Mitsubishi"}, "4A3": {"Mitsubishi"}, "4A4": {"Mitsubishi"},
+	"JN1": {"Nissan"}, "JN8": {"Nissan"}, "1N4": {"Nissan"}, "1N6": {"Nissan"}, "3N1": {"Nissan"}, "5N1": {"Nissan"},
+	"1G3": {"Oldsmobile"}, "1GH": {"Oldsmobile"},
+	"1P3": {"Plymouth"}, "1P4": {"Plymouth"}, "2P4": {"Plymouth"},
+	"1G2": {"Pontiac"}, "2G2": {"Pontiac"}, "5Y2": {"Pontiac"},
+	"WP0": {"Porsche"}, "WP1": {"Porsche"},
+	"SCA": {"Rolls-Royce"},
+	"YS3": {"Saab"},
+	"1G8": {"Saturn"}, "5GZ": {"Saturn"},
+	"JF1": {"Subaru"}, "JF2": {"Subaru"}, "4S3": {"Subaru"}, "4S4": {"Subaru"},
+	"JS2": {"Suzuki"}, "JS3": {"Suzuki"}, "2S3": {"Suzuki"},
+	"JT2": {"Toyota"}, "JT3": {"Toyota"}, "JT4": {"Toyota"}, "JTD": {"Toyota"}, "JTE": {"Toyota"}, "JTM": {"Toyota"}, "JTN": {"Toyota"},
+	"2T1": {"Toyota"}, "4T1": {"Toyota"}, "4T3": {"Toyota"}, "5TD": {"Toyota"}, "5TF": {"Toyota"},
+	"WVW": {"Volkswagen"}, "WV1": {"Volkswagen"}, "WV2": {"Volkswagen"}, "1VW": {"Volkswagen"}, "3VW": {"Volkswagen"},
+	"YV1": {"Volvo"}, "YV4": {"Volvo"},
+}
+
+// NewDecoder is a function that returns a new instance of Decoder with the brands of manufacturers by WMI,
+// DefaultManufacturers if it is nil
+func NewDecoder(manufacturers map[string][]string) *Decoder {
+	if manufacturers == nil {
+		manufacturers = DefaultManufacturers
+	}
+	return &Decoder{manufacturers: manufacturers}
+}
+
+// Decoder is a struct that implements the VINDecoder interface. A VIN is normalized upper case without whitespace
+// and hyphens and must have 17 letters and digits but I, O and Q, with the check digit of the ninth position
+type Decoder struct {
+	// manufacturers are the brands by WMI
+	manufacturers map[string][]string
+}
+
+// Normalize is a method that returns the normalized form of a VIN
+func (d *Decoder) Normalize(vin string) (normalized string) {
+	return strings.Map(func(c rune) rune {
+		switch {
+		case c == ' ', c == '\t', c == '-':
+			return -1
+		case c >= 'a' && c <= 'z':
+			return c - 'a' + 'A'
+		}
+		return c
+	}, vin)
+}
+
+// Decode is a method that returns what a VIN tells about its vehicle, failing when it is malformed
+// or its check digit does not match
+func (d *Decoder) Decode(vin string) (info internal.VINInfo, err error) {
+	normalized := d.Normalize(vin)
+	if len(normalized) != Length {
+		err = fmt.Errorf("%w: %s must have %d characters", internal.ErrorInvalidVIN, normalized, Length)
+		return
+	}
+
+	// - check digit: the weighted sum of the values of the characters modulo 11, 10 is X
+	sum := 0
+	for i, c := range normalized {
+		value, ok := values[c]
+		switch {
+		case c >= '0' && c <= '9':
+			value = int(c - '0')
+		case !ok:
+			err = fmt.Errorf("%w: %s has %q, only letters but I, O and Q and digits are allowed", internal.ErrorInvalidVIN, normalized, c)
+			return
+		}
+		sum += value * weights[i]
+	}
+	check := byte('0' + sum%11)
+	if sum%11 == 10 {
+		check = 'X'
+	}
+	if normalized[8] != check {
+		err = fmt.Errorf("%w: %s has the check digit %c, %c expected", internal.ErrorInvalidVIN, normalized, normalized[8], check)
+		return
+	}
+
+	// - model year: the codes repeat every YearCycle years
+	info = internal.VINInfo{VIN: normalized, WMI: normalized[:3], Manufacturers: d.manufacturers[normalized[:3]]}
+	if i := strings.IndexByte(yearCodes, normalized[9]); i >= 0 {
+		for year := FirstModelYear + i; year <= LastModelYear; year += YearCycle {
+			info.ModelYears = append(info.ModelYears, year)
+		}
+	}
+	if len(info.ModelYears) == 0 {
+		err = fmt.Errorf("%w: %s has %q as model year, must be one of %s", internal.ErrorInvalidVIN, normalized, normalized[9], yearCodes)
+		info = internal.VINInfo{}
+		return
+	}
+
+	return
+}
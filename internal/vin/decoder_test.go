@@ -0,0 +1,108 @@
+package vin_test
+
+import (
+	"app/internal"
+	"app/internal/vin"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	cases := []struct {
+		name          string
+		vin           string
+		normalized    string
+		manufacturers []string
+		years         []int
+		err           error
+	}{
+		{
+			name:          "brand and model years",
+			vin:           "1HGCM82633A004352",
+			normalized:    "1HGCM82633A004352",
+			manufacturers: []string{"Honda"},
+			years:         []int{2003, 2033, 2063, 2093},
+		},
+		{
+			name:          "normalized first",
+			vin:           " 1hgcm826-33a004352\t",
+			normalized:    "1HGCM82633A004352",
+			manufacturers: []string{"Honda"},
+			years:         []int{2003, 2033, 2063, 2093},
+		},
+		{
+			name:          "WMI of several brands",
+			vin:           "1C4RJFAG0FC625797",
+			normalized:    "1C4RJFAG0FC625797",
+			manufacturers: []string{"Chrysler", "Dodge", "Jeep"},
+			years:         []int{1985, 2015, 2045, 2075},
+		},
+		{
+			name:       "check digit X",
+			vin:        "1M8GDM9AXKP042788",
+			normalized: "1M8GDM9AXKP042788",
+			years:      []int{1989, 2019, 2049, 2079},
+		},
+		{
+			name:          "European WMI",
+			vin:           "WBA3A5C53CF256985",
+			normalized:    "WBA3A5C53CF256985",
+			manufacturers: []string{"BMW"},
+			years:         []int{1982, 2012, 2042, 2072},
+		},
+		{name: "check digit mismatch", vin: "1HGCM82643A004352", err: internal.ErrorInvalidVIN},
+		{name: "too short", vin: "1HGCM82633A00435", err: internal.ErrorInvalidVIN},
+		{name: "too long", vin: "1HGCM82633A0043521", err: internal.ErrorInvalidVIN},
+		{name: "letter I", vin: "1HGCM8I633A004352", err: internal.ErrorInvalidVIN},
+		{name: "letter O", vin: "1HGCM82633AO04352", err: internal.ErrorInvalidVIN},
+		{name: "model year U", vin: "1HGCM8261UA004352", err: internal.ErrorInvalidVIN},
+		{name: "model year 0", vin: "1HGCM82690A004352", err: internal.ErrorInvalidVIN},
+	}
+
+	d := vin.NewDecoder(nil)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info, err := d.Decode(c.vin)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("error = %v, want %v", err, c.err)
+			}
+			if c.err != nil {
+				if info.VIN != "" {
+					t.Errorf("info = %+v, want the zero value", info)
+				}
+				return
+			}
+			if info.VIN != c.normalized || info.WMI != c.normalized[:3] {
+				t.Errorf("VIN and WMI = %s %s, want %s %s", info.VIN, info.WMI, c.normalized, c.normalized[:3])
+			}
+			if !slices.Equal(info.Manufacturers, c.manufacturers) {
+				t.Errorf("manufacturers = %v, want %v", info.Manufacturers, c.manufacturers)
+			}
+			if !slices.Equal(info.ModelYears, c.years) {
+				t.Errorf("model years = %v, want %v", info.ModelYears, c.years)
+			}
+		})
+	}
+}
+
+func TestNewDecoder_Manufacturers(t *testing.T) {
+	// - the manufacturers given replace the default ones
+	d := vin.NewDecoder(map[string][]string{"1M8": {"Motor Coach Industries"}})
+
+	info, err := d.Decode("1M8GDM9AXKP042788")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(info.Manufacturers, []string{"Motor Coach Industries"}) {
+		t.Errorf("manufacturers = %v, want the ones given", info.Manufacturers)
+	}
+
+	info, err = d.Decode("1HGCM82633A004352")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Manufacturers) != 0 {
+		t.Errorf("manufacturers = %v, want none", info.Manufacturers)
+	}
+}
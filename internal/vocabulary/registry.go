@@ -0,0 +1,120 @@
+package vocabulary
+
+import (
+	"app/internal"
+	"fmt"
+	"strings"
+)
+
+// DefaultFuelTypes are the fuel types allowed when none are configured
+var DefaultFuelTypes = []string{
+	string(internal.FuelTypeGasoline),
+	string(internal.FuelTypeDiesel),
+	string(internal.FuelTypeBiodiesel),
+	string(internal.FuelTypeGas),
+	string(internal.FuelTypeElectric),
+	string(internal.FuelTypeHybrid),
+}
+
+// DefaultTransmissions are the transmissions allowed when none are configured
+var DefaultTransmissions = []string{
+	string(internal.TransmissionManual),
+	string(internal.TransmissionAutomatic),
+	string(internal.TransmissionSemiAutomatic),
+	string(internal.TransmissionCVT),
+}
+
+// NewRegistry is a function that returns a new instance of Registry allowing the fuel types and transmissions
+// given, compared by their keys in nm. Each list must have a value and no two values with the same key
+func NewRegistry(nm internal.TextNormalizer, fuelTypes []string, transmissions []string) (r *Registry, err error) {
+	r = &Registry{nm: nm}
+	r.fuelTypes, r.fuelTypeKeys, err = terms(nm, internal.TextFieldFuelType, fuelTypes)
+	if err != nil {
+		return nil, err
+	}
+	r.transmissions, r.transmissionKeys, err = terms(nm, internal.TextFieldTransmission, transmissions)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Registry is a struct that implements the Vocabulary interface with lists of values fixed when it is built
+type Registry struct {
+	// nm is the normalizer that compares the values with the allowed ones
+	nm internal.TextNormalizer
+	// fuelTypes are the fuel types allowed, in the order given
+	fuelTypes []string
+	// fuelTypeKeys are the keys of the fuel types allowed
+	fuelTypeKeys map[string]bool
+	// transmissions are the transmissions allowed, in the order given
+	transmissions []string
+	// transmissionKeys are the keys of the transmissions allowed
+	transmissionKeys map[string]bool
+}
+
+// FuelTypes is a method that returns the fuel types allowed
+func (r *Registry) FuelTypes() (fuelTypes []internal.FuelType) {
+	fuelTypes = make([]internal.FuelType, 0, len(r.fuelTypes))
+	for _, value := range r.fuelTypes {
+		fuelTypes = append(fuelTypes, internal.FuelType(value))
+	}
+	return
+}
+
+// Transmissions is a method that returns the transmissions allowed
+func (r *Registry) Transmissions() (transmissions []internal.Transmission) {
+	transmissions = make([]internal.Transmission, 0, len(r.transmissions))
+	for _, value := range r.transmissions {
+		transmissions = append(transmissions, internal.Transmission(value))
+	}
+	return
+}
+
+// CheckFuelType is a method that returns an error when a fuel type is empty or not allowed
+func (r *Registry) CheckFuelType(fuelType internal.FuelType) (err error) {
+	switch {
+	case strings.TrimSpace(string(fuelType)) == "":
+		err = internal.ErrorInvalidFuelType
+	case !r.fuelTypeKeys[r.nm.Normalize(internal.TextFieldFuelType, string(fuelType))]:
+		err = fmt.Errorf("%w: must be one of %s", internal.ErrorInvalidFuelTypeUpdate, strings.Join(r.fuelTypes, ", "))
+	}
+	return
+}
+
+// CheckTransmission is a method that returns an error when a transmission is empty or not allowed
+func (r *Registry) CheckTransmission(transmission internal.Transmission) (err error) {
+	switch {
+	case strings.TrimSpace(string(transmission)) == "":
+		err = internal.ErrorInvalidTransmissionType
+	case !r.transmissionKeys[r.nm.Normalize(internal.TextFieldTransmission, string(transmission))]:
+		err = fmt.Errorf("%w: must be one of %s", internal.ErrorInvalidTransmissionValue, strings.Join(r.transmissions, ", "))
+	}
+	return
+}
+
+// terms is a function that returns the values of a field trimmed and their keys, failing on an empty list,
+// an empty value or two values with the same key
+func terms(nm internal.TextNormalizer, field string, values []string) (trimmed []string, keys map[string]bool, err error) {
+	if len(values) == 0 {
+		err = fmt.Errorf("%w: %s must have a value", internal.ErrorInvalidVocabulary, field)
+		return
+	}
+
+	keys = make(map[string]bool, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		key := nm.Normalize(field, value)
+		if key == "" {
+			err = fmt.Errorf("%w: %s has an empty value", internal.ErrorInvalidVocabulary, field)
+			return
+		}
+		if keys[key] {
+			err = fmt.Errorf("%w: %s has %q twice", internal.ErrorInvalidVocabulary, field, value)
+			return
+		}
+		keys[key] = true
+		trimmed = append(trimmed, value)
+	}
+	return
+}